@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	onesWords = []string{"zero", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine",
+		"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tensWords = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// numberWords spells out 0–99 in English, hyphenating compounds: "forty-two".
+func numberWords(n int) string {
+	if n < 20 {
+		return onesWords[n]
+	}
+	if n >= 100 {
+		return fmt.Sprint(n)
+	}
+	if n%10 == 0 {
+		return tensWords[n/10]
+	}
+	return tensWords[n/10] + "-" + onesWords[n%10]
+}
+
+// spokenTime reads a clock time the way it is said aloud: "three thirty two PM",
+// "six oh five AM", "noon".
+func spokenTime(t time.Time) string {
+	hour, minute := t.Hour(), t.Minute()
+	switch {
+	case hour == 0 && minute == 0:
+		return "midnight"
+	case hour == 12 && minute == 0:
+		return "noon"
+	}
+
+	period := "AM"
+	if hour >= 12 {
+		period = "PM"
+	}
+	hour %= 12
+	if hour == 0 {
+		hour = 12
+	}
+
+	words := numberWords(hour)
+	switch {
+	case minute == 0:
+	case minute < 10:
+		words += " oh " + numberWords(minute)
+	default:
+		words += " " + strings.ReplaceAll(numberWords(minute), "-", " ")
+	}
+	return words + " " + period
+}
+
+// durationWords spells out a duration to the minute: "forty-two minutes",
+// "one hour and five minutes". Seconds are rounded up like the countdown.
+func durationWords(d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	hours := minutes / 60
+	minutes %= 60
+
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return "one " + unit
+		}
+		return numberWords(n) + " " + unit + "s"
+	}
+	switch {
+	case hours == 0:
+		return plural(minutes, "minute")
+	case minutes == 0:
+		return plural(hours, "hour")
+	default:
+		return plural(hours, "hour") + " and " + plural(minutes, "minute")
+	}
+}
+
+// spokenRelative is "in forty-two minutes" or "ten minutes ago".
+func spokenRelative(d time.Duration) string {
+	if d < 0 {
+		return durationWords((-d).Truncate(time.Minute)) + " ago"
+	}
+	if d < time.Minute {
+		return "now"
+	}
+	return "in " + durationWords(d)
+}
+
+// speakPrayerTimes prints today's timings as one plain sentence per line, without
+// emoji, box drawing, or tables, for screen readers.
+func speakPrayerTimes(city string, data *PrayerTimesResponse, cfg Config, today bool, moment string) {
+	date := data.Data.Date
+	hijri, other := cfg.hijriDates(date)
+	fmt.Printf("Prayer times for %s, %s %s %s %s, %s %s %s AH.\n", city,
+		date.Gregorian.Weekday.En, strings.TrimLeft(date.Gregorian.Day, "0"), date.Gregorian.Month.En, date.Gregorian.Year,
+		strings.TrimLeft(hijri.Day, "0"), hijri.Month.En, hijri.Year)
+	if other != nil {
+		fmt.Printf("In the %s calendar it is %s %s.\n", tr("en", cfg.otherHijriCalendar()), strings.TrimLeft(other.Day, "0"), other.Month.En)
+	}
+
+	timings := map[string]string{
+		"Fajr":    data.Data.Timings.Fajr,
+		"Sunrise": data.Data.Timings.Sunrise,
+		"Dhuhr":   data.Data.Timings.Dhuhr,
+		"Asr":     data.Data.Timings.Asr,
+		"Maghrib": data.Data.Timings.Maghrib,
+		"Isha":    data.Data.Timings.Isha,
+	}
+	for _, prayer := range prayerOrder {
+		t, err := parseTime(timings[prayer])
+		if err != nil {
+			continue
+		}
+		fmt.Printf("%s at %s.\n", prayer, spokenTime(t))
+	}
+
+	if next, at, err := findNextPrayer(data.Data.Timings); today && err == nil && next != "Sunrise" {
+		if d := at.Sub(clock.Now()); d > 0 {
+			fmt.Printf("Next prayer: %s at %s, %s.\n", next, spokenTime(at), spokenRelative(d))
+		}
+	}
+	if moment != "" {
+		fmt.Printf("%s.\n", moment)
+	}
+	fmt.Printf("Method: %s.\n", data.Data.Meta.Method.Name)
+}
+
+// speakNextPrayer is the screen reader form of pray next.
+func speakNextPrayer(city string, data *PrayerTimesResponse, next string, at time.Time, opts nextOptions) {
+	if opts.Context {
+		prev, start, end, err := currentPrayerAt(data.Data.Timings, clock.Now())
+		if err == nil {
+			fmt.Printf("Previous prayer: %s at %s, %s.\n", prev, spokenTime(start), spokenRelative(start.Sub(clock.Now())))
+			if until := end.Sub(clock.Now()); until > 0 {
+				fmt.Printf("%s time ends %s.\n", prev, spokenRelative(until))
+			} else {
+				fmt.Printf("%s time ended at %s.\n", prev, spokenTime(end))
+			}
+		}
+	}
+
+	if d := at.Sub(clock.Now()); d > 0 {
+		fmt.Printf("Next prayer: %s at %s, %s.\n", next, spokenTime(at), spokenRelative(d))
+	} else {
+		fmt.Printf("It is time for %s.\n", next)
+	}
+	fmt.Printf("Location: %s.\n", city)
+}
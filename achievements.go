@@ -0,0 +1,226 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// achievement is a badge earned from the prayer log. progress reports how far a user is
+// towards it, and it's earned when have reaches need.
+type achievement struct {
+	ID          string
+	Badge       string
+	Name        string
+	Description string
+	progress    func(s *Store, user string) (have, need int)
+}
+
+var achievements = []achievement{
+	{
+		ID: "first-prayer", Badge: "🌱", Name: "First Step",
+		Description: "Log your first prayer",
+		progress: func(s *Store, user string) (int, int) {
+			for _, p := range s.Prayers {
+				if p.User == user && p.Status != statusMissed {
+					return 1, 1
+				}
+			}
+			return 0, 1
+		},
+	},
+	{
+		ID: "streak-7", Badge: "🔥", Name: "Steadfast Week",
+		Description: "Pray all five prayers 7 days in a row",
+		progress: func(s *Store, user string) (int, int) {
+			return s.longestStreak(user), 7
+		},
+	},
+	{
+		ID: "streak-30", Badge: "🏆", Name: "Steadfast Month",
+		Description: "Pray all five prayers 30 days in a row",
+		progress: func(s *Store, user string) (int, int) {
+			return s.longestStreak(user), 30
+		},
+	},
+	{
+		ID: "fajr-30", Badge: "🌅", Name: "Early Riser",
+		Description: "Pray Fajr on time 30 times",
+		progress: func(s *Store, user string) (int, int) {
+			n := 0
+			for _, p := range s.Prayers {
+				if p.User == user && p.Prayer == "Fajr" && p.Status == statusPrayed {
+					n++
+				}
+			}
+			return n, 30
+		},
+	},
+	{
+		ID: "full-ramadan", Badge: "🌙", Name: "Full Ramadan",
+		Description: "Pray all five prayers every day of Ramadan",
+		progress: func(s *Store, user string) (int, int) {
+			return s.bestRamadan(user)
+		},
+	},
+	{
+		ID: "unhurried-10", Badge: "⏱️", Name: "Unhurried",
+		Description: "Take at least your target time over 10 timed prayers",
+		progress: func(s *Store, user string) (int, int) {
+			n := 0
+			for _, t := range s.TimerSessions {
+				if t.User == user && t.Activity != timerAthkar && t.Target > 0 && t.Ended.Sub(t.Started) >= time.Duration(t.Target)*time.Minute {
+					n++
+				}
+			}
+			return n, 10
+		},
+	},
+}
+
+// longestStreak is a user's longest run of days with all five prayers.
+func (s *Store) longestStreak(user string) int {
+	var days []string
+	for date := range s.completeDays(user) {
+		days = append(days, date)
+	}
+	sort.Strings(days)
+
+	longest, run := 0, 0
+	var prev time.Time
+	for _, date := range days {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		if run > 0 && daysBetween(prev, day) == 1 {
+			run++
+		} else {
+			run = 1
+		}
+		prev = day
+		if run > longest {
+			longest = run
+		}
+	}
+	return longest
+}
+
+// bestRamadan is the most days of a single Ramadan with all five prayers, and that
+// Ramadan's length. Months follow the tabular Hijri calendar, so a locally sighted
+// Ramadan can start or end a day apart.
+func (s *Store) bestRamadan(user string) (int, int) {
+	counts := map[int]int{}
+	for date := range s.completeDays(user) {
+		day, err := time.Parse("2006-01-02", date)
+		if err != nil {
+			continue
+		}
+		if h := tabularHijri(day); h.Month == 9 {
+			counts[h.Year]++
+		}
+	}
+
+	// With nothing logged in Ramadan yet, measure against this Hijri year's
+	year := tabularHijri(clock.Now()).Year
+	best := 0
+	for y, n := range counts {
+		if n > best {
+			year, best = y, n
+		}
+	}
+	return best, HijriDate{Year: year, Month: 9, Day: 1}.monthLength()
+}
+
+// unlockAchievements records achievements a user has newly earned and returns them.
+func (s *Store) unlockAchievements(user string, now time.Time) []achievement {
+	earned := map[string]bool{}
+	for _, b := range s.Badges {
+		if b.User == user {
+			earned[b.ID] = true
+		}
+	}
+
+	var unlocked []achievement
+	for _, a := range achievements {
+		if earned[a.ID] {
+			continue
+		}
+		if have, need := a.progress(s, user); have >= need {
+			s.Badges = append(s.Badges, EarnedBadge{User: user, ID: a.ID, Earned: now})
+			unlocked = append(unlocked, a)
+		}
+	}
+	return unlocked
+}
+
+// announceAchievements tells a user about newly earned badges, and sends them through
+// the notification backends when announce_badges is set.
+func announceAchievements(ctx context.Context, user string, unlocked []achievement, cfg Config) {
+	if len(unlocked) == 0 {
+		return
+	}
+
+	var notifiers []notifier
+	if cfg.AnnounceBadges {
+		var err error
+		if notifiers, err = newNotifiers(cfg); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		}
+	}
+
+	for _, a := range unlocked {
+		title := fmt.Sprintf("%s Badge unlocked: %s", a.Badge, a.Name)
+		if user != "" {
+			title = fmt.Sprintf("%s %s unlocked %s", a.Badge, capitalize(user), a.Name)
+		}
+		fmt.Println(nextPrayerStyle.Render(title))
+
+		n := notification{Title: title, Body: a.Description, Speech: title}
+		for _, d := range dispatch(ctx, notifiers, n) {
+			if d.Err != nil {
+				fmt.Println(prayerStyle.Render(fmt.Sprintf("(%s: %v)", d.Notifier, d.Err)))
+			}
+		}
+	}
+}
+
+// showBadges lists every achievement with when it was earned or the progress towards it.
+func showBadges(user string) error {
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+
+	title := "🎖️  Badges"
+	if user != "" {
+		title += " for " + user
+	}
+	fmt.Println(titleStyle.Render(title))
+	fmt.Println(strings.Repeat("━", 50))
+
+	earned := map[string]time.Time{}
+	for _, b := range store.Badges {
+		if b.User == user {
+			earned[b.ID] = b.Earned
+		}
+	}
+
+	for _, a := range achievements {
+		name := padRight(a.Badge+" "+a.Name, 20)
+		if at, ok := earned[a.ID]; ok {
+			fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s earned %s", name, at.Format("02 Jan 2006"))))
+			continue
+		}
+		have, need := a.progress(store, user)
+		if have > need {
+			have = need
+		}
+		bar := strings.Repeat("▰", have*10/need) + strings.Repeat("▱", 10-have*10/need)
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %s %d/%d", name, bar, have, need)))
+		fmt.Println(cityStyle.PaddingLeft(25).Render(a.Description))
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// With nothing logged in Ramadan, the badge measures against the Ramadan of the Hijri
+// year the clock is in; days logged either side of it don't count.
+func TestBestRamadan(t *testing.T) {
+	useClock(t, newFakeClock(time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)))
+
+	var s Store
+	if best, length := s.bestRamadan(""); best != 0 || length != 30 {
+		t.Errorf("bestRamadan with nothing logged = %d of %d, want 0 of 30", best, length)
+	}
+
+	// The last of Sha'ban and the first two days of Ramadan 1446
+	for _, date := range []string{"2025-02-28", "2025-03-01", "2025-03-02"} {
+		for _, prayer := range trackedPrayers {
+			s.Prayers = append(s.Prayers, PrayerLog{Date: date, Prayer: prayer, Status: statusPrayed})
+		}
+	}
+	if best, length := s.bestRamadan(""); best != 2 || length != 30 {
+		t.Errorf("bestRamadan = %d of %d, want 2 of 30", best, length)
+	}
+}
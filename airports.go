@@ -0,0 +1,63 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+//go:embed data/airports.json
+var airportsData []byte
+
+// Airport is an airport in the embedded database, by its IATA code
+type Airport struct {
+	Code      string  `json:"code"`
+	Name      string  `json:"name"`
+	City      string  `json:"city"`
+	Country   string  `json:"country"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone"`
+}
+
+var loadAirports = sync.OnceValues(func() (map[string]Airport, error) {
+	var airports []Airport
+	if err := json.Unmarshal(airportsData, &airports); err != nil {
+		return nil, fmt.Errorf("failed to decode airports: %v", err)
+	}
+	byCode := make(map[string]Airport, len(airports))
+	for _, a := range airports {
+		byCode[a.Code] = a
+	}
+	return byCode, nil
+})
+
+// lookupAirport finds an airport by its IATA code. Codes are taken in capitals only, so
+// Fez the city isn't mistaken for FEZ the airport.
+func lookupAirport(code string) (Airport, bool) {
+	if len(code) != 3 {
+		return Airport{}, false
+	}
+	airports, err := loadAirports()
+	if err != nil {
+		return Airport{}, false
+	}
+	a, ok := airports[code]
+	return a, ok
+}
+
+// city is the airport as a place in the city database: at the airport, named for the
+// city it serves.
+func (a Airport) city() City {
+	return City{Name: a.City, Country: a.Country, Latitude: a.Latitude, Longitude: a.Longitude, Timezone: a.Timezone}
+}
+
+// byLocation is the API path and query for a city, like timingsByCity/17-10-2026?city=…,
+// or for an airport code, the coordinates endpoint at the airport.
+func byLocation(endpoint, path, city, country string) string {
+	if a, ok := lookupAirport(city); ok {
+		return fmt.Sprintf("%s%s?latitude=%.4f&longitude=%.4f", endpoint, path, a.Latitude, a.Longitude)
+	}
+	return fmt.Sprintf("%sByCity%s?city=%s&country=%s", endpoint, path, city, country)
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Names the alarm is scheduled under, so it can be replaced and cancelled
+const (
+	alarmUnit  = "pray-alarm"
+	alarmLabel = "com.github.isibra.pray.alarm"
+	alarmQueue = "p"
+)
+
+// Alarm defaults, used when the config leaves them out
+const (
+	defaultAlarmRounds = 5
+	alarmRoundPause    = 2 * time.Second
+)
+
+// alarmTimes returns when to ring for the prayer on each of the next days, skipping
+// alarms that are already past. Each day's time comes from that day's timings.
+func alarmTimes(ctx context.Context, prayer string, before time.Duration, days int, city, country string, method int, cfg Config) ([]time.Time, error) {
+	now := clock.Now()
+	calendars := map[[2]int]*CalendarResponse{}
+
+	var times []time.Time
+	for i := 0; len(times) < days; i++ {
+		day := addDays(now, i)
+		key := [2]int{day.Year(), int(day.Month())}
+		calendar, ok := calendars[key]
+		if !ok {
+			var err error
+			calendar, err = fetchCalendar(ctx, city, country, method, cfg, day.Year(), int(day.Month()))
+			if err != nil {
+				return nil, err
+			}
+			calendars[key] = calendar
+		}
+		if day.Day() > len(calendar.Data) {
+			return nil, fmt.Errorf("no timings for %s", day.Format("2006-01-02"))
+		}
+
+		timings := calendar.Data[day.Day()-1].Timings
+		prayerTimes := map[string]string{
+			"Fajr":    timings.Fajr,
+			"Dhuhr":   timings.Dhuhr,
+			"Asr":     timings.Asr,
+			"Maghrib": timings.Maghrib,
+			"Isha":    timings.Isha,
+		}
+		at, err := parseTimeOn(prayerTimes[prayer], day)
+		if err != nil {
+			return nil, err
+		}
+		if alarm := at.Add(-before); alarm.After(now) {
+			times = append(times, alarm)
+		}
+	}
+	return times, nil
+}
+
+// setAlarm schedules `pray alarm ring` at each time with the system scheduler, replacing
+// any alarm set before, and returns the scheduler used.
+func setAlarm(ctx context.Context, prayer string, before time.Duration, times []time.Time) (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the pray binary: %v", err)
+	}
+	args := []string{exe, "alarm", "ring", prayer, "--before", before.String()}
+
+	cancelAlarm(ctx)
+
+	switch runtime.GOOS {
+	case "darwin":
+		return "launchd", setLaunchdAlarm(ctx, args, times)
+	case "windows":
+		return "", fmt.Errorf("alarms are not supported on Windows; schedule %q with Task Scheduler instead", strings.Join(args, " "))
+	}
+
+	if _, err := exec.LookPath("systemd-run"); err == nil {
+		return "systemd", setSystemdAlarm(ctx, args, times)
+	}
+	if _, err := exec.LookPath("at"); err == nil {
+		return "at", setAtAlarm(ctx, args, times)
+	}
+	return "", fmt.Errorf("no scheduler found (install systemd or at)")
+}
+
+// setSystemdAlarm starts a transient user timer with one OnCalendar per alarm.
+func setSystemdAlarm(ctx context.Context, args []string, times []time.Time) error {
+	cmdArgs := []string{"--user", "--unit=" + alarmUnit, "--description=pray alarm", "--timer-property=AccuracySec=1s"}
+	for _, t := range times {
+		cmdArgs = append(cmdArgs, "--timer-property=OnCalendar="+t.Format("2006-01-02 15:04:05"))
+	}
+	if path := os.Getenv("PRAY_CONFIG"); path != "" {
+		cmdArgs = append(cmdArgs, "--setenv=PRAY_CONFIG="+path)
+	}
+	cmdArgs = append(cmdArgs, "--")
+	cmdArgs = append(cmdArgs, args...)
+
+	return runScheduler(exec.CommandContext(ctx, "systemd-run", cmdArgs...))
+}
+
+// setAtAlarm queues one at job per alarm in pray's own queue.
+func setAtAlarm(ctx context.Context, args []string, times []time.Time) error {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+	}
+	command := strings.Join(quoted, " ")
+
+	for _, t := range times {
+		cmd := exec.CommandContext(ctx, "at", "-q", alarmQueue, "-t", t.Format("200601021504"))
+		cmd.Stdin = strings.NewReader(command + "\n")
+		if err := runScheduler(cmd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setLaunchdAlarm writes a launch agent with a calendar interval per alarm and loads it.
+func setLaunchdAlarm(ctx context.Context, args []string, times []time.Time) error {
+	path, err := launchAgentPath()
+	if err != nil {
+		return err
+	}
+
+	var plist strings.Builder
+	plist.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>` + alarmLabel + `</string>
+	<key>ProgramArguments</key>
+	<array>
+`)
+	for _, arg := range args {
+		fmt.Fprintf(&plist, "\t\t<string>%s</string>\n", xmlEscape(arg))
+	}
+	plist.WriteString("\t</array>\n")
+	if configPath := os.Getenv("PRAY_CONFIG"); configPath != "" {
+		fmt.Fprintf(&plist, "\t<key>EnvironmentVariables</key>\n\t<dict>\n\t\t<key>PRAY_CONFIG</key>\n\t\t<string>%s</string>\n\t</dict>\n", xmlEscape(configPath))
+	}
+	plist.WriteString("\t<key>StartCalendarInterval</key>\n\t<array>\n")
+	for _, t := range times {
+		fmt.Fprintf(&plist, "\t\t<dict><key>Month</key><integer>%d</integer><key>Day</key><integer>%d</integer><key>Hour</key><integer>%d</integer><key>Minute</key><integer>%d</integer></dict>\n",
+			t.Month(), t.Day(), t.Hour(), t.Minute())
+	}
+	plist.WriteString("\t</array>\n</dict>\n</plist>\n")
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create %s: %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(plist.String()), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return runScheduler(exec.CommandContext(ctx, "launchctl", "load", "-w", path))
+}
+
+func launchAgentPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %v", err)
+	}
+	return filepath.Join(home, "Library", "LaunchAgents", alarmLabel+".plist"), nil
+}
+
+func xmlEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// cancelAlarm removes a scheduled alarm, if there is one.
+func cancelAlarm(ctx context.Context) {
+	switch runtime.GOOS {
+	case "darwin":
+		if path, err := launchAgentPath(); err == nil {
+			exec.CommandContext(ctx, "launchctl", "unload", "-w", path).Run()
+			os.Remove(path)
+		}
+		return
+	case "windows":
+		return
+	}
+
+	if _, err := exec.LookPath("systemctl"); err == nil {
+		exec.CommandContext(ctx, "systemctl", "--user", "stop", alarmUnit+".timer").Run()
+		exec.CommandContext(ctx, "systemctl", "--user", "reset-failed", alarmUnit+".service").Run()
+	}
+	if _, err := exec.LookPath("atq"); err == nil {
+		out, err := exec.CommandContext(ctx, "atq", "-q", alarmQueue).Output()
+		if err != nil {
+			return
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				exec.CommandContext(ctx, "atrm", fields[0]).Run()
+			}
+		}
+	}
+}
+
+// runScheduler runs a scheduler command, including its output in the error.
+func runScheduler(cmd *exec.Cmd) error {
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s failed: %v: %s", filepath.Base(cmd.Path), err, msg)
+		}
+		return fmt.Errorf("%s failed: %v", filepath.Base(cmd.Path), err)
+	}
+	return nil
+}
+
+// ringAlarm notifies and plays the alarm sound louder each round, so it wakes without
+// starting at full volume.
+func ringAlarm(ctx context.Context, prayer string, before time.Duration, cfg Config) error {
+	name := localPrayerName(cfg.Language, prayer)
+	title := tr(cfg.Language, "alarm_title", name)
+	body := tr(cfg.Language, "alarm_body", name, int(before.Minutes()))
+
+	fmt.Println(nextPrayerStyle.Render(title))
+	if err := sendNotification(ctx, title, body); err != nil {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+	}
+
+	sound := expandHome(cfg.Alarm.Sound)
+	if sound == "" {
+		sound = cfg.sound(prayer)
+	}
+	if sound == "" {
+		return fmt.Errorf("no alarm sound configured (set alarm.sound or sounds.default)")
+	}
+
+	rounds := cfg.Alarm.Rounds
+	if rounds <= 0 {
+		rounds = defaultAlarmRounds
+	}
+	for round := 1; round <= rounds; round++ {
+		cmd, err := soundCommandAt(ctx, sound, float64(round)/float64(rounds))
+		if err != nil {
+			return err
+		}
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to play %s: %v", sound, err)
+		}
+		if round < rounds && sleepContext(ctx, alarmRoundPause) != nil {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// prayerPeriod names the part of the day now falls in by its prayer: the prayer whose
+// time it is, or Duha between sunrise and Dhuhr, when Fajr's time is over.
+func prayerPeriod(timings Timings, now time.Time) (string, error) {
+	prayer, _, end, err := currentPrayerAt(timings, now)
+	if err != nil {
+		return "", err
+	}
+	if prayer == "Fajr" && !now.Before(end) {
+		return "Duha", nil
+	}
+	return prayer, nil
+}
+
+// annotation is the tag put before a journal entry, like [Asr · 5 Jumādá al-ūlá, 1448 AH].
+func annotation(data *PrayerTimesResponse, cfg Config) (string, error) {
+	period, err := prayerPeriod(data.Data.Timings, cityNow(data.Data))
+	if err != nil {
+		return "", fmt.Errorf("failed to work out the prayer period: %v", err)
+	}
+	return fmt.Sprintf("[%s · %s]", period, cfg.hijriText(cfg.hijriDate(data.Data.Date), true)), nil
+}
+
+// runAnnotate writes text with the current prayer period and Hijri date before its first
+// line, so it can sit in a pipe into a journaling tool like jrnl.
+func runAnnotate(ctx context.Context, text io.Reader, city, country string, method int, cfg Config, out io.Writer) error {
+	raw, err := io.ReadAll(text)
+	if err != nil {
+		return fmt.Errorf("failed to read text: %v", err)
+	}
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	tag, err := annotation(data, cfg)
+	if err != nil {
+		return err
+	}
+
+	entry := strings.TrimLeft(string(raw), "\n")
+	if entry == "" {
+		_, err = fmt.Fprintln(out, tag)
+		return err
+	}
+	if !strings.HasSuffix(entry, "\n") {
+		entry += "\n"
+	}
+	_, err = fmt.Fprintf(out, "%s %s", tag, entry)
+	return err
+}
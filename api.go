@@ -0,0 +1,286 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Where requests go unless api_url says otherwise
+const defaultAPIURL = "http://api.aladhan.com/v1"
+
+// apiEnvelope is the outer shape of every aladhan response. Data is decoded later because
+// the API returns an object, an array (calendar endpoints), or a string (error messages).
+type apiEnvelope struct {
+	Code   flexInt         `json:"code"`
+	Status string          `json:"status"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// apiStatusError is an answer from the API with a status other than 200
+type apiStatusError struct {
+	Status  int
+	URL     string
+	Message string
+}
+
+func (e *apiStatusError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("API returned status %d for URL: %s: %s", e.Status, e.URL, e.Message)
+	}
+	return fmt.Sprintf("API returned status %d for URL: %s", e.Status, e.URL)
+}
+
+// newStatusError keeps the error text the API sent with a failed response, if it decoded.
+func newStatusError(status int, url string, envelope *apiEnvelope, decodeErr error) error {
+	err := &apiStatusError{Status: status, URL: url}
+	if decodeErr == nil {
+		err.Message = envelope.message()
+	}
+	return err
+}
+
+func decodeAPIResponse(body io.Reader) (*apiEnvelope, error) {
+	var envelope apiEnvelope
+	if err := json.NewDecoder(body).Decode(&envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	return &envelope, nil
+}
+
+// message returns the error text the API puts in data, if any.
+func (e *apiEnvelope) message() string {
+	var msg string
+	if json.Unmarshal(e.Data, &msg) == nil {
+		return msg
+	}
+	return ""
+}
+
+// day returns a single day of timings. If the API answered with a list, today's entry is used.
+func (e *apiEnvelope) day() (Data, error) {
+	days, err := e.days()
+	if err != nil {
+		return Data{}, err
+	}
+
+	day := days[0]
+	if len(days) > 1 {
+		today := clock.Now().Format("02-01-2006")
+		for _, d := range days {
+			if d.Date.Gregorian.Date == today {
+				day = d
+				break
+			}
+		}
+	}
+
+	if err := validateData(day); err != nil {
+		return Data{}, fmt.Errorf("invalid API response: %v", err)
+	}
+	return day, nil
+}
+
+// days returns every day in the response, accepting a single object as a one-day list.
+func (e *apiEnvelope) days() ([]Data, error) {
+	raw := bytes.TrimSpace(e.Data)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, fmt.Errorf("invalid API response: field data missing")
+	}
+
+	switch raw[0] {
+	case '"':
+		return nil, fmt.Errorf("API error: %s", e.message())
+	case '{':
+		var day Data
+		if err := json.Unmarshal(raw, &day); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %v", err)
+		}
+		return []Data{day}, nil
+	case '[':
+		var days []Data
+		if err := json.Unmarshal(raw, &days); err != nil {
+			return nil, fmt.Errorf("failed to decode response: %v", err)
+		}
+		if len(days) == 0 {
+			return nil, fmt.Errorf("invalid API response: field data is empty")
+		}
+		return days, nil
+	default:
+		return nil, fmt.Errorf("invalid API response: unexpected data %.40s", raw)
+	}
+}
+
+// validateData rejects responses that would otherwise show up as 00:00 timings or bogus countdowns.
+func validateData(d Data) error {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"Fajr", d.Timings.Fajr},
+		{"Sunrise", d.Timings.Sunrise},
+		{"Dhuhr", d.Timings.Dhuhr},
+		{"Asr", d.Timings.Asr},
+		{"Maghrib", d.Timings.Maghrib},
+		{"Isha", d.Timings.Isha},
+	}
+
+	for _, field := range fields {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("field timings.%s missing", field.name)
+		}
+		clock := strings.Split(strings.TrimSpace(field.value), " ")[0]
+		if _, err := time.Parse("15:04", clock); err != nil {
+			return fmt.Errorf("field timings.%s has invalid time %q", field.name, field.value)
+		}
+	}
+	return nil
+}
+
+// flexInt accepts 4, "4", "", and null.
+type flexInt int
+
+func (f *flexInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("expected a number, got %s", b)
+	}
+	*f = flexInt(n)
+	return nil
+}
+
+// flexFloat accepts 24.7, "24.7", "", and null.
+type flexFloat float64
+
+func (f *flexFloat) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("expected a number, got %s", b)
+	}
+	*f = flexFloat(n)
+	return nil
+}
+
+// flexString accepts "12", 12, and null.
+type flexString string
+
+func (f *flexString) UnmarshalJSON(b []byte) error {
+	if string(b) == "null" {
+		*f = ""
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*f = flexString(s)
+		return nil
+	}
+	*f = flexString(strings.TrimSpace(string(b)))
+	return nil
+}
+
+// The types below decode through an alias with the loosely typed fields shadowed by flex types.
+
+func (m *Month) UnmarshalJSON(b []byte) error {
+	type alias Month
+	raw := struct {
+		*alias
+		Number flexInt `json:"number"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	m.Number = int(raw.Number)
+	return nil
+}
+
+func (m *Method) UnmarshalJSON(b []byte) error {
+	type alias Method
+	raw := struct {
+		*alias
+		Id     flexInt         `json:"id"`
+		Params json.RawMessage `json:"params"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	m.Id = int(raw.Id)
+
+	// Params is sometimes an empty list instead of an object
+	m.Params = nil
+	if params := bytes.TrimSpace(raw.Params); len(params) > 0 && params[0] == '{' {
+		if err := json.Unmarshal(params, &m.Params); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Meta) UnmarshalJSON(b []byte) error {
+	type alias Meta
+	raw := struct {
+		*alias
+		Latitude  flexFloat `json:"latitude"`
+		Longitude flexFloat `json:"longitude"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	m.Latitude, m.Longitude = float64(raw.Latitude), float64(raw.Longitude)
+	return nil
+}
+
+func (l *Location) UnmarshalJSON(b []byte) error {
+	type alias Location
+	raw := struct {
+		*alias
+		Latitude  flexFloat `json:"latitude"`
+		Longitude flexFloat `json:"longitude"`
+	}{alias: (*alias)(l)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	l.Latitude, l.Longitude = float64(raw.Latitude), float64(raw.Longitude)
+	return nil
+}
+
+func (h *Hijri) UnmarshalJSON(b []byte) error {
+	type alias Hijri
+	raw := struct {
+		*alias
+		Day  flexString `json:"day"`
+		Year flexString `json:"year"`
+	}{alias: (*alias)(h)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	h.Day, h.Year = string(raw.Day), string(raw.Year)
+	return nil
+}
+
+func (g *Gregorian) UnmarshalJSON(b []byte) error {
+	type alias Gregorian
+	raw := struct {
+		*alias
+		Day  flexString `json:"day"`
+		Year flexString `json:"year"`
+	}{alias: (*alias)(g)}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	g.Day, g.Year = string(raw.Day), string(raw.Year)
+	return nil
+}
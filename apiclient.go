@@ -0,0 +1,266 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Client-side limits that keep batch operations under the public API's rate limits
+const (
+	apiRate       = 4 // requests per second
+	apiBurst      = 4
+	apiMaxRetries = 3
+)
+
+// Deadline for a single HTTP request to the API
+const apiTimeout = 15 * time.Second
+
+// Deadline for a shared fetch, retries included. It runs apart from any one caller's
+// context, so a caller giving up doesn't fail the others waiting on it.
+const apiCallTimeout = 2 * time.Minute
+
+// Idle connections kept open to the API, enough for batch and multi-city fan-out to reuse
+// them instead of reconnecting
+const apiIdleConns = 8
+
+// rateLimiter is a token bucket shared by every request in the process.
+type rateLimiter struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+}
+
+func newRateLimiter(rate, burst float64) *rateLimiter {
+	return &rateLimiter{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a request may be sent or ctx is done.
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+
+		if now.Before(l.pausedUntil) {
+			delay := l.pausedUntil.Sub(now)
+			l.mu.Unlock()
+			if err := sleepContext(ctx, delay); err != nil {
+				return err
+			}
+			continue
+		}
+
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+		if err := sleepContext(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// pause holds back all requests, used when the server asks us to slow down.
+func (l *rateLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+	l.tokens = 0
+}
+
+// apiCall is an in-flight request that concurrent callers for the same URL wait on.
+type apiCall struct {
+	done   chan struct{}
+	status int
+	body   []byte
+	err    error
+}
+
+type apiClient struct {
+	client  *http.Client
+	limiter *rateLimiter
+	cache   responseCache
+
+	mu    sync.Mutex
+	calls map[string]*apiCall
+}
+
+var api = &apiClient{
+	client:  &http.Client{Transport: apiTransport()},
+	limiter: newRateLimiter(apiRate, apiBurst),
+	cache:   &diskCache{},
+	calls:   map[string]*apiCall{},
+}
+
+// get fetches a URL through the rate limiter. Identical concurrent requests share one call,
+// which each caller waits on until it finishes or the caller's own ctx is done.
+func (c *apiClient) get(ctx context.Context, url string) (int, []byte, error) {
+	c.mu.Lock()
+	call, ok := c.calls[url]
+	if !ok {
+		call = &apiCall{done: make(chan struct{})}
+		c.calls[url] = call
+		go c.run(context.WithoutCancel(ctx), url, call)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.status, call.body, call.err
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+}
+
+// run makes a shared call and hands its result to everyone waiting on it.
+func (c *apiClient) run(ctx context.Context, url string, call *apiCall) {
+	ctx, cancel := context.WithTimeout(ctx, apiCallTimeout)
+	defer cancel()
+
+	call.status, call.body, call.err = c.fetch(ctx, url)
+
+	c.mu.Lock()
+	delete(c.calls, url)
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// apiTransport keeps connections to the API alive between requests. It leaves
+// Accept-Encoding alone so responses come gzipped and are unzipped transparently.
+func apiTransport() http.RoundTripper {
+	base, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	transport := base.Clone()
+	transport.MaxIdleConnsPerHost = apiIdleConns
+	transport.IdleConnTimeout = 90 * time.Second
+	return transport
+}
+
+// fetch answers from the cache while a response is fresh. Otherwise it asks the server,
+// revalidating a cached response with its ETag or Last-Modified so an unchanged one costs
+// a 304 instead of the whole body.
+func (c *apiClient) fetch(ctx context.Context, url string) (int, []byte, error) {
+	cached := c.cache.load(url)
+	if cached != nil && cached.fresh(time.Now()) {
+		return http.StatusOK, cached.Body, nil
+	}
+
+	// Another process may be fetching the same URL; wait for it and use its response
+	waitStart := time.Now()
+	unlock, err := c.cache.lock(ctx, url)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer unlock()
+	if cached = c.cache.load(url); cached != nil && (cached.fresh(time.Now()) || !cached.Fetched.Before(waitStart)) {
+		return http.StatusOK, cached.Body, nil
+	}
+
+	if cached != nil && !cached.revalidates() {
+		cached = nil
+	}
+
+	backoff := time.Second
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return 0, nil, err
+		}
+
+		resp, body, err := c.do(ctx, url, cached)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && cached != nil:
+			cached.update(resp.Header, time.Now())
+			c.cache.store(cached)
+			return http.StatusOK, cached.Body, nil
+		case resp.StatusCode == http.StatusOK && !noStore(resp.Header):
+			fetched := &cachedResponse{URL: url, Body: body}
+			fetched.update(resp.Header, time.Now())
+			c.cache.store(fetched)
+		}
+
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+		if !retryable || attempt == apiMaxRetries {
+			return resp.StatusCode, body, nil
+		}
+
+		delay := retryAfter(resp.Header.Get("Retry-After"), backoff)
+		c.limiter.pause(delay)
+		backoff *= 2
+	}
+}
+
+// do sends a single request with its own deadline and reads the whole body, which lets
+// the connection be reused. A cached response's validators make the request conditional.
+func (c *apiClient) do(ctx context.Context, url string, cached *cachedResponse) (*http.Response, []byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %v", err)
+	}
+	return resp, body, nil
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP date.
+func retryAfter(header string, fallback time.Duration) time.Duration {
+	if header == "" {
+		return fallback
+	}
+	if seconds, err := strconv.Atoi(header); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if at, err := http.ParseTime(header); err == nil {
+		if d := time.Until(at); d > 0 {
+			return d
+		}
+		return 0
+	}
+	return fallback
+}
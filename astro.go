@@ -0,0 +1,111 @@
+package main
+
+import (
+	"math"
+	"time"
+)
+
+// Astronomical helpers based on Jean Meeus, "Astronomical Algorithms" (2nd ed.)
+
+// Mean length of the lunar month in days
+const synodicMonth = 29.530588861
+
+// Approximate difference between Terrestrial Time and UT in recent years
+const deltaT = 69 * time.Second
+
+const unixEpochJD = 2440587.5
+
+// Seconds rather than UnixNano, which only reaches the years 1678 to 2262
+func julianDay(t time.Time) float64 {
+	return (float64(t.Unix())+float64(t.Nanosecond())/1e9)/86400 + unixEpochJD
+}
+
+func fromJulianDay(jd float64) time.Time {
+	seconds := (jd - unixEpochJD) * 86400
+	whole := math.Floor(seconds)
+	return time.Unix(int64(whole), int64((seconds-whole)*1e9)).UTC()
+}
+
+func sinDeg(d float64) float64 { return math.Sin(d * math.Pi / 180) }
+func cosDeg(d float64) float64 { return math.Cos(d * math.Pi / 180) }
+
+// newMoon returns the instant of the true new moon for lunation k (k = 0 is 6 Jan 2000).
+// Planetary perturbations are left out, which keeps the result within about a minute.
+func newMoon(k float64) time.Time {
+	T := k / 1236.85
+	T2, T3, T4 := T*T, T*T*T, T*T*T*T
+
+	jde := 2451550.09766 + synodicMonth*k + 0.00015437*T2 - 0.000000150*T3 + 0.00000000073*T4
+
+	E := 1 - 0.002516*T - 0.0000074*T2
+	M := 2.5534 + 29.10535670*k - 0.0000014*T2 - 0.00000011*T3
+	Mp := 201.5643 + 385.81693528*k + 0.0107582*T2 + 0.00001238*T3 - 0.000000058*T4
+	F := 160.7108 + 390.67050284*k - 0.0016118*T2 - 0.00000227*T3 + 0.000000011*T4
+	O := 124.7746 - 1.56375588*k + 0.0020672*T2 + 0.00000215*T3
+
+	jde += -0.40720*sinDeg(Mp) +
+		0.17241*E*sinDeg(M) +
+		0.01608*sinDeg(2*Mp) +
+		0.01039*sinDeg(2*F) +
+		0.00739*E*sinDeg(Mp-M) -
+		0.00514*E*sinDeg(Mp+M) +
+		0.00208*E*E*sinDeg(2*M) -
+		0.00111*sinDeg(Mp-2*F) -
+		0.00057*sinDeg(Mp+2*F) +
+		0.00056*E*sinDeg(2*Mp+M) -
+		0.00042*sinDeg(3*Mp) +
+		0.00042*E*sinDeg(M+2*F) +
+		0.00038*E*sinDeg(M-2*F) -
+		0.00024*E*sinDeg(2*Mp-M) -
+		0.00017*sinDeg(O) -
+		0.00007*sinDeg(Mp+2*M) +
+		0.00004*sinDeg(2*Mp-2*F) +
+		0.00004*sinDeg(3*M) +
+		0.00003*sinDeg(Mp+M-2*F) +
+		0.00003*sinDeg(2*Mp+2*F) -
+		0.00003*sinDeg(Mp+M+2*F) +
+		0.00003*sinDeg(Mp-M+2*F) -
+		0.00002*sinDeg(Mp-M-2*F) -
+		0.00002*sinDeg(3*Mp+M) +
+		0.00002*sinDeg(4*Mp)
+
+	return fromJulianDay(jde).Add(-deltaT)
+}
+
+// surroundingNewMoons returns the last new moon at or before t and the first one after it.
+func surroundingNewMoons(t time.Time) (time.Time, time.Time) {
+	k := math.Floor((julianDay(t) - 2451550.09766) / synodicMonth)
+
+	prev := newMoon(k)
+	for prev.After(t) {
+		k--
+		prev = newMoon(k)
+	}
+
+	next := newMoon(k + 1)
+	for !next.After(t) {
+		k++
+		prev, next = next, newMoon(k+1)
+	}
+	return prev, next
+}
+
+// moonIllumination returns the illuminated fraction of the lunar disk (0–1).
+func moonIllumination(t time.Time) float64 {
+	T := (julianDay(t.Add(deltaT)) - 2451545.0) / 36525
+
+	D := 297.8501921 + 445267.1114034*T
+	M := 357.5291092 + 35999.0502909*T
+	Mp := 134.9633964 + 477198.8675055*T
+
+	// Phase angle
+	i := 180 - D -
+		6.289*sinDeg(Mp) +
+		2.100*sinDeg(M) -
+		1.274*sinDeg(2*D-Mp) -
+		0.658*sinDeg(2*D) -
+		0.214*sinDeg(2*Mp) -
+		0.110*sinDeg(D)
+
+	return (1 + cosDeg(i)) / 2
+}
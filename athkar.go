@@ -0,0 +1,94 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed data/athkar.json
+var athkarData []byte
+
+// Dhikr is a single remembrance with its recommended repetition count
+type Dhikr struct {
+	Arabic          string `json:"arabic"`
+	Transliteration string `json:"transliteration"`
+	Translation     string `json:"translation"`
+	Count           int    `json:"count"`
+	Reference       string `json:"reference"`
+}
+
+// Athkar categories in display order
+var athkarCategories = []string{"morning", "evening", "after-prayer"}
+
+var athkarTitles = map[string]string{
+	"morning":      "🌄 Morning Athkar",
+	"evening":      "🌆 Evening Athkar",
+	"after-prayer": "📿 Athkar After Prayer",
+}
+
+func newAthkarCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       "athkar [morning|evening|after-prayer]",
+		Short:     "Show adhkar with Arabic text, transliteration, and translation",
+		Long:      "Show adhkar for the morning, evening, or after prayer. Without an argument, morning adhkar are shown before noon and evening adhkar after.",
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: athkarCategories,
+		Run: func(cmd *cobra.Command, args []string) {
+			category := "morning"
+			if clock.Now().Hour() >= 12 {
+				category = "evening"
+			}
+			if len(args) > 0 {
+				category = args[0]
+			}
+
+			if err := showAthkar(category); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func loadAthkar() (map[string][]Dhikr, error) {
+	var athkar map[string][]Dhikr
+	if err := json.Unmarshal(athkarData, &athkar); err != nil {
+		return nil, fmt.Errorf("failed to decode athkar data: %v", err)
+	}
+	return athkar, nil
+}
+
+func showAthkar(category string) error {
+	athkar, err := loadAthkar()
+	if err != nil {
+		return err
+	}
+
+	list, ok := athkar[category]
+	if !ok {
+		return fmt.Errorf("unknown athkar category %q (expected one of: %s)", category, strings.Join(athkarCategories, ", "))
+	}
+
+	// Header
+	fmt.Println(titleStyle.Render(athkarTitles[category]))
+	fmt.Println(strings.Repeat("━", 50))
+
+	for _, dhikr := range list {
+		fmt.Println()
+		fmt.Println(nextPrayerStyle.Render(dhikr.Arabic))
+		fmt.Println(prayerStyle.Render(dhikr.Transliteration))
+		fmt.Println(cityStyle.PaddingLeft(2).Render(dhikr.Translation))
+
+		repeat := fmt.Sprintf("×%d", dhikr.Count)
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("%s  · %s", timeStyle.Render(repeat), dhikr.Reference)))
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("━", 50))
+	return nil
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// API token scopes. write includes read.
+const (
+	scopeRead  = "read"
+	scopeWrite = "write"
+)
+
+// APIToken lets a household member use the tracking endpoints of pray serve
+type APIToken struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	Scope string `yaml:"scope"`
+}
+
+// validateTokens checks every token has a secret and a known scope.
+func validateTokens(tokens []APIToken) error {
+	for i, t := range tokens {
+		if t.Token == "" {
+			return fmt.Errorf("api_tokens[%d] (%s) has no token", i, t.Name)
+		}
+		if t.Scope != scopeRead && t.Scope != scopeWrite {
+			return fmt.Errorf("api_tokens[%d] (%s) has unknown scope %q (expected read or write)", i, t.Name, t.Scope)
+		}
+	}
+	return nil
+}
+
+// requestToken is the bearer token of a request, or the token query parameter for
+// devices that can't set headers.
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return r.URL.Query().Get("token")
+}
+
+// tokenFor finds the configured token a request presents, comparing in constant time.
+func (s *server) tokenFor(r *http.Request) (APIToken, bool) {
+	presented := requestToken(r)
+	if presented == "" {
+		return APIToken{}, false
+	}
+	for _, t := range s.cfg.APITokens {
+		if subtle.ConstantTimeCompare([]byte(presented), []byte(t.Token)) == 1 {
+			return t, true
+		}
+	}
+	return APIToken{}, false
+}
+
+// requireScope guards a tracking endpoint. Timings stay public; with api_tokens set, the
+// prayer log needs a read token to view and a write token to change. Without tokens the
+// endpoints are open, for a trusted home network.
+func (s *server) requireScope(scope string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if len(s.cfg.APITokens) == 0 {
+			next(w, r)
+			return
+		}
+
+		token, ok := s.tokenFor(r)
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="pray"`)
+			writeError(w, http.StatusUnauthorized, fmt.Errorf("a valid API token is required"))
+			return
+		}
+		if scope == scopeWrite && token.Scope != scopeWrite {
+			writeError(w, http.StatusForbidden, fmt.Errorf("token %q is read-only", token.Name))
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// batchResult is one line of pray batch output. Lines that fail carry the error instead
+// of timings, so one bad city doesn't stop the rest.
+type batchResult struct {
+	Line     int               `json:"line"`
+	City     string            `json:"city"`
+	Country  string            `json:"country"`
+	Method   int               `json:"method"`
+	Date     string            `json:"date,omitempty"`
+	Timezone string            `json:"timezone,omitempty"`
+	Timings  map[string]string `json:"timings,omitempty"`
+	Error    string            `json:"error,omitempty"`
+}
+
+// batchQuery is a location read from the input
+type batchQuery struct {
+	Line    int
+	City    string
+	Country string
+	Method  int
+	Err     error
+}
+
+// readBatch parses city[,country][,method] lines. Blank lines and # comments are
+// skipped; missing fields fall back to the defaults.
+func readBatch(r io.Reader, country string, method int) ([]batchQuery, error) {
+	var queries []batchQuery
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		q := batchQuery{Line: line, City: fields[0], Country: country, Method: method}
+		if len(fields) > 1 && fields[1] != "" {
+			q.Country = fields[1]
+		}
+		if len(fields) > 2 && fields[2] != "" {
+			m, err := strconv.Atoi(fields[2])
+			if err != nil {
+				q.Err = fmt.Errorf("invalid method %q", fields[2])
+			} else {
+				q.Method = m
+			}
+		}
+		if len(fields) > 3 {
+			q.Err = fmt.Errorf("expected city[,country][,method], got %d fields", len(fields))
+		}
+		if q.City == "" {
+			q.Err = fmt.Errorf("missing city")
+		}
+		queries = append(queries, q)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read input: %v", err)
+	}
+	return queries, nil
+}
+
+// runBatch fetches today's timings for every location in the input, up to concurrency at
+// a time, and writes a JSON result per line in input order. It reports whether every
+// line succeeded.
+func runBatch(ctx context.Context, r io.Reader, w io.Writer, country string, method, concurrency int, cfg Config) (bool, error) {
+	if concurrency < 1 {
+		return false, fmt.Errorf("--concurrency must be at least 1")
+	}
+	queries, err := readBatch(r, country, method)
+	if err != nil {
+		return false, err
+	}
+
+	results := make([]chan batchResult, len(queries))
+	slots := make(chan struct{}, concurrency)
+	for i, q := range queries {
+		results[i] = make(chan batchResult, 1)
+		go func(q batchQuery, out chan<- batchResult) {
+			result := batchResult{Line: q.Line, City: q.City, Country: q.Country, Method: q.Method}
+			if q.Err != nil {
+				result.Error = q.Err.Error()
+				out <- result
+				return
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				result.Error = ctx.Err().Error()
+				out <- result
+				return
+			}
+			data, err := fetchPrayerTimes(ctx, q.City, q.Country, q.Method, cfg)
+			<-slots
+
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				t := data.Data.Timings
+				result.Date = isoDate(data.Data.Date.Gregorian.Date)
+				result.Timezone = data.Data.Meta.Timezone
+				result.Timings = map[string]string{
+					"Fajr":    strings.Split(t.Fajr, " ")[0],
+					"Sunrise": strings.Split(t.Sunrise, " ")[0],
+					"Dhuhr":   strings.Split(t.Dhuhr, " ")[0],
+					"Asr":     strings.Split(t.Asr, " ")[0],
+					"Maghrib": strings.Split(t.Maghrib, " ")[0],
+					"Isha":    strings.Split(t.Isha, " ")[0],
+				}
+			}
+			out <- result
+		}(q, results[i])
+	}
+
+	// Stream results in input order as they come in
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	ok := true
+	for _, ch := range results {
+		result := <-ch
+		if result.Error != "" {
+			ok = false
+		}
+		if err := encoder.Encode(result); err != nil {
+			return false, err
+		}
+	}
+	return ok, nil
+}
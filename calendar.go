@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CalendarResponse is the API response for a month of timings
+type CalendarResponse struct {
+	Code   int    `json:"code"`
+	Status string `json:"status"`
+	Data   []Data `json:"data"`
+}
+
+func fetchCalendar(ctx context.Context, city, country string, method int, cfg Config, year, month int) (*CalendarResponse, error) {
+	url := fmt.Sprintf("%s/%s&method=%d", cfg.apiURL(), byLocation("calendar", fmt.Sprintf("/%d/%d", year, month), city, country), method)
+	url += cfg.apiParams()
+
+	status, body, err := api.get(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch calendar: %v", err)
+	}
+
+	envelope, decodeErr := decodeAPIResponse(bytes.NewReader(body))
+	if status != http.StatusOK {
+		return nil, cityError(newStatusError(status, url, envelope, decodeErr), city, country)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+
+	days, err := envelope.days()
+	if err != nil {
+		return nil, err
+	}
+	for i, day := range days {
+		if err := validateData(day); err != nil {
+			return nil, fmt.Errorf("invalid API response: day %d: %v", i+1, err)
+		}
+	}
+
+	return &CalendarResponse{Code: int(envelope.Code), Status: envelope.Status, Data: days}, nil
+}
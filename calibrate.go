@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// Calculation methods tried by pray calibrate unless --methods is given
+var calibrationMethods = []int{1, 2, 3, 4, 5, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23}
+
+// Prayers a mosque announces, in the order they are entered
+var announcedPrayers = []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"}
+
+// announcedDay is one day of times announced by a mosque
+type announcedDay struct {
+	Date  time.Time
+	Times map[string]time.Time
+}
+
+// methodFit is how closely a method matches the announced times, in minutes
+type methodFit struct {
+	Method int
+	Name   string
+	RMS    float64
+	Max    float64
+	Tune   map[string]int
+	Tuned  float64
+}
+
+// readAnnouncedDays reads lines of "YYYY-MM-DD Fajr Dhuhr Asr Maghrib Isha" (HH:MM),
+// skipping blank lines and # comments. In a terminal a blank line ends the input.
+func readAnnouncedDays(r io.Reader, interactive bool) ([]announcedDay, error) {
+	var days []announcedDay
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" && interactive {
+			break
+		}
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) != 1+len(announcedPrayers) {
+			return nil, fmt.Errorf("line %d: expected a date and %d times, got %q", line, len(announcedPrayers), text)
+		}
+		date, err := time.ParseInLocation("2006-01-02", fields[0], time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid date %q (expected YYYY-MM-DD)", line, fields[0])
+		}
+
+		day := announcedDay{Date: date, Times: map[string]time.Time{}}
+		for i, prayer := range announcedPrayers {
+			t, err := parseTimeOn(fields[i+1], date)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s time %q (expected HH:MM)", line, prayer, fields[i+1])
+			}
+			day.Times[prayer] = t
+		}
+		days = append(days, day)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return days, nil
+}
+
+// methodDiffs returns how many minutes each recorded time is after the method's timing
+// for the same day, per prayer, and the method's name. Configured tune offsets are
+// applied, so pass a config without them to compare against the method itself.
+func methodDiffs(ctx context.Context, method int, days []announcedDay, city, country string, cfg Config) (map[string][]float64, string, error) {
+	calendars := map[[2]int]*CalendarResponse{}
+	diffs := map[string][]float64{}
+	name := ""
+
+	for _, day := range days {
+		key := [2]int{day.Date.Year(), int(day.Date.Month())}
+		calendar, ok := calendars[key]
+		if !ok {
+			var err error
+			calendar, err = fetchCalendar(ctx, city, country, method, cfg, day.Date.Year(), int(day.Date.Month()))
+			if err != nil {
+				return nil, "", err
+			}
+			calendars[key] = calendar
+		}
+		if day.Date.Day() > len(calendar.Data) {
+			return nil, "", fmt.Errorf("no timings for %s", day.Date.Format("2006-01-02"))
+		}
+		data := calendar.Data[day.Date.Day()-1]
+		name = data.Meta.Method.Name
+		if err := addDayDiffs(diffs, day, data.Timings); err != nil {
+			return nil, "", err
+		}
+	}
+	return diffs, name, nil
+}
+
+// addDayDiffs adds how far each of a day's recorded times is from the API's timings, in
+// minutes, to diffs.
+func addDayDiffs(diffs map[string][]float64, day announcedDay, timings Timings) error {
+	apiTimes := map[string]string{
+		"Fajr":    timings.Fajr,
+		"Sunrise": timings.Sunrise,
+		"Dhuhr":   timings.Dhuhr,
+		"Asr":     timings.Asr,
+		"Maghrib": timings.Maghrib,
+		"Isha":    timings.Isha,
+	}
+	for prayer, recorded := range day.Times {
+		timing, err := parseTimeOn(apiTimes[prayer], day.Date)
+		if err != nil {
+			return err
+		}
+		diffs[prayer] = append(diffs[prayer], recorded.Sub(timing).Minutes())
+	}
+	return nil
+}
+
+// fitMethod compares the API's timings for a method with the announced times. The tune
+// offset for a prayer is the constant shift that fits best, its mean difference rounded
+// to a minute; the method's own parameters, like its twilight angles, aren't fitted.
+func fitMethod(ctx context.Context, method int, days []announcedDay, city, country string, cfg Config) (methodFit, error) {
+	// Calibrate against the method itself, not the offsets already configured
+	cfg.Tune = nil
+
+	fit := methodFit{Method: method, Tune: map[string]int{}}
+	diffs, name, err := methodDiffs(ctx, method, days, city, country, cfg)
+	if err != nil {
+		return fit, err
+	}
+	fit.Name = name
+
+	var sumSquares, tunedSquares float64
+	var n int
+	for _, prayer := range announcedPrayers {
+		var sum float64
+		for _, d := range diffs[prayer] {
+			sum += d
+			sumSquares += d * d
+			fit.Max = math.Max(fit.Max, math.Abs(d))
+		}
+		offset := int(math.Round(sum / float64(len(diffs[prayer]))))
+		if offset != 0 {
+			fit.Tune[prayer] = offset
+		}
+		for _, d := range diffs[prayer] {
+			r := d - float64(offset)
+			tunedSquares += r * r
+		}
+		n += len(diffs[prayer])
+	}
+	fit.RMS = math.Sqrt(sumSquares / float64(n))
+	fit.Tuned = math.Sqrt(tunedSquares / float64(n))
+	return fit, nil
+}
+
+// rankMethods fits each method to the announced times, the closest first.
+func rankMethods(ctx context.Context, methods []int, days []announcedDay, city, country string, cfg Config) ([]methodFit, error) {
+	var fits []methodFit
+	for _, method := range methods {
+		fit, err := fitMethod(ctx, method, days, city, country, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("method %d: %v", method, err)
+		}
+		fits = append(fits, fit)
+	}
+	sort.SliceStable(fits, func(i, j int) bool { return fits[i].RMS < fits[j].RMS })
+	return fits, nil
+}
+
+// runCalibrate reads announced times and suggests the method and tune offsets that fit them best.
+func runCalibrate(ctx context.Context, path string, methods []int, city, country string, cfg Config) error {
+	var input io.Reader = os.Stdin
+	interactive := false
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %v", path, err)
+		}
+		defer f.Close()
+		input = f
+	} else if term.IsTerminal(os.Stdin.Fd()) {
+		interactive = true
+		fmt.Println(titleStyle.Render("🎯 Calibrate against your mosque"))
+		fmt.Println(strings.Repeat("━", 50))
+		fmt.Println(prayerStyle.Render("Enter one day per line: date, then Fajr Dhuhr Asr Maghrib Isha"))
+		fmt.Println(cityStyle.PaddingLeft(2).Render("2026-03-14 05:02 12:09 15:33 18:12 19:42"))
+		fmt.Println(prayerStyle.Render("A few days is enough. Finish with an empty line."))
+		fmt.Println()
+	}
+
+	days, err := readAnnouncedDays(input, interactive)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("no announced times to calibrate against")
+	}
+
+	fits, err := rankMethods(ctx, methods, days, city, country, cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🎯 Best methods for %s over %d days", cityStyle.Render(city), len(days))))
+	fmt.Println(strings.Repeat("━", 50))
+	for i, fit := range fits {
+		if i == 5 {
+			break
+		}
+		line := fmt.Sprintf("#%-3d %-40.40s %s", fit.Method, fit.Name, timeStyle.Render(fmt.Sprintf("±%.1fm (max %.0fm)", fit.RMS, fit.Max)))
+		if i == 0 {
+			fmt.Println(nextPrayerStyle.Render(line))
+		} else {
+			fmt.Println(prayerStyle.Render(line))
+		}
+	}
+
+	best := fits[0]
+	fmt.Println()
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("With these offsets the times are within ±%.1fm on average. Add to your config:", best.Tuned)))
+	fmt.Println()
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("method: %d", best.Method)))
+	if len(best.Tune) > 0 {
+		fmt.Println(cityStyle.PaddingLeft(2).Render("tune:"))
+		for _, prayer := range announcedPrayers {
+			if offset, ok := best.Tune[prayer]; ok {
+				fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("  %s: %d", prayer, offset)))
+			}
+		}
+	}
+	return nil
+}
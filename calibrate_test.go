@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// A mosque in Riyadh announcing Fajr about two minutes and Maghrib one minute after the
+// Umm al-Qura calendar in testdata
+const riyadhAnnounced = `# date Fajr Dhuhr Asr Maghrib Isha
+2024-03-01 05:00 12:07 15:26 17:57 19:26
+2024-03-02 04:59 12:06 15:26 17:57 19:26
+2024-03-03 04:58 12:06 15:27 17:58 19:27
+2024-03-04 04:57 12:06 15:27 17:58 19:27
+2024-03-05 04:56 12:06 15:27 18:02 19:31
+`
+
+func TestRankMethods(t *testing.T) {
+	var requests int
+	cfg := useAPI(t, serveCalendar(t, &requests))
+	days, err := readAnnouncedDays(strings.NewReader(riyadhAnnounced), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fits, err := rankMethods(context.Background(), []int{2, 4}, days, "Riyadh", "SA", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fits) != 2 {
+		t.Fatalf("got %d fits, want 2", len(fits))
+	}
+	best := fits[0]
+	if best.Method != 4 || fits[1].Method != 2 {
+		t.Fatalf("ranked methods %d, %d, want 4, 2", best.Method, fits[1].Method)
+	}
+	if want := map[string]int{"Fajr": 2, "Maghrib": 1}; !reflect.DeepEqual(best.Tune, want) {
+		t.Errorf("tune = %v, want %v", best.Tune, want)
+	}
+	// 25 differences: Fajr 2, 2, 2, 3, 2, Asr one of 1, and Maghrib 1 on all five days
+	if want := math.Sqrt(31.0 / 25); math.Abs(best.RMS-want) > 1e-9 {
+		t.Errorf("RMS = %v, want %v", best.RMS, want)
+	}
+	// Once tuned, only Fajr's 3 and Asr's 1 are a minute off
+	if want := math.Sqrt(2.0 / 25); math.Abs(best.Tuned-want) > 1e-9 {
+		t.Errorf("tuned RMS = %v, want %v", best.Tuned, want)
+	}
+	if best.Max != 3 {
+		t.Errorf("max = %v, want 3", best.Max)
+	}
+}
+
+func TestRankMethodsUnknownMethod(t *testing.T) {
+	var requests int
+	cfg := useAPI(t, serveCalendar(t, &requests))
+	days, err := readAnnouncedDays(strings.NewReader(riyadhAnnounced), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rankMethods(context.Background(), []int{4, 99}, days, "Riyadh", "SA", cfg); err == nil {
+		t.Errorf("rankMethods succeeded, want an error for a method the API doesn't answer")
+	}
+}
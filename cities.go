@@ -0,0 +1,236 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//go:embed data/cities.json
+var citiesData []byte
+
+// How many spellings an unknown city error suggests
+const maxCitySuggestions = 5
+
+// City is a city in the embedded database, with the other spellings it goes by
+type City struct {
+	Name      string   `json:"name"`
+	Country   string   `json:"country"`
+	Latitude  float64  `json:"latitude"`
+	Longitude float64  `json:"longitude"`
+	Timezone  string   `json:"timezone"`
+	Aliases   []string `json:"aliases,omitempty"`
+	Capital   bool     `json:"capital,omitempty"`
+}
+
+// loadCities decodes the city database the first time it's needed, so commands that
+// never look a city up don't pay for it, and batch lookups decode it only once.
+var loadCities = sync.OnceValues(func() ([]City, error) {
+	var cities []City
+	if err := json.Unmarshal(citiesData, &cities); err != nil {
+		return nil, fmt.Errorf("failed to decode cities: %v", err)
+	}
+	return cities, nil
+})
+
+// spellings is the city's name followed by its aliases.
+func (c City) spellings() []string {
+	return append([]string{c.Name}, c.Aliases...)
+}
+
+// lookupCity finds a city by any of its spellings, preferring the given country, or by
+// an airport code, at the airport.
+func lookupCity(name, country string) (City, bool) {
+	if a, ok := lookupAirport(name); ok {
+		return a.city(), true
+	}
+	cities, err := loadCities()
+	if err != nil {
+		return City{}, false
+	}
+	query := normalizeCity(name)
+	var found City
+	ok := false
+	for _, c := range cities {
+		for _, spelling := range c.spellings() {
+			if normalizeCity(spelling) != query {
+				continue
+			}
+			if strings.EqualFold(c.Country, country) {
+				return c, true
+			}
+			if !ok {
+				found, ok = c, true
+			}
+		}
+	}
+	return found, ok
+}
+
+// countryCity picks a city for a country given without one: its capital, or where the
+// database doesn't have the capital, the first of the country's cities, which are listed
+// biggest first.
+func countryCity(country string) (City, bool) {
+	cities, err := loadCities()
+	if err != nil {
+		return City{}, false
+	}
+	var found City
+	ok := false
+	for _, c := range cities {
+		if !strings.EqualFold(c.Country, country) {
+			continue
+		}
+		if c.Capital {
+			return c, true
+		}
+		if !ok {
+			found, ok = c, true
+		}
+	}
+	return found, ok
+}
+
+// unknownCityError is the API not finding a city, with spellings it might know instead
+type unknownCityError struct {
+	City        string
+	Country     string
+	Suggestions []string
+	More        bool
+}
+
+func (e *unknownCityError) Error() string {
+	msg := fmt.Sprintf("no prayer times found for %q in %s", e.City, e.Country)
+	if len(e.Suggestions) == 0 {
+		return msg + "; check the spelling, or try the nearest large city"
+	}
+	msg += "; did you mean: " + strings.Join(e.Suggestions, ", ")
+	if e.More {
+		msg += "…"
+	}
+	return msg
+}
+
+// cityError turns the API rejecting a city into suggestions from the city database.
+// Network failures and server errors are passed through unchanged.
+func cityError(err error, city, country string) error {
+	var statusErr *apiStatusError
+	if !errors.As(err, &statusErr) || statusErr.Status < http.StatusBadRequest || statusErr.Status >= http.StatusInternalServerError {
+		return err
+	}
+	suggestions, more := suggestCities(city, country)
+	return &unknownCityError{City: city, Country: country, Suggestions: suggestions, More: more}
+}
+
+// suggestCities finds the cities closest to a misspelt name and lists their spellings,
+// best match first. Cities in another country than the one asked for are marked with
+// theirs, since the country may be what's wrong.
+func suggestCities(city, country string) ([]string, bool) {
+	cities, err := loadCities()
+	if err != nil {
+		return nil, false
+	}
+
+	query := normalizeCity(city)
+	if query == "" {
+		return nil, false
+	}
+	// Allow about one typo in every three letters
+	threshold := max(1, len([]rune(query))/3)
+
+	type match struct {
+		city     City
+		distance int
+	}
+	var matches []match
+	for _, c := range cities {
+		best := -1
+		for _, spelling := range c.spellings() {
+			name := normalizeCity(spelling)
+			d := editDistance(query, name)
+			// A prefix is as good as a typo, so "Kuala" finds Kuala Lumpur
+			if len([]rune(query)) >= 3 && strings.HasPrefix(name, query) {
+				d = min(d, 1)
+			}
+			if best < 0 || d < best {
+				best = d
+			}
+		}
+		if best <= threshold {
+			matches = append(matches, match{city: c, distance: best})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		// Prefer the country that was asked for
+		return strings.EqualFold(matches[i].city.Country, country) && !strings.EqualFold(matches[j].city.Country, country)
+	})
+
+	var suggestions []string
+	for _, m := range matches {
+		for i, spelling := range m.city.spellings() {
+			// Don't suggest what was just tried
+			if normalizeCity(spelling) == query && strings.EqualFold(m.city.Country, country) {
+				continue
+			}
+			if i == 0 && !strings.EqualFold(m.city.Country, country) {
+				spelling += " (" + m.city.Country + ")"
+			}
+			suggestions = append(suggestions, spelling)
+		}
+	}
+	if len(suggestions) > maxCitySuggestions {
+		return suggestions[:maxCitySuggestions], true
+	}
+	return suggestions, false
+}
+
+// normalizeCity lowercases a city name and drops spaces and punctuation, so "Ar-Riyadh"
+// and "ar riyadh" compare equal.
+func normalizeCity(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// editDistance counts the insertions, deletions, substitutions, and swaps of neighbouring
+// letters that turn a into b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// rows[i][j] is the distance between the first i runes of s and the first j of t
+	rows := make([][]int, len(s)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(t)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(s)][len(t)]
+}
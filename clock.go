@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Clock tells the time and waits. Everything that asks what day or time it is for the
+// prayers, the countdowns, and the daemon's scheduler goes through it rather than the time
+// package, so pray daemon --simulate can run the scheduler on a clock of its own, and
+// midnight, a clock change, or a day in Ramadan can be replayed at will. Timestamps of
+// when something happened, network deadlines, and rate limits stay on the real clock.
+type Clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+// clock is the system clock, unless a simulation has swapped in a fakeClock
+var clock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
+func (systemClock) Sleep(ctx context.Context, d time.Duration) error { return sleepContext(ctx, d) }
+
+// fakeClock stands still until something sleeps on it, when it jumps ahead at once.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	return nil
+}
+
+// Defaults for the clock check
+const (
+	defaultNTPServer      = "pool.ntp.org:123"
+	defaultClockThreshold = time.Minute
+)
+
+// Seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// clockCheckURL is the fallback for the clock check where NTP is blocked: the root of the
+// prayer times API's host, for its Date header.
+func clockCheckURL(cfg Config) string {
+	u, err := url.Parse(cfg.apiURL())
+	if err != nil {
+		return cfg.apiURL()
+	}
+	return u.Scheme + "://" + u.Host + "/"
+}
+
+func newClockCmd(cfg Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clock",
+		Short: "Check the system clock against NTP",
+		Long:  "Compare the system clock with an NTP server (or, where NTP is blocked, the prayer times API's Date header). A skewed clock makes countdowns and notifications wrong without any other sign. Exits 1 when the clock is off by more than clock_skew_threshold.",
+		Run: func(cmd *cobra.Command, args []string) {
+			offset, source, err := clockSkew(cmd.Context(), cfg)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println(titleStyle.Render("⏱️  Clock check"))
+			fmt.Println(strings.Repeat("━", 50))
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("Offset from %s: %s", source, formatOffset(offset))))
+			if warning := cfg.clockWarning(offset); warning != "" {
+				fmt.Println(countdownStyle.Render("⚠️  " + warning))
+				os.Exit(1)
+			}
+			fmt.Println(cityStyle.Render(fmt.Sprintf("✓ Within %s", formatDuration(cfg.clockThreshold()))))
+		},
+	}
+}
+
+// clockThreshold is how far the clock may drift before pray warns.
+func (c Config) clockThreshold() time.Duration {
+	if c.ClockSkewThreshold > 0 {
+		return c.ClockSkewThreshold
+	}
+	return defaultClockThreshold
+}
+
+// clockWarning describes a skew beyond the threshold, or is empty when the clock is fine.
+func (c Config) clockWarning(offset time.Duration) string {
+	skew := offset
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew <= c.clockThreshold() {
+		return ""
+	}
+	direction := "behind"
+	if offset < 0 {
+		direction = "ahead"
+	}
+	return fmt.Sprintf("The system clock is %s %s, so prayer times and notifications will be off. Turn on automatic time sync.", formatDurationTo(skew.Truncate(time.Second), time.Second), direction)
+}
+
+// formatOffset shows how far the true time is from the system clock, with a sign.
+func formatOffset(offset time.Duration) string {
+	if offset < 0 {
+		return "-" + formatDurationTo((-offset).Truncate(time.Second), time.Second)
+	}
+	return "+" + formatDurationTo(offset.Truncate(time.Second), time.Second)
+}
+
+// clockSkew is how far the true time is ahead of the system clock, from NTP or, failing
+// that, the API's Date header, along with which one answered.
+func clockSkew(ctx context.Context, cfg Config) (time.Duration, string, error) {
+	server := cfg.NTPServer
+	if server == "" {
+		server = defaultNTPServer
+	}
+	offset, ntpErr := ntpOffset(ctx, server)
+	if ntpErr == nil {
+		return offset, server, nil
+	}
+	offset, err := httpDateOffset(ctx, clockCheckURL(cfg))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to check the clock: %v; %v", ntpErr, err)
+	}
+	return offset, "the API's Date header", nil
+}
+
+// ntpOffset asks an NTP server for the time with a single SNTP request.
+func ntpOffset(ctx context.Context, server string) (time.Duration, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", server)
+	if err != nil {
+		return 0, fmt.Errorf("NTP: %v", err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// Version 4, client mode
+	req := make([]byte, 48)
+	req[0] = 4<<3 | 3
+
+	sent := time.Now()
+	if _, err := conn.Write(req); err != nil {
+		return 0, fmt.Errorf("NTP: %v", err)
+	}
+	resp := make([]byte, 48)
+	n, err := conn.Read(resp)
+	received := time.Now()
+	if err != nil {
+		return 0, fmt.Errorf("NTP: %v", err)
+	}
+	if n < 48 || resp[0]&0x7 != 4 || resp[1] == 0 {
+		return 0, fmt.Errorf("NTP: invalid response from %s", server)
+	}
+
+	serverReceived := ntpTime(resp[32:40])
+	serverSent := ntpTime(resp[40:48])
+	return (serverReceived.Sub(sent) + serverSent.Sub(received)) / 2, nil
+}
+
+// ntpTime decodes a 64-bit NTP timestamp.
+func ntpTime(b []byte) time.Time {
+	seconds := int64(binary.BigEndian.Uint32(b[:4])) - ntpEpochOffset
+	fraction := int64(binary.BigEndian.Uint32(b[4:])) * 1e9 >> 32
+	return time.Unix(seconds, fraction)
+}
+
+// httpDateOffset compares the system clock with a server's Date header, which is only
+// accurate to the second.
+func httpDateOffset(ctx context.Context, url string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	sent := time.Now()
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("Date header: %v", err)
+	}
+	resp.Body.Close()
+	received := time.Now()
+
+	date, err := http.ParseTime(resp.Header.Get("Date"))
+	if err != nil {
+		return 0, fmt.Errorf("Date header: no valid date from %s", url)
+	}
+	// The header is truncated to the second, so compare with the middle of that second
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	return date.Add(500 * time.Millisecond).Sub(midpoint), nil
+}
+
+// warnClockSkew checks the clock in the background and warns through the daemon's
+// outputs when it is off.
+func warnClockSkew(ctx context.Context, cfg Config, out *daemonOutputs) {
+	offset, _, err := clockSkew(ctx, cfg)
+	if err != nil {
+		// Offline or blocked: not worth bothering the user about
+		return
+	}
+	if warning := cfg.clockWarning(offset); warning != "" {
+		fmt.Println(countdownStyle.Render("⚠️  " + warning))
+		out.notify(ctx, notification{Title: "⚠️ System clock is off", Body: warning})
+	}
+}
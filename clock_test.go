@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2025, 3, 1, 18, 0, 0, 0, time.UTC)
+	c := newFakeClock(start)
+	if !c.Now().Equal(start) {
+		t.Fatalf("Now = %s, want %s", c.Now(), start)
+	}
+	if err := c.Sleep(context.Background(), 90*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if want := start.Add(90 * time.Minute); !c.Now().Equal(want) {
+		t.Errorf("after sleeping 90m Now = %s, want %s", c.Now(), want)
+	}
+	// Sleeping for nothing or less leaves the clock alone
+	c.Sleep(context.Background(), -time.Hour)
+	if want := start.Add(90 * time.Minute); !c.Now().Equal(want) {
+		t.Errorf("after a negative sleep Now = %s, want %s", c.Now(), want)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.Sleep(ctx, time.Hour); err == nil {
+		t.Error("Sleep on a canceled context succeeded, want an error")
+	}
+}
+
+// After Isha the next prayer is tomorrow's Fajr, placed on the new day's clock even when
+// the clocks go forward overnight.
+func TestFindNextPrayerAcrossMidnight(t *testing.T) {
+	loc := newYork(t)
+	timings := Timings{Fajr: "05:45 (EST)", Dhuhr: "12:15 (EST)", Asr: "15:20 (EST)", Maghrib: "17:55 (EST)", Isha: "19:15 (EST)"}
+
+	tests := []struct {
+		now        time.Time
+		wantPrayer string
+		want       string
+	}{
+		{time.Date(2025, 3, 8, 13, 0, 0, 0, loc), "Asr", "2025-03-08 15:20 EST"},
+		{time.Date(2025, 3, 8, 19, 15, 0, 0, loc), "Fajr", "2025-03-09 05:45 EDT"},
+		{time.Date(2025, 3, 8, 23, 59, 0, 0, loc), "Fajr", "2025-03-09 05:45 EDT"},
+		{time.Date(2025, 3, 9, 0, 1, 0, 0, loc), "Fajr", "2025-03-09 05:45 EDT"},
+	}
+	for _, tt := range tests {
+		useClock(t, newFakeClock(tt.now))
+		prayer, at, err := findNextPrayer(timings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := at.Format("2006-01-02 15:04 MST"); prayer != tt.wantPrayer || got != tt.want {
+			t.Errorf("at %s next prayer = %s at %s, want %s at %s", tt.now.Format("01-02 15:04"), prayer, got, tt.wantPrayer, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// showCompare prints today's timings under several calculation methods side by side,
+// with the spread between the earliest and latest time for each prayer.
+func showCompare(ctx context.Context, methods []int, city, country string, cfg Config) error {
+	if len(methods) == 0 {
+		return fmt.Errorf("no methods to compare")
+	}
+
+	results := make([]*PrayerTimesResponse, len(methods))
+	for i, method := range methods {
+		data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+		if err != nil {
+			return fmt.Errorf("method %d: %v", method, err)
+		}
+		results[i] = data
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 Methods compared for %s", cityStyle.Render(city))))
+	fmt.Println(strings.Repeat("━", 16+8*len(methods)+8))
+
+	header := padRight("", 15) + " "
+	for _, method := range methods {
+		header += fmt.Sprintf(" %7s", fmt.Sprintf("#%d", method))
+	}
+	header += fmt.Sprintf(" %7s", "spread")
+	fmt.Println(prayerStyle.Render(header))
+
+	for _, prayer := range prayerOrder {
+		var earliest, latest time.Time
+		row := ""
+		for _, data := range results {
+			timings := map[string]string{
+				"Fajr":    data.Data.Timings.Fajr,
+				"Sunrise": data.Data.Timings.Sunrise,
+				"Dhuhr":   data.Data.Timings.Dhuhr,
+				"Asr":     data.Data.Timings.Asr,
+				"Maghrib": data.Data.Timings.Maghrib,
+				"Isha":    data.Data.Timings.Isha,
+			}
+			row += fmt.Sprintf(" %7s", strings.Split(timings[prayer], " ")[0])
+
+			if t, err := parseTime(timings[prayer]); err == nil {
+				if earliest.IsZero() || t.Before(earliest) {
+					earliest = t
+				}
+				if latest.IsZero() || t.After(latest) {
+					latest = t
+				}
+			}
+		}
+
+		spread := latest.Sub(earliest)
+		line := fmt.Sprintf("%s %s %s", prayerStyle.Render(padRight(prayerNames[prayer], 15)), timeStyle.Render(row), cityStyle.Render(fmt.Sprintf("%7s", formatDuration(spread))))
+		if spread >= 10*time.Minute {
+			line = fmt.Sprintf("%s %s %s", nextPrayerStyle.Render(padRight(prayerNames[prayer], 15)), timeStyle.Render(row), countdownStyle.Render(fmt.Sprintf("%7s", formatDuration(spread))))
+		}
+		fmt.Println(line)
+	}
+
+	fmt.Println()
+	for i, method := range methods {
+		fmt.Println(cityStyle.Render(fmt.Sprintf("  #%-3d %s", method, results[i].Data.Meta.Method.Name)))
+	}
+	return nil
+}
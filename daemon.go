@@ -0,0 +1,560 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Kinds of events the daemon fires during the day
+const (
+	eventAdhan  = "adhan"
+	eventIqama  = "iqama"
+	eventAthkar = "athkar"
+	eventDaily  = "daily"
+
+	// Runs the after_prayer hooks, without a notification
+	eventAfterPrayer = "after-prayer"
+
+	// Sets the chat status, without a notification
+	eventStatus = "status"
+
+	// Warns that a prayer's time is about to run out
+	eventWindowEnd = "window-end"
+)
+
+// How long after the iqama the prayer is considered over, when the athkar are shown
+// and the after_prayer hooks run
+const athkarDelay = 10 * time.Minute
+
+type daemonEvent struct {
+	At     time.Time
+	Prayer string
+	Kind   string
+}
+
+// daemonEvents builds a day's schedule of adhan, iqama, athkar, and daily reading events.
+func daemonEvents(timings Timings, day time.Time, cfg Config) []daemonEvent {
+	prayerTimes := map[string]string{
+		"Fajr":    timings.Fajr,
+		"Dhuhr":   timings.Dhuhr,
+		"Asr":     timings.Asr,
+		"Maghrib": timings.Maghrib,
+		"Isha":    timings.Isha,
+	}
+
+	var events []daemonEvent
+	for _, prayer := range []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"} {
+		adhan, err := parseTimeOn(prayerTimes[prayer], day)
+		if err != nil {
+			continue
+		}
+		events = append(events, daemonEvent{At: adhan, Prayer: prayer, Kind: eventAdhan})
+
+		iqama := adhan
+		if delay := cfg.iqamaDelay(prayer); delay > 0 {
+			iqama = adhan.Add(time.Duration(delay) * time.Minute)
+			events = append(events, daemonEvent{At: iqama, Prayer: prayer, Kind: eventIqama})
+		}
+
+		if cfg.AfterPrayerAthkar {
+			events = append(events, daemonEvent{At: iqama.Add(athkarDelay), Prayer: prayer, Kind: eventAthkar})
+		}
+		// The status goes up when the prayer starts: at the iqama if there is one
+		if cfg.Status.SlackToken != "" || cfg.Status.Teams.RefreshToken != "" {
+			events = append(events, daemonEvent{At: iqama, Prayer: prayer, Kind: eventStatus})
+		}
+		if len(cfg.Hooks.AfterPrayer) > 0 {
+			events = append(events, daemonEvent{At: iqama.Add(athkarDelay), Prayer: prayer, Kind: eventAfterPrayer})
+		}
+	}
+
+	// A prayer's time runs until the next one, Fajr's until sunrise. Isha's runs until
+	// Fajr, so its warning fires in the morning, for the night before.
+	windowEnds := map[string]string{
+		"Fajr":    timings.Sunrise,
+		"Dhuhr":   timings.Asr,
+		"Asr":     timings.Maghrib,
+		"Maghrib": timings.Isha,
+		"Isha":    timings.Fajr,
+	}
+	for prayer, minutes := range cfg.WindowWarnings {
+		end, err := parseTimeOn(windowEnds[prayer], day)
+		if err != nil || minutes <= 0 {
+			continue
+		}
+		events = append(events, daemonEvent{At: end.Add(-time.Duration(minutes) * time.Minute), Prayer: prayer, Kind: eventWindowEnd})
+	}
+
+	if cfg.Daily {
+		if sunrise, err := parseTimeOn(timings.Sunrise, day); err == nil {
+			events = append(events, daemonEvent{At: sunrise, Kind: eventDaily})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+	return events
+}
+
+func runDaemon(ctx context.Context, city, country string, method int, cfg Config) {
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 pray daemon for %s", cityStyle.Render(city))))
+	fmt.Println(strings.Repeat("━", 50))
+
+	out, err := newDaemonOutputs(cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	out.status = newDaemonStatus(city, out.notifiers)
+	if err := out.status.save(); err != nil {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+	}
+	if err := checkDaemonConfig(cfg); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	tracker, err := newLocationTracker(city, country, cfg)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if tracker != nil && tracker.check(ctx) {
+		city = tracker.pos.String()
+		fmt.Println(cityStyle.Render(fmt.Sprintf("📍 %s", city)))
+	}
+
+	var prompt *promptFile
+	if cfg.PromptFile != "" {
+		prompt = newPromptFile(cfg.PromptFile)
+		go prompt.run(ctx)
+	}
+	var title *terminalTitle
+	if cfg.TerminalTitle {
+		if title, err = newTerminalTitle(); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		} else {
+			go title.run(ctx)
+		}
+	}
+
+	// The last day's timings, to say when a prayer moves a long way overnight
+	var previous *Timings
+	if cfg.TimeChangeAlert > 0 && tracker == nil {
+		if data, err := fetchPrayerTimesOn(ctx, addDays(clock.Now(), -1), city, country, method, cfg); err == nil {
+			previous = &data.Data.Timings
+		}
+	}
+
+	for {
+		var data *PrayerTimesResponse
+		if tracker != nil {
+			data, err = tracker.fetch(ctx, method, cfg)
+		} else {
+			data, err = fetchPrayerTimes(ctx, city, country, method, cfg)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			fmt.Printf("Error: %v (retrying in 5m)\n", err)
+			if clock.Sleep(ctx, 5*time.Minute) != nil {
+				return
+			}
+			continue
+		}
+
+		if prompt != nil {
+			prompt.update(data.Data.Timings)
+		}
+		if title != nil {
+			title.update(data.Data.Timings)
+		}
+		if previous != nil {
+			announceTimeChanges(ctx, *previous, data.Data.Timings, cfg, out)
+		}
+		previous = &data.Data.Timings
+
+		// A skewed clock would fire every event at the wrong time, so check once a day
+		go warnClockSkew(ctx, cfg, out)
+
+		// Skip what was already over when the schedule was made. Events that come due while
+		// another one is still running (a long hook, a focus break) fire late instead.
+		scheduled := clock.Now()
+		moved := false
+		for _, event := range daemonEvents(data.Data.Timings, scheduled, cfg) {
+			if event.At.Before(scheduled) {
+				continue
+			}
+			if moved, err = sleepUntil(ctx, event.At, tracker); err != nil {
+				return
+			}
+			if moved {
+				break
+			}
+			fireDaemonEvent(ctx, event, city, cfg, out)
+		}
+
+		if !moved {
+			// Sleep until just after midnight, then fetch the new day's timings
+			if moved, err = sleepUntil(ctx, nextDayStart(clock.Now()), tracker); err != nil {
+				return
+			}
+		}
+
+		if moved {
+			city = tracker.pos.String()
+			announceMove(ctx, tracker.pos, cfg, out)
+			// The new place's times aren't a change in the old one's
+			previous = nil
+		}
+	}
+}
+
+// checkDaemonConfig catches settings the daemon would otherwise only trip over at a
+// prayer time.
+func checkDaemonConfig(cfg Config) error {
+	if _, err := cfg.QuietHours.contains(clock.Now()); err != nil {
+		return err
+	}
+	if mode := cfg.FocusBreak.Mode; mode != "" && mode != focusOverlay && mode != focusLock {
+		return fmt.Errorf("unknown focus_break mode %q (expected overlay or lock)", mode)
+	}
+	return nil
+}
+
+// Long sleeps are cut into pieces and the wait worked out again from the wall clock after
+// each: the timers run on the monotonic clock, which stops while the machine is suspended
+// and doesn't follow the clock being set.
+const maxSleep = time.Minute
+
+// nextDayStart is a minute past the coming midnight, when the daemon fetches the new day's
+// timings.
+func nextDayStart(now time.Time) time.Time {
+	return wallClock(addDays(now, 1), 0, 1, "")
+}
+
+// sleepUntil sleeps until t. When following the location it checks it whenever due
+// and returns early with moved set once the location has changed.
+func sleepUntil(ctx context.Context, t time.Time, tracker *locationTracker) (bool, error) {
+	for {
+		wait := t.Sub(clock.Now())
+		if wait <= 0 {
+			return false, nil
+		}
+		if tracker != nil && !clock.Now().Before(tracker.nextCheck()) {
+			if tracker.check(ctx) {
+				return true, nil
+			}
+			continue
+		}
+		if tracker != nil {
+			wait = min(wait, tracker.nextCheck().Sub(clock.Now()))
+		}
+		if err := clock.Sleep(ctx, min(wait, maxSleep)); err != nil {
+			return false, err
+		}
+	}
+}
+
+// announceTimeChanges says which prayers moved by time_change_alert minutes or more
+// since the day before, so a much earlier Fajr after a clock change comes as no surprise.
+func announceTimeChanges(ctx context.Context, before, after Timings, cfg Config, out *daemonOutputs) {
+	if cfg.TimeChangeAlert <= 0 {
+		return
+	}
+	lang := cfg.Language
+	threshold := time.Duration(cfg.TimeChangeAlert) * time.Minute
+	beforeTimes, afterTimes := diffTimings(before), diffTimings(after)
+
+	var changes []string
+	for _, prayer := range trackedPrayers {
+		change := diffChange(beforeTimes[prayer], afterTimes[prayer])
+		name := localPrayerName(lang, prayer)
+		switch {
+		case change <= -threshold:
+			changes = append(changes, tr(lang, "change_earlier", name, int(-change.Minutes()), afterTimes[prayer]))
+		case change >= threshold:
+			changes = append(changes, tr(lang, "change_later", name, int(change.Minutes()), afterTimes[prayer]))
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+
+	title := tr(lang, "change_title")
+	body := strings.Join(changes, " ")
+
+	fmt.Println()
+	fmt.Println(countdownStyle.Render(title))
+	for _, change := range changes {
+		fmt.Println(prayerStyle.Render(change))
+	}
+
+	out.notify(ctx, notification{Title: title, Body: body})
+}
+
+func announceMove(ctx context.Context, pos position, cfg Config, out *daemonOutputs) {
+	where := pos.String()
+	if pos.Country != "" {
+		where = fmt.Sprintf("%s, %s", pos.City, pos.Country)
+	}
+	title := tr(cfg.Language, "moved_title", pos)
+	body := tr(cfg.Language, "moved_body", where)
+
+	fmt.Println()
+	fmt.Println(nextPrayerStyle.Render(title))
+
+	out.notify(ctx, notification{Title: title, Body: body})
+}
+
+// fireDaemonEvent does what an event calls for through fx: the daemon's outputs, or a
+// simulation's record of what they would have done.
+func fireDaemonEvent(ctx context.Context, event daemonEvent, city string, cfg Config, fx daemonEffects) {
+	// Wait for a GPIO pulse to finish so the pin is never left on
+	var pulses sync.WaitGroup
+	defer pulses.Wait()
+
+	switch event.Kind {
+	case eventAfterPrayer:
+		fx.runHooks(ctx, cfg.Hooks.AfterPrayer, event, city)
+		return
+	case eventStatus:
+		fx.setStatus(ctx, cfg.Status)
+		return
+	}
+
+	n, err := daemonNotification(event, city, cfg)
+	if err != nil {
+		fx.fail(err)
+		return
+	}
+	fx.show(event, n, cfg)
+
+	if event.Kind == eventAdhan {
+		fx.runHooks(ctx, cfg.Hooks.OnPrayer, event, city)
+		if pin, ok := cfg.gpioPin(event.Prayer); ok {
+			fx.pulseGPIO(ctx, pin, &pulses)
+		}
+	}
+
+	fx.notify(ctx, n)
+
+	if event.Kind == eventAdhan && cfg.FocusBreak.applies(event.Prayer) {
+		fx.focusBreak(ctx, cfg.FocusBreak.Mode, n)
+	}
+}
+
+// daemonNotification is what an event sends through the notification backends, with
+// sound and speech muted in quiet hours.
+func daemonNotification(event daemonEvent, city string, cfg Config) (notification, error) {
+	lang := cfg.Language
+	name := localPrayerName(lang, event.Prayer)
+
+	var n notification
+	switch event.Kind {
+	case eventAdhan:
+		n = notification{
+			Title:  tr(lang, "adhan_title", name),
+			Body:   tr(lang, "adhan_body", name, city),
+			Speech: tr(lang, "adhan_speech", name),
+			Sound:  cfg.sound(event.Prayer),
+		}
+		if delay := cfg.iqamaDelay(event.Prayer); delay > 0 {
+			n.Body = fmt.Sprintf("%s %s %s", n.Body, tr(lang, "iqama_window", delay), tr(lang, "dua_reminder"))
+		}
+	case eventIqama:
+		n = notification{Title: tr(lang, "iqama_title", name), Body: tr(lang, "iqama_body", name), Speech: tr(lang, "iqama_speech", name)}
+	case eventWindowEnd:
+		body := tr(lang, "window_body", cfg.WindowWarnings[event.Prayer], name)
+		n = notification{Title: tr(lang, "window_title", name), Body: body, Speech: body}
+	case eventAthkar:
+		n = notification{Title: tr(lang, "athkar_title", name), Body: tr(lang, "athkar_body")}
+	case eventDaily:
+		ayah, _, err := dailyReading(event.At)
+		if err != nil {
+			return notification{}, err
+		}
+		n = notification{Title: tr(lang, "daily_title"), Body: fmt.Sprintf("%s (%s)", ayah.Translation, ayah.Reference)}
+		if lang == "ar" {
+			n.Body = fmt.Sprintf("%s (%s)", ayah.Arabic, ayah.Reference)
+		}
+	}
+
+	if quiet, _ := cfg.QuietHours.contains(event.At); quiet {
+		// Visual notifications only
+		n.Speech, n.Sound = "", ""
+	}
+	return n, nil
+}
+
+// daemonOutputs are where the daemon sends events, built once at startup
+type daemonOutputs struct {
+	notifiers []notifier
+	statuses  []statusUpdater
+
+	// Per-notifier results for pray daemon status; nil outside the daemon, so pray notify
+	// test doesn't overwrite them. The clock check notifies from its own goroutine.
+	status   *daemonStatus
+	statusMu sync.Mutex
+}
+
+// daemonEffects are the side effects of firing a daemon event. The daemon's outputs carry
+// them out; pray daemon --simulate lists them instead.
+type daemonEffects interface {
+	// show prints the event in the daemon's terminal
+	show(event daemonEvent, n notification, cfg Config)
+	runHooks(ctx context.Context, commands []string, event daemonEvent, city string)
+	// pulseGPIO starts a pulse on pin, counted in pulses until it ends
+	pulseGPIO(ctx context.Context, pin GPIOPin, pulses *sync.WaitGroup)
+	notify(ctx context.Context, n notification)
+	setStatus(ctx context.Context, cfg StatusConfig)
+	focusBreak(ctx context.Context, mode string, n notification)
+	fail(err error)
+}
+
+func newDaemonOutputs(cfg Config) (*daemonOutputs, error) {
+	notifiers, err := newNotifiers(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &daemonOutputs{notifiers: notifiers, statuses: newStatusUpdaters(cfg.Status)}, nil
+}
+
+// notify sends n through every backend at once, reporting failures without stopping.
+func (o *daemonOutputs) notify(ctx context.Context, n notification) {
+	deliveries := dispatch(ctx, o.notifiers, n)
+	for _, d := range deliveries {
+		if d.Err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%s: %v)", d.Notifier, d.Err)))
+		}
+	}
+	if o.status != nil {
+		o.statusMu.Lock()
+		defer o.statusMu.Unlock()
+		o.status.record(deliveries, clock.Now())
+		if err := o.status.save(); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		}
+	}
+}
+
+func (o *daemonOutputs) show(event daemonEvent, n notification, cfg Config) {
+	lang := cfg.Language
+	stamp := timeStyle.Render(event.At.Format("15:04"))
+
+	switch event.Kind {
+	case eventAdhan:
+		fmt.Println()
+		fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s %s", stamp, n.Title)))
+
+		// Between adhan and iqama, remind of the dua after adhan
+		if delay := cfg.iqamaDelay(event.Prayer); delay > 0 {
+			fmt.Println(prayerStyle.Render(translations["ar"]["dua_after_adhan"]))
+			if lang != "ar" {
+				fmt.Println(prayerStyle.Render(tr(lang, "dua_after_adhan")))
+			}
+			fmt.Println(cityStyle.PaddingLeft(2).Render(tr(lang, "dua_reminder")))
+			fmt.Println(prayerStyle.Render(tr(lang, "iqama_window", delay)))
+		}
+	case eventIqama:
+		fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s %s", stamp, n.Title)))
+	case eventWindowEnd:
+		fmt.Println()
+		fmt.Println(countdownStyle.Render(fmt.Sprintf("%s %s", stamp, n.Title)))
+		fmt.Println(prayerStyle.Render(n.Body))
+	case eventAthkar:
+		fmt.Println()
+		if err := showAthkar("after-prayer"); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	case eventDaily:
+		fmt.Println()
+		if err := showDaily(event.At); err != nil {
+			fmt.Printf("Error: %v\n", err)
+		}
+	}
+}
+
+func (o *daemonOutputs) runHooks(ctx context.Context, commands []string, event daemonEvent, city string) {
+	runHooks(ctx, commands, event, city)
+}
+
+func (o *daemonOutputs) pulseGPIO(ctx context.Context, pin GPIOPin, pulses *sync.WaitGroup) {
+	pulses.Add(1)
+	go func() {
+		defer pulses.Done()
+		if err := pulseGPIO(ctx, pin); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		}
+	}()
+}
+
+func (o *daemonOutputs) focusBreak(ctx context.Context, mode string, n notification) {
+	if err := focusBreak(ctx, mode, n.Title, n.Body); err != nil {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+	}
+}
+
+func (o *daemonOutputs) fail(err error) {
+	fmt.Printf("Error: %v\n", err)
+}
+
+// setStatus puts up the praying status on every chat service. It expires on its own.
+func (o *daemonOutputs) setStatus(ctx context.Context, cfg StatusConfig) {
+	text, emoji, duration := cfg.Text, cfg.Emoji, cfg.Duration
+	if text == "" {
+		text = defaultStatusText
+	}
+	if emoji == "" {
+		emoji = defaultStatusEmoji
+	}
+	if duration <= 0 {
+		duration = defaultStatusDuration
+	}
+
+	until := clock.Now().Add(duration)
+	for _, status := range o.statuses {
+		if err := status.setStatus(ctx, text, emoji, until); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		}
+	}
+}
+
+// canonicalPrayer matches a prayer name typed on the command line, in any case.
+func canonicalPrayer(prayer string) (string, error) {
+	for _, p := range prayerOrder {
+		if strings.EqualFold(p, prayer) && p != "Sunrise" {
+			return p, nil
+		}
+	}
+	return "", fmt.Errorf("unknown prayer %q (expected Fajr, Dhuhr, Asr, Maghrib, or Isha)", prayer)
+}
+
+// testNotification fires an adhan event for a prayer right now, as the daemon would.
+func testNotification(ctx context.Context, prayer, city string, cfg Config) error {
+	name, err := canonicalPrayer(prayer)
+	if err != nil {
+		return err
+	}
+
+	out, err := newDaemonOutputs(cfg)
+	if err != nil {
+		return err
+	}
+	quiet, err := cfg.QuietHours.contains(clock.Now())
+	if err != nil {
+		return err
+	}
+	if quiet {
+		fmt.Println(cityStyle.Render("🔕 Quiet hours: sound and speech are muted"))
+	}
+
+	fireDaemonEvent(ctx, daemonEvent{At: clock.Now(), Prayer: name, Kind: eventAdhan}, city, cfg, out)
+	playing.Wait()
+	return nil
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNextDayStart(t *testing.T) {
+	loc := newYork(t)
+	tests := []struct {
+		now  time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 8, 23, 30, 0, 0, loc), "2025-03-09 00:01 EST"},
+		{time.Date(2025, 3, 9, 22, 0, 0, 0, loc), "2025-03-10 00:01 EDT"},
+		{time.Date(2025, 11, 1, 23, 0, 0, 0, loc), "2025-11-02 00:01 EDT"},
+		{time.Date(2025, 11, 2, 23, 0, 0, 0, loc), "2025-11-03 00:01 EST"},
+		{time.Date(2025, 12, 31, 0, 0, 30, 0, loc), "2026-01-01 00:01 EST"},
+	}
+	for _, tt := range tests {
+		if got := nextDayStart(tt.now).Format("2006-01-02 15:04 MST"); got != tt.want {
+			t.Errorf("nextDayStart(%s) = %s, want %s", tt.now, got, tt.want)
+		}
+	}
+}
+
+// The daemon's sleep across midnight wakes exactly at the new day, in steps no longer
+// than maxSleep, on the days the clocks change as on any other.
+func TestSleepUntilMidnight(t *testing.T) {
+	loc := newYork(t)
+	for _, start := range []time.Time{
+		time.Date(2025, 3, 8, 21, 17, 30, 0, loc),
+		time.Date(2025, 11, 1, 21, 17, 30, 0, loc),
+	} {
+		fake := &steppingClock{fakeClock: newFakeClock(start)}
+		useClock(t, fake)
+
+		target := nextDayStart(clock.Now())
+		moved, err := sleepUntil(context.Background(), target, nil)
+		if err != nil || moved {
+			t.Fatalf("sleepUntil = %v, %v; want false, nil", moved, err)
+		}
+		if now := clock.Now(); !now.Equal(target) {
+			t.Errorf("woke at %s, want %s", now, target)
+		}
+		if want := int(target.Sub(start) / maxSleep); fake.steps < want {
+			t.Errorf("slept in %d steps, want at least %d", fake.steps, want)
+		}
+		if fake.longest > maxSleep {
+			t.Errorf("longest sleep %s, want at most %s", fake.longest, maxSleep)
+		}
+	}
+}
+
+func TestSleepUntilCanceled(t *testing.T) {
+	useClock(t, newFakeClock(time.Date(2025, 3, 8, 23, 0, 0, 0, time.UTC)))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := sleepUntil(ctx, clock.Now().Add(time.Hour), nil); err == nil {
+		t.Error("sleepUntil on a canceled context succeeded, want an error")
+	}
+}
+
+// steppingClock counts the sleeps on a fake clock.
+type steppingClock struct {
+	*fakeClock
+	steps   int
+	longest time.Duration
+}
+
+func (c *steppingClock) Sleep(ctx context.Context, d time.Duration) error {
+	c.steps++
+	c.longest = max(c.longest, d)
+	return c.fakeClock.Sleep(ctx, d)
+}
+
+// useClock swaps in a clock for the rest of a test.
+func useClock(t *testing.T, c Clock) {
+	t.Helper()
+	saved := clock
+	clock = c
+	t.Cleanup(func() { clock = saved })
+}
+
+func TestFireDaemonEventSimulated(t *testing.T) {
+	cfg := Config{
+		Hooks: Hooks{OnPrayer: []string{"echo adhan"}, AfterPrayer: []string{"echo after"}},
+		GPIO:  map[string]GPIOPin{"Fajr": {Pin: 17, Pulse: 5 * time.Second}},
+	}
+	at := time.Date(2024, 3, 1, 4, 58, 0, 0, time.UTC)
+	tests := []struct {
+		event daemonEvent
+		want  []string
+	}{
+		{daemonEvent{At: at, Prayer: "Fajr", Kind: eventAdhan}, []string{"hook", "gpio", "notify", ""}},
+		{daemonEvent{At: at, Prayer: "Fajr", Kind: eventAfterPrayer}, []string{"hook"}},
+		{daemonEvent{At: at, Prayer: "Fajr", Kind: eventStatus}, []string{"status"}},
+	}
+	for _, tt := range tests {
+		sim := &simulatedOutputs{cfg: cfg, targets: []string{"desktop"}, at: at}
+		fireDaemonEvent(context.Background(), tt.event, "Riyadh", cfg, sim)
+		var got []string
+		for _, line := range sim.lines {
+			got = append(got, line[0])
+		}
+		// The adhan sound may follow the notification
+		if len(got) < len(tt.want) || strings.Join(got[:len(tt.want)], ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%s effects = %q, want %q first", tt.event.Kind, got, tt.want)
+		}
+	}
+}
+
+func TestSimulateDaemonRestoresClock(t *testing.T) {
+	var requests int
+	cfg := useAPI(t, serveCalendar(t, &requests))
+	running := newFakeClock(time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC))
+	useClock(t, running)
+
+	start := time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC)
+	if err := simulateDaemon(context.Background(), "Riyadh", "SA", 4, cfg, start); err != nil {
+		t.Fatal(err)
+	}
+	if clock != Clock(running) {
+		t.Errorf("clock after the simulation is %v, want the clock from before it", clock)
+	}
+	if got := clock.Now(); !got.Equal(time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("clock.Now() = %v, want the time before the simulation", got)
+	}
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// daemonStatus is what the running daemon reports about itself, for pray daemon status
+type daemonStatus struct {
+	PID     int            `json:"pid"`
+	City    string         `json:"city"`
+	Started time.Time      `json:"started"`
+	Targets []targetStatus `json:"targets"`
+}
+
+// targetStatus is how notifications through one backend have gone since the daemon started
+type targetStatus struct {
+	Name       string    `json:"name"`
+	Sent       int       `json:"sent"`
+	Failed     int       `json:"failed"`
+	LastSent   time.Time `json:"last_sent,omitempty"`
+	LastFailed time.Time `json:"last_failed,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+}
+
+func newDaemonStatus(city string, notifiers []notifier) *daemonStatus {
+	s := &daemonStatus{PID: os.Getpid(), City: city, Started: time.Now()}
+	for _, backend := range notifiers {
+		s.Targets = append(s.Targets, targetStatus{Name: backend.name()})
+	}
+	return s
+}
+
+// record counts the deliveries of one notification against their targets.
+func (s *daemonStatus) record(deliveries []delivery, at time.Time) {
+	for i, d := range deliveries {
+		target := &s.Targets[i]
+		if d.Err != nil {
+			target.Failed++
+			target.LastFailed = at
+			target.LastError = d.Err.Error()
+			continue
+		}
+		target.Sent++
+		target.LastSent = at
+	}
+}
+
+// daemonStatusPath is pray/daemon.json in the user cache directory (~/.cache on Linux).
+func daemonStatusPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pray", "daemon.json"), nil
+}
+
+// save writes the status, replacing the file at once so a reader never sees half of it.
+func (s *daemonStatus) save() error {
+	path, err := daemonStatusPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %v", err)
+	}
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write daemon status: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write daemon status: %v", err)
+	}
+	return nil
+}
+
+// loadDaemonStatus reads what the last daemon to run reported, or nil if none has.
+func loadDaemonStatus() (*daemonStatus, error) {
+	path, err := daemonStatusPath()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read daemon status: %v", err)
+	}
+	var s daemonStatus
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse daemon status %s: %v", path, err)
+	}
+	return &s, nil
+}
+
+// processRunning reports whether a process is still alive. Windows has no signal 0, so
+// there finding the process has to do.
+func processRunning(pid int) bool {
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	err = p.Signal(syscall.Signal(0))
+	return err == nil || errors.Is(err, os.ErrPermission)
+}
+
+func newDaemonStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show whether the daemon is running and how each notifier is doing",
+		Long:  "Show whether the daemon is running, and for each notification backend how many notifications it has sent and failed since the daemon started, with the last error.",
+		Run: func(cmd *cobra.Command, args []string) {
+			s, err := loadDaemonStatus()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println(titleStyle.Render("🕌 pray daemon status"))
+			fmt.Println(strings.Repeat("━", 50))
+			if s == nil {
+				fmt.Println(prayerStyle.Render("The daemon hasn't run yet; start it with pray daemon"))
+				return
+			}
+			if processRunning(s.PID) {
+				fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("Running for %s (pid %d), since %s", s.City, s.PID, s.Started.Local().Format("Mon 02 Jan 15:04"))))
+			} else {
+				fmt.Println(countdownStyle.PaddingLeft(2).Render(fmt.Sprintf("Stopped; last ran for %s, from %s", s.City, s.Started.Local().Format("Mon 02 Jan 15:04"))))
+			}
+			fmt.Println()
+
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %6s %6s  %s", padRight("Notifier", 10), "sent", "failed", "last")))
+			for _, t := range s.Targets {
+				last := "nothing sent yet"
+				style := timeStyle
+				switch {
+				case !t.LastFailed.IsZero() && t.LastFailed.After(t.LastSent):
+					last = fmt.Sprintf("failed %s: %s", t.LastFailed.Local().Format("Mon 15:04"), t.LastError)
+					style = countdownStyle
+				case !t.LastSent.IsZero():
+					last = "sent " + t.LastSent.Local().Format("Mon 15:04")
+				}
+				counts := fmt.Sprintf("%6d %6d", t.Sent, t.Failed)
+				fmt.Printf("%s %s  %s\n", prayerStyle.Render(padRight(t.Name, 10)), timeStyle.Render(counts), style.Render(last))
+			}
+		},
+	}
+}
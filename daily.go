@@ -0,0 +1,82 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed data/daily.json
+var dailyData []byte
+
+// Reading is a short ayah or hadith with its translation
+type Reading struct {
+	Arabic      string `json:"arabic"`
+	Translation string `json:"translation"`
+	Reference   string `json:"reference"`
+}
+
+type dailyReadings struct {
+	Ayat   []Reading `json:"ayat"`
+	Hadith []Reading `json:"hadith"`
+}
+
+func newDailyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "daily",
+		Short: "Show the ayah and hadith of the day",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showDaily(clock.Now()); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+// dailyReading picks the ayah and hadith for a date. The same date always gives the same pair.
+func dailyReading(date time.Time) (Reading, Reading, error) {
+	var readings dailyReadings
+	if err := json.Unmarshal(dailyData, &readings); err != nil {
+		return Reading{}, Reading{}, fmt.Errorf("failed to decode daily readings: %v", err)
+	}
+
+	day := dayIndex(date)
+	ayah := readings.Ayat[day%len(readings.Ayat)]
+	hadith := readings.Hadith[day%len(readings.Hadith)]
+	return ayah, hadith, nil
+}
+
+// dayIndex numbers calendar days so daily rotations advance by one each day, including
+// from 31 December to 1 January.
+func dayIndex(date time.Time) int {
+	return dayNumber(date)
+}
+
+func showDaily(date time.Time) error {
+	ayah, hadith, err := dailyReading(date)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("📖 Ayah of the Day"))
+	fmt.Println(strings.Repeat("━", 50))
+	printReading(ayah)
+
+	fmt.Println()
+	fmt.Println(titleStyle.Render("📜 Hadith of the Day"))
+	fmt.Println(strings.Repeat("━", 50))
+	printReading(hadith)
+	return nil
+}
+
+func printReading(r Reading) {
+	fmt.Println(nextPrayerStyle.Render(r.Arabic))
+	fmt.Println(cityStyle.PaddingLeft(2).Render(r.Translation))
+	fmt.Println(prayerStyle.Render("— " + r.Reference))
+}
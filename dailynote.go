@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed data/daily-note.md
+var defaultDailyNote string
+
+// dailyNote is what a daily-note template is filled with
+type dailyNote struct {
+	Date      string // 2006-01-02, as daily-note plugins name their files
+	Gregorian string
+	Hijri     string
+	City      string
+	Method    string
+	Timings   map[string]string
+	Prayers   []notePrayer
+}
+
+// notePrayer is a prayer in a daily note, with what pray log has for it
+type notePrayer struct {
+	Name   string
+	Time   string
+	Status string
+	Done   bool
+}
+
+// newDailyNote gathers today's timings, dates, and logged prayers for a template.
+func newDailyNote(data *PrayerTimesResponse, city string, store *Store, cfg Config) dailyNote {
+	t := data.Data.Timings
+	note := dailyNote{
+		Gregorian: cfg.gregorianDate(data.Data.Date.Gregorian),
+		Hijri:     cfg.hijriText(cfg.hijriDate(data.Data.Date), true),
+		City:      city,
+		Method:    data.Data.Meta.Method.Name,
+		Timings: map[string]string{
+			"Imsak": t.Imsak, "Fajr": t.Fajr, "Sunrise": t.Sunrise, "Dhuhr": t.Dhuhr, "Asr": t.Asr,
+			"Maghrib": t.Maghrib, "Isha": t.Isha, "Midnight": t.Midnight, "Lastthird": t.Lastthird,
+		},
+	}
+	for prayer, timing := range note.Timings {
+		note.Timings[prayer] = strings.Split(timing, " ")[0]
+	}
+
+	day, err := time.Parse("02-01-2006", data.Data.Date.Gregorian.Date)
+	if err != nil {
+		day = cityNow(data.Data)
+	}
+	note.Date = day.Format("2006-01-02")
+	for _, prayer := range trackedPrayers {
+		status := store.prayerStatus("", prayer, day)
+		note.Prayers = append(note.Prayers, notePrayer{
+			Name:   prayer,
+			Time:   note.Timings[prayer],
+			Status: status,
+			Done:   status == statusPrayed || status == statusLate,
+		})
+	}
+	return note
+}
+
+// exportDailyNote fills a Markdown template, or the built-in one, with today's timings,
+// Hijri date, and prayer checkboxes, for planning the day in Obsidian or Logseq. An
+// existing note is only replaced with force, so a day's writing isn't lost.
+func exportDailyNote(ctx context.Context, templatePath, out string, force bool, city, country string, method int, cfg Config) error {
+	text := defaultDailyNote
+	if templatePath != "" {
+		raw, err := os.ReadFile(expandHome(templatePath))
+		if err != nil {
+			return fmt.Errorf("failed to read template: %v", err)
+		}
+		text = string(raw)
+	}
+	tmpl, err := template.New("daily-note").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %v", templatePath, err)
+	}
+
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+	note := newDailyNote(data, city, store, cfg)
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, note); err != nil {
+		return fmt.Errorf("failed to fill template: %v", err)
+	}
+	if out == "-" {
+		_, err = os.Stdout.Write(buf.Bytes())
+		return err
+	}
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if !force {
+		flags |= os.O_EXCL
+	}
+	f, err := os.OpenFile(expandHome(out), flags, 0o644)
+	if errors.Is(err, os.ErrExist) {
+		return fmt.Errorf("%s already exists; pass --force to replace it", out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", out, err)
+	}
+	_, err = f.Write(buf.Bytes())
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", out, err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// Calendar arithmetic for the Gregorian side. Days are counted by calendar date rather
+// than in 24-hour steps, and clock times are placed with wallClock, so day, week, and
+// year views stay right across leap days and daylight saving changes. In zones where the
+// clocks go forward at midnight (Chile, Paraguay, Lebanon) midnight doesn't exist, and
+// time.Date alone would put the start of the day in the evening before.
+
+// isLeapYear reports whether a Gregorian year has a 29 February.
+func isLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth is the length of a Gregorian month.
+func daysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// dayNumber numbers t's calendar date, counting from 1 January 1970, so consecutive
+// dates are consecutive numbers across year ends.
+func dayNumber(t time.Time) int {
+	year, month, day := t.Date()
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// daysBetween is how many calendar days b is after a, however long those days were.
+func daysBetween(a, b time.Time) int {
+	return dayNumber(b) - dayNumber(a)
+}
+
+// addDays is the same clock time n calendar days after t.
+func addDays(t time.Time, n int) time.Time {
+	year, month, day := t.Date()
+	// Noon is never skipped by a clock change, so it pins the date
+	date := time.Date(year, month, day+n, 12, 0, 0, 0, t.Location())
+	clock := time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return wallClock(date, t.Hour(), t.Minute(), "").Add(clock)
+}
+
+// dayStart is the first moment of t's calendar date.
+func dayStart(t time.Time) time.Time {
+	return wallClock(t, 0, 0, "")
+}
+
+// First days of the week for the week_start setting; unset is Monday
+var weekStarts = map[string]time.Weekday{
+	"":         time.Monday,
+	"monday":   time.Monday,
+	"sunday":   time.Sunday,
+	"saturday": time.Saturday,
+}
+
+// firstWeekday is the day weeks start on.
+func (c Config) firstWeekday() time.Weekday {
+	return weekStarts[strings.ToLower(c.WeekStart)]
+}
+
+// weekOfYear numbers t's week in its year, for weeks starting on first: the week
+// 1 January falls in is week 1.
+func weekOfYear(t time.Time, first time.Weekday) int {
+	jan1 := time.Date(t.Year(), time.January, 1, 12, 0, 0, 0, t.Location())
+	offset := (int(jan1.Weekday()) - int(first) + 7) % 7
+	return (t.YearDay()-1+offset)/7 + 1
+}
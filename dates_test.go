@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsLeapYear(t *testing.T) {
+	tests := map[int]bool{1900: false, 2000: true, 2023: false, 2024: true, 2100: false, 2400: true}
+	for year, want := range tests {
+		if got := isLeapYear(year); got != want {
+			t.Errorf("isLeapYear(%d) = %v, want %v", year, got, want)
+		}
+	}
+}
+
+func TestDaysInMonth(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		want  int
+	}{
+		{2024, time.February, 29},
+		{2025, time.February, 28},
+		{1900, time.February, 28},
+		{2000, time.February, 29},
+		{2025, time.April, 30},
+		{2025, time.December, 31},
+	}
+	for _, tt := range tests {
+		if got := daysInMonth(tt.year, tt.month); got != tt.want {
+			t.Errorf("daysInMonth(%d, %s) = %d, want %d", tt.year, tt.month, got, tt.want)
+		}
+	}
+	// Every month agrees with the time package's own normalization over four centuries
+	for year := 1800; year < 2200; year++ {
+		for month := time.January; month <= time.December; month++ {
+			if got, want := daysInMonth(year, month), time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day(); got != want {
+				t.Fatalf("daysInMonth(%d, %s) = %d, want %d", year, month, got, want)
+			}
+		}
+	}
+}
+
+func TestAddDaysLeapDay(t *testing.T) {
+	tests := []struct {
+		from string
+		n    int
+		want string
+	}{
+		{"2024-02-28 05:10", 1, "2024-02-29 05:10"},
+		{"2024-02-29 05:10", 1, "2024-03-01 05:10"},
+		{"2024-02-29 05:10", 365, "2025-02-28 05:10"},
+		{"2024-02-29 05:10", 366, "2025-03-01 05:10"},
+		{"2025-02-28 05:10", 1, "2025-03-01 05:10"},
+		{"2024-03-01 05:10", -1, "2024-02-29 05:10"},
+		{"2024-12-31 23:59", 1, "2025-01-01 23:59"},
+	}
+	for _, tt := range tests {
+		from, _ := time.Parse("2006-01-02 15:04", tt.from)
+		if got := addDays(from, tt.n).Format("2006-01-02 15:04"); got != tt.want {
+			t.Errorf("addDays(%s, %d) = %s, want %s", tt.from, tt.n, got, tt.want)
+		}
+	}
+}
+
+func TestDaysBetween(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2024-02-28", "2024-03-01", 2},
+		{"2025-02-28", "2025-03-01", 1},
+		{"2024-01-01", "2025-01-01", 366},
+		{"2025-01-01", "2024-12-31", -1},
+	}
+	for _, tt := range tests {
+		a, _ := time.Parse("2006-01-02", tt.a)
+		b, _ := time.Parse("2006-01-02", tt.b)
+		if got := daysBetween(a, b); got != tt.want {
+			t.Errorf("daysBetween(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	// A 23-hour day is still one day
+	loc := newYork(t)
+	if got := daysBetween(time.Date(2025, 3, 8, 23, 0, 0, 0, loc), time.Date(2025, 3, 9, 23, 0, 0, 0, loc)); got != 1 {
+		t.Errorf("daysBetween across spring forward = %d, want 1", got)
+	}
+}
+
+// In Chile the clocks go forward at midnight, so the day starts at 01:00.
+func TestDayStartWithoutMidnight(t *testing.T) {
+	loc, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	got := dayStart(time.Date(2024, 9, 8, 15, 0, 0, 0, loc))
+	if want := "2024-09-08 01:00"; got.Format("2006-01-02 15:04") != want {
+		t.Errorf("dayStart = %s, want %s", got.Format("2006-01-02 15:04"), want)
+	}
+}
+
+func TestWeekOfYear(t *testing.T) {
+	// 1 January 2025 is a Wednesday
+	tests := []struct {
+		date  string
+		first time.Weekday
+		want  int
+	}{
+		{"2025-01-01", time.Monday, 1},
+		{"2025-01-05", time.Monday, 1},
+		{"2025-01-06", time.Monday, 2},
+		{"2025-01-04", time.Saturday, 2},
+		{"2025-01-05", time.Sunday, 2},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := weekOfYear(date, tt.first); got != tt.want {
+			t.Errorf("weekOfYear(%s, %s) = %d, want %d", tt.date, tt.first, got, tt.want)
+		}
+	}
+}
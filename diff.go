@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Changes at least this big are highlighted, as in pray compare
+const diffHighlight = 10 * time.Minute
+
+// diffSettings is a location and calculation method
+type diffSettings struct {
+	City    string
+	Country string
+	Method  int
+}
+
+// showDiff prints how today's and this week's timings would move if the configured
+// location and method were changed to the flags', so a change can be tried out first.
+func showDiff(ctx context.Context, city, country string, method int, cfg Config) error {
+	current := diffSettings{City: cfg.City, Country: cfg.Country, Method: cfg.Method}
+	proposed := diffSettings{City: city, Country: country, Method: method}
+	if current == proposed {
+		return fmt.Errorf("nothing to compare: pass a --method, --city, or --country other than the configured %s, %s, method %d", cfg.City, cfg.Country, cfg.Method)
+	}
+
+	now := clock.Now()
+	start := weekStart(now, cfg.firstWeekday())
+	before, err := diffWeek(ctx, current, start, cfg)
+	if err != nil {
+		return err
+	}
+	after, err := diffWeek(ctx, proposed, start, cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 Timings diff for %s", cityStyle.Render(city))))
+	fmt.Println(strings.Repeat("━", 66))
+	if current.Method != proposed.Method {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("%s #%d %s → #%d %s", padRight("Method", 10), current.Method, before[0].Meta.Method.Name, proposed.Method, after[0].Meta.Method.Name)))
+	}
+	if current.City != proposed.City || current.Country != proposed.Country {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %s, %s → %s, %s", padRight("Location", 10), current.City, current.Country, proposed.City, proposed.Country)))
+	}
+	fmt.Println()
+
+	today := int(dayStart(now).Sub(start).Hours()+12) / 24
+	fmt.Println(nextPrayerStyle.Render("Today: " + diffSummary(before[today].Timings, after[today].Timings)))
+	fmt.Println()
+
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %7s %7s %7s", padRight("", 15), "now", "new", "change")))
+	beforeTimes, afterTimes := diffTimings(before[today].Timings), diffTimings(after[today].Timings)
+	for _, prayer := range prayerOrder {
+		change := diffChange(beforeTimes[prayer], afterTimes[prayer])
+		row := fmt.Sprintf("%7s %7s", beforeTimes[prayer], afterTimes[prayer])
+		fmt.Printf("%s %s %s\n", prayerStyle.Render(padRight(prayerNames[prayer], 15)), timeStyle.Render(row), diffStyle(change).Render(fmt.Sprintf("%7s", formatDelta(change))))
+	}
+	fmt.Println()
+
+	header := padRight("This week", 10)
+	for _, prayer := range prayerOrder {
+		header += fmt.Sprintf(" %8s", prayer)
+	}
+	fmt.Println(prayerStyle.Render(header))
+	for i := range before {
+		day := addDays(start, i)
+		label := prayerStyle.Render(padRight(day.Format("Mon 02"), 10))
+		switch {
+		case i == today:
+			label = nextPrayerStyle.Render(padRight(day.Format("Mon 02"), 10))
+		case day.Weekday() == time.Friday:
+			label = cityStyle.PaddingLeft(2).Render(padRight(day.Format("Mon 02"), 10))
+		}
+		beforeTimes, afterTimes := diffTimings(before[i].Timings), diffTimings(after[i].Timings)
+		line := label
+		for _, prayer := range prayerOrder {
+			change := diffChange(beforeTimes[prayer], afterTimes[prayer])
+			line += " " + diffStyle(change).Render(fmt.Sprintf("%8s", formatDelta(change)))
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// diffWeek fetches the seven days from start, a month's calendar at a time.
+func diffWeek(ctx context.Context, s diffSettings, start time.Time, cfg Config) ([]Data, error) {
+	calendars := map[[2]int]*CalendarResponse{}
+	week := make([]Data, 7)
+	for i := range week {
+		day := addDays(start, i)
+		key := [2]int{day.Year(), int(day.Month())}
+		calendar, ok := calendars[key]
+		if !ok {
+			var err error
+			calendar, err = fetchCalendar(ctx, s.City, s.Country, s.Method, cfg, day.Year(), int(day.Month()))
+			if err != nil {
+				return nil, fmt.Errorf("%s, method %d: %v", s.City, s.Method, err)
+			}
+			calendars[key] = calendar
+		}
+		if day.Day() > len(calendar.Data) {
+			return nil, fmt.Errorf("no timings for %s", day.Format("2006-01-02"))
+		}
+		week[i] = calendar.Data[day.Day()-1]
+	}
+	return week, nil
+}
+
+// diffTimings is a day's timings by prayer, without the timezone.
+func diffTimings(t Timings) map[string]string {
+	timings := map[string]string{
+		"Fajr":    t.Fajr,
+		"Sunrise": t.Sunrise,
+		"Dhuhr":   t.Dhuhr,
+		"Asr":     t.Asr,
+		"Maghrib": t.Maghrib,
+		"Isha":    t.Isha,
+	}
+	for prayer, timing := range timings {
+		timings[prayer] = strings.Split(timing, " ")[0]
+	}
+	return timings
+}
+
+// diffChange is how far a time moves on the clock. Cities in other timezones are compared
+// by local time, which is what a traveller lives by; Isha moving past midnight counts
+// as a few minutes later, not most of a day earlier.
+func diffChange(before, after string) time.Duration {
+	b, err1 := time.Parse("15:04", before)
+	a, err2 := time.Parse("15:04", after)
+	if err1 != nil || err2 != nil {
+		return 0
+	}
+	d := a.Sub(b)
+	switch {
+	case d > 12*time.Hour:
+		d -= 24 * time.Hour
+	case d < -12*time.Hour:
+		d += 24 * time.Hour
+	}
+	return d
+}
+
+// diffSummary lists the prayers that move, e.g. "Fajr −7m, Isha +12m".
+func diffSummary(before, after Timings) string {
+	beforeTimes, afterTimes := diffTimings(before), diffTimings(after)
+	var changes []string
+	for _, prayer := range prayerOrder {
+		if change := diffChange(beforeTimes[prayer], afterTimes[prayer]); change != 0 {
+			changes = append(changes, fmt.Sprintf("%s %s", prayer, formatDelta(change)))
+		}
+	}
+	if len(changes) == 0 {
+		return "no change"
+	}
+	return strings.Join(changes, ", ")
+}
+
+// formatDelta is a signed change like "+12m" or "−1h 5m", with a minus sign rather than a
+// hyphen.
+func formatDelta(d time.Duration) string {
+	switch {
+	case d > 0:
+		return "+" + formatDuration(d)
+	case d < 0:
+		return "−" + formatDuration(-d)
+	}
+	return "0"
+}
+
+func diffStyle(change time.Duration) lipgloss.Style {
+	if change >= diffHighlight || change <= -diffHighlight {
+		return countdownStyle
+	}
+	return cityStyle
+}
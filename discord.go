@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// Colour of the timings embed (the same green as the terminal header)
+const discordEmbedColor = 0x04B575
+
+var discordCommands = []*discordgo.ApplicationCommand{
+	{
+		Name:        "prayer",
+		Description: "Prayer times",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "times",
+				Description: "Today's prayer times for a city",
+				Options: []*discordgo.ApplicationCommandOption{
+					{Type: discordgo.ApplicationCommandOptionString, Name: "city", Description: "City name"},
+					{Type: discordgo.ApplicationCommandOptionString, Name: "country", Description: "Country code"},
+				},
+			},
+		},
+	},
+}
+
+// discordBot answers /prayer slash commands and posts daily timings to a channel.
+type discordBot struct {
+	session *discordgo.Session
+	city    string
+	country string
+	method  int
+	cfg     Config
+}
+
+func runDiscordBot(ctx context.Context, token, channel, postAt, city, country string, method int, cfg Config) error {
+	if token == "" {
+		token = os.Getenv("PRAY_DISCORD_TOKEN")
+	}
+	if token == "" {
+		return fmt.Errorf("a bot token is required (--token or PRAY_DISCORD_TOKEN)")
+	}
+
+	var postHour, postMinute int
+	if channel != "" {
+		at, err := time.Parse("15:04", postAt)
+		if err != nil {
+			return fmt.Errorf("invalid --post-at %q, expected HH:MM", postAt)
+		}
+		postHour, postMinute = at.Hour(), at.Minute()
+	}
+
+	session, err := discordgo.New("Bot " + token)
+	if err != nil {
+		return fmt.Errorf("failed to create Discord session: %v", err)
+	}
+
+	b := &discordBot{session: session, city: city, country: country, method: method, cfg: cfg}
+	session.AddHandler(func(_ *discordgo.Session, i *discordgo.InteractionCreate) {
+		b.handleInteraction(ctx, i)
+	})
+
+	if err := session.Open(); err != nil {
+		return fmt.Errorf("failed to connect to Discord: %v", err)
+	}
+	defer session.Close()
+
+	if _, err := session.ApplicationCommandBulkOverwrite(session.State.User.ID, "", discordCommands); err != nil {
+		return fmt.Errorf("failed to register slash commands: %v", err)
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 pray bot connected as %s", cityStyle.Render(session.State.User.Username))))
+
+	if channel == "" {
+		<-ctx.Done()
+		return nil
+	}
+
+	for {
+		now := clock.Now()
+		next := wallClock(now, postHour, postMinute, "")
+		if !next.After(now) {
+			next = addDays(next, 1)
+		}
+		if clock.Sleep(ctx, next.Sub(now)) != nil {
+			return nil
+		}
+
+		embed, err := b.timingsEmbed(ctx, city, country)
+		if err != nil {
+			log.Printf("failed to fetch daily timings: %v", err)
+			continue
+		}
+		if _, err := session.ChannelMessageSendEmbed(channel, embed, discordgo.WithContext(ctx)); err != nil {
+			log.Printf("failed to post daily timings: %v", err)
+		}
+	}
+}
+
+func (b *discordBot) handleInteraction(ctx context.Context, i *discordgo.InteractionCreate) {
+	if i.Type != discordgo.InteractionApplicationCommand {
+		return
+	}
+	data := i.ApplicationCommandData()
+	if data.Name != "prayer" || len(data.Options) == 0 || data.Options[0].Name != "times" {
+		return
+	}
+
+	city, country := b.city, b.country
+	for _, option := range data.Options[0].Options {
+		switch option.Name {
+		case "city":
+			city = option.StringValue()
+		case "country":
+			country = option.StringValue()
+		}
+	}
+
+	// Fetching can take longer than Discord's 3 second reply window, so defer the reply
+	err := b.session.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	})
+	if err != nil {
+		log.Printf("failed to acknowledge interaction: %v", err)
+		return
+	}
+
+	edit := &discordgo.WebhookEdit{}
+	embed, err := b.timingsEmbed(ctx, city, country)
+	if err != nil {
+		msg := fmt.Sprintf("Couldn't get prayer times for %s: %v", city, err)
+		edit.Content = &msg
+	} else {
+		edit.Embeds = &[]*discordgo.MessageEmbed{embed}
+	}
+
+	if _, err := b.session.InteractionResponseEdit(i.Interaction, edit); err != nil {
+		log.Printf("failed to reply to interaction: %v", err)
+	}
+}
+
+// timingsEmbed renders today's timings the way the terminal table does.
+func (b *discordBot) timingsEmbed(ctx context.Context, city, country string) (*discordgo.MessageEmbed, error) {
+	data, err := fetchPrayerTimes(ctx, city, country, b.method, b.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	timings := map[string]string{
+		"Fajr":    data.Data.Timings.Fajr,
+		"Sunrise": data.Data.Timings.Sunrise,
+		"Dhuhr":   data.Data.Timings.Dhuhr,
+		"Asr":     data.Data.Timings.Asr,
+		"Maghrib": data.Data.Timings.Maghrib,
+		"Isha":    data.Data.Timings.Isha,
+	}
+
+	var lines []string
+	for _, prayer := range prayerOrder {
+		timeStr := strings.Split(timings[prayer], " ")[0]
+		lines = append(lines, padRight(prayerNames[prayer], 15)+" "+timeStr)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🕌 Prayer Times for %s", city),
+		Description: fmt.Sprintf("📅 %s | %s %s, %s AH\n```\n%s\n```",
+			Config{}.gregorianDate(data.Data.Date.Gregorian),
+			data.Data.Date.Hijri.Day,
+			data.Data.Date.Hijri.Month.En,
+			data.Data.Date.Hijri.Year,
+			strings.Join(lines, "\n")),
+		Color:  discordEmbedColor,
+		Footer: &discordgo.MessageEmbedFooter{Text: data.Data.Meta.Method.Name},
+	}, nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wallClock is the moment a clock in day's location shows hour:min on that day. On the
+// days the clocks change, time.Date alone gets this wrong by the size of the change:
+//
+//   - When the clocks go forward, a time in the skipped hour is taken by the old clock,
+//     so 02:30 on a 02:00→03:00 morning is 03:30 rather than 01:30 the night before.
+//   - When the clocks go back, a time in the repeated hour happens twice. zone, the
+//     offset or abbreviation the API gives with its timings ("-05", "EST"), picks which;
+//     without it the first is used, so nothing fires an hour late.
+func wallClock(day time.Time, hour, min int, zone string) time.Time {
+	loc := day.Location()
+	year, month, date := day.Date()
+	t := time.Date(year, month, date, hour, min, 0, 0, loc)
+
+	// Transitions are months apart, so a day either side gives the offsets around one
+	_, before := t.Add(-12 * time.Hour).Zone()
+	_, after := t.Add(12 * time.Hour).Zone()
+	if before == after {
+		return t
+	}
+
+	var candidates []time.Time
+	for _, offset := range []int{before, after} {
+		c := time.Date(year, month, date, hour, min, 0, 0, time.FixedZone("", offset)).In(loc)
+		if c.Hour() == hour && c.Minute() == min {
+			candidates = append(candidates, c)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		// Skipped when the clocks went forward
+		return time.Date(year, month, date, hour, min, 0, 0, time.FixedZone("", before)).In(loc)
+	case 2:
+		if zone != "" && inZone(candidates[1], zone) && !inZone(candidates[0], zone) {
+			return candidates[1]
+		}
+	}
+	return candidates[0]
+}
+
+// inZone reports whether t is in the zone an API timing names, by abbreviation or by
+// an offset like "+03", "-0330", or "+05:30".
+func inZone(t time.Time, zone string) bool {
+	name, offset := t.Zone()
+	if zone == name {
+		return true
+	}
+	if len(zone) < 3 || (zone[0] != '+' && zone[0] != '-') {
+		return false
+	}
+
+	digits := strings.ReplaceAll(zone[1:], ":", "")
+	if len(digits) < 2 || len(digits) > 4 {
+		return false
+	}
+	hours, err := strconv.Atoi(digits[:2])
+	if err != nil {
+		return false
+	}
+	minutes := 0
+	if len(digits) > 2 {
+		if minutes, err = strconv.Atoi(digits[2:]); err != nil {
+			return false
+		}
+	}
+	seconds := hours*3600 + minutes*60
+	if zone[0] == '-' {
+		seconds = -seconds
+	}
+	return seconds == offset
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func newYork(t *testing.T) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+	return loc
+}
+
+func TestWallClock(t *testing.T) {
+	loc := newYork(t)
+	spring := time.Date(2025, 3, 9, 12, 0, 0, 0, loc)
+	fall := time.Date(2025, 11, 2, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		name      string
+		day       time.Time
+		hour, min int
+		zone      string
+		want      string // UTC
+		wantZone  string
+	}{
+		{"spring before the change", spring, 1, 30, "", "2025-03-09 06:30", "EST"},
+		{"spring skipped hour", spring, 2, 30, "", "2025-03-09 07:30", "EDT"},
+		{"spring after the change", spring, 3, 30, "", "2025-03-09 07:30", "EDT"},
+		{"spring evening", spring, 18, 0, "EDT", "2025-03-09 22:00", "EDT"},
+		{"fall before the change", fall, 0, 30, "", "2025-11-02 04:30", "EDT"},
+		{"fall repeated hour without a hint", fall, 1, 30, "", "2025-11-02 05:30", "EDT"},
+		{"fall repeated hour, EDT", fall, 1, 30, "EDT", "2025-11-02 05:30", "EDT"},
+		{"fall repeated hour, EST", fall, 1, 30, "EST", "2025-11-02 06:30", "EST"},
+		{"fall repeated hour, -04", fall, 1, 30, "-04", "2025-11-02 05:30", "EDT"},
+		{"fall repeated hour, -05", fall, 1, 30, "-05", "2025-11-02 06:30", "EST"},
+		{"fall after the change", fall, 2, 30, "", "2025-11-02 07:30", "EST"},
+		{"ordinary day", time.Date(2025, 6, 1, 12, 0, 0, 0, loc), 5, 0, "", "2025-06-01 09:00", "EDT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wallClock(tt.day, tt.hour, tt.min, tt.zone)
+			if utc := got.UTC().Format("2006-01-02 15:04"); utc != tt.want {
+				t.Errorf("wallClock = %s UTC, want %s UTC", utc, tt.want)
+			}
+			if zone, _ := got.Zone(); zone != tt.wantZone {
+				t.Errorf("wallClock zone = %s, want %s", zone, tt.wantZone)
+			}
+		})
+	}
+}
+
+func TestInZone(t *testing.T) {
+	t0 := time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("IST", 5*3600+1800))
+	tests := map[string]bool{
+		"IST":    true,
+		"+0530":  true,
+		"+05:30": true,
+		"+05":    false,
+		"-0530":  false,
+		"EST":    false,
+		"":       false,
+		// Malformed offsets are no match rather than a panic
+		"+5:":       false,
+		"+:5":       false,
+		"-5":        false,
+		"+05:30:00": false,
+	}
+	for zone, want := range tests {
+		if got := inZone(t0, zone); got != want {
+			t.Errorf("inZone(%q) = %v, want %v", zone, got, want)
+		}
+	}
+}
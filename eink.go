@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/inconsolata"
+	"golang.org/x/image/math/fixed"
+)
+
+// The e-paper size the layout is drawn for; larger displays get it scaled up by a whole
+// factor so the bitmap fonts stay sharp
+var einkBaseSize = image.Point{X: 296, Y: 128}
+
+// Black and white, the two colors of a 1-bit PNG
+var einkPalette = color.Palette{color.White, color.Black}
+
+// einkFonts is a regular and bold face of one size; the layout tries the larger set first
+type einkFonts struct {
+	regular, bold font.Face
+	width         int
+	height        int
+}
+
+var einkFontSets = []einkFonts{
+	{regular: inconsolata.Regular8x16, bold: inconsolata.Bold8x16, width: 8, height: 16},
+	{regular: basicfont.Face7x13, bold: basicfont.Face7x13, width: 7, height: 13},
+}
+
+// Space between text and the edge of its box
+const einkPadding = 4
+
+// parseSize reads a display size like 296x128.
+func parseSize(size string) (image.Point, error) {
+	w, h, ok := strings.Cut(strings.ToLower(size), "x")
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if !ok || errW != nil || errH != nil || width <= 0 || height <= 0 {
+		return image.Point{}, fmt.Errorf("invalid size %q (expected WIDTHxHEIGHT, e.g. 296x128)", size)
+	}
+	return image.Point{X: width, Y: height}, nil
+}
+
+func renderEink(ctx context.Context, city, country string, method int, cfg Config, size, out string) error {
+	dims, err := parseSize(size)
+	if err != nil {
+		return err
+	}
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	frame, err := drawEink(dims, city, data.Data, clock.Now())
+	if err != nil {
+		return err
+	}
+	return writePNG(frame, out)
+}
+
+// drawEink lays out today's schedule for a display of the given size: the city and date in
+// a black bar, the six timings with the next prayer inverted, and the Hijri date below.
+func drawEink(size image.Point, city string, day Data, now time.Time) (*image.Paletted, error) {
+	scale := min(size.X/einkBaseSize.X, size.Y/einkBaseSize.Y)
+	if scale < 1 {
+		scale = 1
+	}
+	canvas := image.NewPaletted(image.Rect(0, 0, size.X/scale, size.Y/scale), einkPalette)
+
+	next, _, err := findNextPrayerAt(day.Timings, now)
+	if err != nil {
+		return nil, err
+	}
+	layout, ok := einkLayoutFor(canvas.Bounds().Size())
+	if !ok {
+		return nil, fmt.Errorf("%dx%d is too small for the schedule", size.X, size.Y)
+	}
+	f := layout.fonts
+
+	header := image.Rect(0, 0, canvas.Rect.Dx(), f.height+2)
+	draw.Draw(canvas, header, image.Black, image.Point{}, draw.Src)
+	date := now.Format("Mon 2 Jan")
+	drawText(canvas, f.bold, header, truncate(city, (header.Dx()-3*einkPadding)/f.width-len(date)), color.White, false)
+	drawText(canvas, f.bold, header, date, color.White, true)
+
+	timings := map[string]string{
+		"Fajr":    day.Timings.Fajr,
+		"Sunrise": day.Timings.Sunrise,
+		"Dhuhr":   day.Timings.Dhuhr,
+		"Asr":     day.Timings.Asr,
+		"Maghrib": day.Timings.Maghrib,
+		"Isha":    day.Timings.Isha,
+	}
+	body := image.Rect(0, header.Max.Y, canvas.Rect.Dx(), canvas.Rect.Dy()-f.height-1)
+	rows := (len(prayerOrder) + layout.columns - 1) / layout.columns
+	cellW, cellH := body.Dx()/layout.columns, body.Dy()/rows
+	for i, prayer := range prayerOrder {
+		col, row := i/rows, i%rows
+		x, y := body.Min.X+col*cellW, body.Min.Y+row*cellH
+		// Leave a gap between the cells so an inverted one doesn't touch its neighbours
+		cell := image.Rect(x+1, y+1, x+cellW-1, y+cellH-1)
+		face, ink := f.regular, color.Color(color.Black)
+		if prayer == next {
+			draw.Draw(canvas, cell, image.Black, image.Point{}, draw.Src)
+			face, ink = f.bold, color.White
+		}
+		drawText(canvas, face, cell, prayer, ink, false)
+		drawText(canvas, face, cell, strings.Split(timings[prayer], " ")[0], ink, true)
+	}
+	if layout.columns > 1 {
+		line := image.Rect(body.Min.X+cellW-1, body.Min.Y+2, body.Min.X+cellW, body.Min.Y+rows*cellH-2)
+		draw.Draw(canvas, line, image.Black, image.Point{}, draw.Src)
+	}
+
+	footer := image.Rect(0, canvas.Rect.Dy()-f.height-1, canvas.Rect.Dx(), canvas.Rect.Dy())
+	draw.Draw(canvas, image.Rect(0, footer.Min.Y, footer.Max.X, footer.Min.Y+1), image.Black, image.Point{}, draw.Src)
+	hijri := truncate(plainHijriDate(day.Date.Hijri), (footer.Dx()-2*einkPadding)/f.width)
+	drawText(canvas, f.regular, footer.Add(image.Pt(0, 1)), hijri, color.Black, false)
+
+	return scaleUp(canvas, size, scale), nil
+}
+
+// einkLayout is the fonts and number of columns a display's schedule fits with
+type einkLayout struct {
+	fonts   einkFonts
+	columns int
+}
+
+// einkLayoutFor picks the largest fonts the schedule fits in, in one column if it's tall
+// enough and in two otherwise.
+func einkLayoutFor(size image.Point) (einkLayout, bool) {
+	for _, f := range einkFontSets {
+		body := size.Y - 2*f.height - 3
+		// "Maghrib" and "18:45" with padding and a space between them
+		cellW := 13*f.width + 2*einkPadding + 2
+		for columns := 1; columns <= 2; columns++ {
+			rows := (len(prayerOrder) + columns - 1) / columns
+			if body/rows >= f.height+2 && size.X/columns >= cellW {
+				return einkLayout{fonts: f, columns: columns}, true
+			}
+		}
+	}
+	return einkLayout{}, false
+}
+
+// drawText draws s vertically centered in box, against its left or right edge.
+func drawText(dst draw.Image, face font.Face, box image.Rectangle, s string, ink color.Color, right bool) {
+	metrics := face.Metrics()
+	d := &font.Drawer{Dst: dst, Src: image.NewUniform(ink), Face: face}
+	x := box.Min.X + einkPadding
+	if right {
+		x = box.Max.X - einkPadding - d.MeasureString(s).Ceil()
+	}
+	textH := (metrics.Ascent + metrics.Descent).Ceil()
+	y := box.Min.Y + (box.Dy()-textH)/2 + metrics.Ascent.Ceil()
+	d.Dot = fixed.P(x, y)
+	d.DrawString(s)
+}
+
+// truncate shortens s to n characters, ending it with a dot when it's cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if n < 1 {
+		return ""
+	}
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-1]) + "."
+}
+
+// plainHijriDate is the Hijri date with ASCII month names, for bitmap fonts and outputs
+// where the API's transliterations wouldn't show.
+func plainHijriDate(h Hijri) string {
+	if h.Month.Number < 1 || h.Month.Number >= len(hijriMonthNames) {
+		return h.Date
+	}
+	return fmt.Sprintf("%s %s %s AH", strings.TrimLeft(h.Day, "0"), hijriMonthNames[h.Month.Number], h.Year)
+}
+
+// scaleUp enlarges the canvas by a whole factor, centered in a frame of the display's size.
+func scaleUp(canvas *image.Paletted, size image.Point, scale int) *image.Paletted {
+	if scale == 1 && canvas.Rect.Size() == size {
+		return canvas
+	}
+	frame := image.NewPaletted(image.Rect(0, 0, size.X, size.Y), einkPalette)
+	offX := (size.X - canvas.Rect.Dx()*scale) / 2
+	offY := (size.Y - canvas.Rect.Dy()*scale) / 2
+	for y := 0; y < canvas.Rect.Dy()*scale; y++ {
+		for x := 0; x < canvas.Rect.Dx()*scale; x++ {
+			frame.SetColorIndex(offX+x, offY+y, canvas.ColorIndexAt(x/scale, y/scale))
+		}
+	}
+	return frame
+}
+
+// writePNG writes img to path, or to standard output for "-". The file is replaced
+// atomically, so a display driver polling it never reads half a frame.
+func writePNG(img image.Image, path string) error {
+	if path == "-" {
+		return png.Encode(os.Stdout, img)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".frame-*.png")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	// Readable by a display driver running as another user, like a file written directly
+	err = tmp.Chmod(0o644)
+	if err == nil {
+		err = png.Encode(tmp, img)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	return nil
+}
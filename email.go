@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// emailNotifier sends notifications as plain text email over SMTP.
+type emailNotifier struct {
+	config EmailConfig
+}
+
+func (emailNotifier) name() string { return "email" }
+
+func (e emailNotifier) notify(ctx context.Context, n notification) error {
+	c := e.config
+	port := c.Port
+	if port == 0 {
+		port = 587
+	}
+	addr := net.JoinHostPort(c.Host, strconv.Itoa(port))
+
+	from := c.From
+	if from == "" {
+		from = c.Username
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", from)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(c.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", n.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if c.Username != "" {
+		auth = smtp.PlainAuth("", c.Username, c.Password, c.Host)
+	}
+
+	// net/smtp has no context support, so a cancelled send is abandoned rather than interrupted
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, from, c.To, []byte(msg.String()))
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %v", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// newEmailNotifier checks the email settings are complete.
+func newEmailNotifier(c EmailConfig) (emailNotifier, error) {
+	if c.Host == "" || len(c.To) == 0 {
+		return emailNotifier{}, fmt.Errorf("the email notifier needs email.host and email.to")
+	}
+	return emailNotifier{config: c}, nil
+}
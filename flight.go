@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// How often the route is sampled for the sun crossing a prayer's altitude
+const flightStep = time.Minute
+
+// methodAngles is how a calculation method places Fajr and Isha offline: the sun's
+// depression below the horizon, or for Isha in some methods, minutes after Maghrib
+type methodAngles struct {
+	Fajr        float64
+	Isha        float64
+	IshaMinutes int
+}
+
+// Fajr and Isha of the API's methods, by method ID
+var methodAnglesByID = map[int]methodAngles{
+	1:  {Fajr: 18, Isha: 18},
+	2:  {Fajr: 15, Isha: 15},
+	3:  {Fajr: 18, Isha: 17},
+	4:  {Fajr: 18.5, IshaMinutes: 90},
+	5:  {Fajr: 19.5, Isha: 17.5},
+	7:  {Fajr: 17.7, Isha: 14},
+	8:  {Fajr: 19.5, IshaMinutes: 90},
+	9:  {Fajr: 18, Isha: 17.5},
+	10: {Fajr: 18, IshaMinutes: 90},
+	11: {Fajr: 20, Isha: 18},
+	12: {Fajr: 12, Isha: 12},
+	13: {Fajr: 18, Isha: 17},
+	14: {Fajr: 16, Isha: 15},
+	15: {Fajr: 18, Isha: 18},
+	16: {Fajr: 18.2, Isha: 18.2},
+}
+
+// lookupMethodAngles finds a method's angles, for the calculations done without the API.
+func lookupMethodAngles(method int) (methodAngles, error) {
+	angles, ok := methodAnglesByID[method]
+	if !ok {
+		return methodAngles{}, fmt.Errorf("method %d can't be calculated offline", method)
+	}
+	return angles, nil
+}
+
+// sunAltitude is the sun's altitude above the horizon at a place, in degrees, and its
+// hour angle, negative before noon and positive after.
+func sunAltitude(t time.Time, latitude, longitude float64) (altitude, hourAngle, declination float64) {
+	declination, eot := solarPosition(t)
+	utc := t.UTC()
+	minutes := float64(utc.Hour()*60+utc.Minute()) + float64(utc.Second())/60
+	hourAngle = math.Mod((minutes+eot)/minutesPerDegree+longitude+720, 360) - 180
+	altitude = toDegrees(math.Asin(sinDeg(latitude)*sinDeg(declination) + cosDeg(latitude)*cosDeg(declination)*cosDeg(hourAngle)))
+	return altitude, hourAngle, declination
+}
+
+// asrAltitude is the sun's altitude when a shadow is its object's length times shadow,
+// plus the noon shadow: 1 for the standard school, 2 for the Hanafi.
+func asrAltitude(latitude, declination, shadow float64) float64 {
+	return toDegrees(math.Atan(1 / (shadow + math.Tan(toRadians(math.Abs(latitude-declination))))))
+}
+
+// routePoint is where along the great circle from one place to another a fraction of the
+// way is.
+func routePoint(lat1, lon1, lat2, lon2, fraction float64) (float64, float64) {
+	phi1, lambda1, phi2, lambda2 := toRadians(lat1), toRadians(lon1), toRadians(lat2), toRadians(lon2)
+	d := distanceKm(lat1, lon1, lat2, lon2) / earthRadiusKm
+	if d == 0 {
+		return lat1, lon1
+	}
+	a := math.Sin((1-fraction)*d) / math.Sin(d)
+	b := math.Sin(fraction*d) / math.Sin(d)
+	x := a*math.Cos(phi1)*math.Cos(lambda1) + b*math.Cos(phi2)*math.Cos(lambda2)
+	y := a*math.Cos(phi1)*math.Sin(lambda1) + b*math.Cos(phi2)*math.Sin(lambda2)
+	z := a*math.Sin(phi1) + b*math.Sin(phi2)
+	return toDegrees(math.Atan2(z, math.Hypot(x, y))), toDegrees(math.Atan2(y, x))
+}
+
+// flightPrayer is a prayer time falling during a flight, and where the plane is then
+type flightPrayer struct {
+	Prayer    string
+	At        time.Time
+	Latitude  float64
+	Longitude float64
+}
+
+// flightPrayers estimates the prayer times along a great-circle flight by working out
+// the sun's position over the plane every minute and noting when it passes each prayer's
+// altitude, or for Dhuhr, the meridian.
+func flightPrayers(from, to City, depart time.Time, duration time.Duration, angles methodAngles, school string) []flightPrayer {
+	shadow := 1.0
+	if school == schoolHanafi {
+		shadow = 2
+	}
+
+	var prayers []flightPrayer
+	var prevAlt, prevHour, prevAsr float64
+	// Isha a fixed time after Maghrib, once the flight gets there
+	var isha time.Time
+	for elapsed := time.Duration(0); elapsed <= duration; elapsed += flightStep {
+		at := depart.Add(elapsed)
+		lat, lon := routePoint(from.Latitude, from.Longitude, to.Latitude, to.Longitude, float64(elapsed)/float64(duration))
+		alt, hour, dec := sunAltitude(at, lat, lon)
+		asr := asrAltitude(lat, dec, shadow)
+		add := func(prayer string) {
+			prayers = append(prayers, flightPrayer{Prayer: prayer, At: at, Latitude: lat, Longitude: lon})
+		}
+		if !isha.IsZero() && !at.Before(isha) {
+			add("Isha")
+			isha = time.Time{}
+		}
+		if elapsed > 0 {
+			rising, setting := alt > prevAlt, alt < prevAlt
+			crossed := func(altitude float64) bool {
+				return (prevAlt < altitude && alt >= altitude) || (prevAlt > altitude && alt <= altitude)
+			}
+			switch {
+			case rising && crossed(-angles.Fajr):
+				add("Fajr")
+			case rising && crossed(sunriseAltitude):
+				add("Sunrise")
+			case prevHour < 0 && hour >= 0 && hour < 90:
+				add("Dhuhr")
+			case setting && hour > 0 && prevAlt > prevAsr && alt <= asr:
+				add("Asr")
+			case setting && crossed(sunriseAltitude):
+				add("Maghrib")
+				if angles.IshaMinutes > 0 {
+					isha = at.Add(time.Duration(angles.IshaMinutes) * time.Minute)
+				}
+			case setting && angles.IshaMinutes == 0 && crossed(-angles.Isha):
+				add("Isha")
+			}
+		}
+		prevAlt, prevHour, prevAsr = alt, hour, asr
+	}
+	return prayers
+}
+
+// parseDepart reads --depart, like 2025-03-01T14:00, or 14:00 for today, in the
+// departure's timezone.
+func parseDepart(s string, loc *time.Location) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, loc); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("15:04", s); err == nil {
+		return wallClock(clock.Now().In(loc), t.Hour(), t.Minute(), ""), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --depart time %q (expected 2006-01-02T15:04, or 15:04 for today)", s)
+}
+
+// flightPlace finds an airport code or city for --from and --to, offline.
+func flightPlace(flag, name string) (City, *time.Location, error) {
+	if name == "" {
+		return City{}, nil, fmt.Errorf("--%s is required, as an airport code like DXB or a city", flag)
+	}
+	c, ok := lookupCity(name, "")
+	if !ok {
+		return City{}, nil, fmt.Errorf("unknown --%s %q: give an airport code like DXB, or one of the built-in cities", flag, name)
+	}
+	loc, err := time.LoadLocation(c.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	return c, loc, nil
+}
+
+// showFlight prints the prayer times falling during a flight, in the departure and
+// arrival timezones.
+func showFlight(fromName, toName, departAt string, duration time.Duration, method int, cfg Config) error {
+	from, fromLoc, err := flightPlace("from", fromName)
+	if err != nil {
+		return err
+	}
+	to, toLoc, err := flightPlace("to", toName)
+	if err != nil {
+		return err
+	}
+	if duration <= 0 {
+		return fmt.Errorf("--duration is required, like 13h or 7h30m")
+	}
+	depart, err := parseDepart(departAt, fromLoc)
+	if err != nil {
+		return err
+	}
+	angles, err := lookupMethodAngles(method)
+	if err != nil {
+		return err
+	}
+
+	arrive := depart.Add(duration)
+	fmt.Println(titleStyle.Render(fmt.Sprintf("✈️  Prayer times from %s to %s", cityStyle.Render(fromName), cityStyle.Render(toName))))
+	fmt.Println(strings.Repeat("━", 60))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("Departs %s in %s, arrives %s in %s",
+		depart.Format("Mon 02 Jan 15:04"), from.Name, arrive.In(toLoc).Format("Mon 02 Jan 15:04"), to.Name)))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s over %.0f km", formatDuration(duration), distanceKm(from.Latitude, from.Longitude, to.Latitude, to.Longitude))))
+	fmt.Println()
+
+	prayers := flightPrayers(from, to, depart, duration, angles, cfg.School)
+	if len(prayers) == 0 {
+		fmt.Println(prayerStyle.Render("No prayer times fall during the flight"))
+		return nil
+	}
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %-12s %-12s %-10s %s", padRight("", 10), from.Name, to.Name, "Flown", "Over")))
+	for _, p := range prayers {
+		row := fmt.Sprintf("%-12s %-12s %-10s %s", p.At.In(fromLoc).Format("15:04"), p.At.In(toLoc).Format("15:04"),
+			formatDuration(p.At.Sub(depart)), formatCoordinates(p.Latitude, p.Longitude))
+		fmt.Printf("%s %s\n", prayerStyle.Render(padRight(prayerNames[p.Prayer], 10)), timeStyle.Render(row))
+	}
+	fmt.Println()
+	fmt.Println(prayerStyle.Render("Estimated along the great-circle route; the flight's real path and the"))
+	fmt.Println(prayerStyle.Render("lower horizon at cruising height can move times by several minutes."))
+	return nil
+}
+
+// formatCoordinates writes a position like 25.3°N 55.4°E.
+func formatCoordinates(latitude, longitude float64) string {
+	ns, ew := "N", "E"
+	if latitude < 0 {
+		ns, latitude = "S", -latitude
+	}
+	if longitude < 0 {
+		ew, longitude = "W", -longitude
+	}
+	return fmt.Sprintf("%.1f°%s %.1f°%s", latitude, ns, longitude, ew)
+}
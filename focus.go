@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+)
+
+// Focus break modes
+const (
+	focusOverlay = "overlay"
+	focusLock    = "lock"
+)
+
+// focusBreak interrupts the user at the adhan: a fullscreen reminder in the daemon's
+// terminal until a key is pressed, or a screen lock.
+func focusBreak(ctx context.Context, mode, title, body string) error {
+	switch mode {
+	case focusOverlay:
+		return focusOverlayScreen(ctx, title, body)
+	case focusLock:
+		return lockScreen(ctx)
+	default:
+		return fmt.Errorf("unknown focus_break mode %q (expected overlay or lock)", mode)
+	}
+}
+
+func focusOverlayScreen(ctx context.Context, title, body string) error {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the focus break overlay needs the daemon to run in a terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	// Alternate screen, hidden cursor; restored on dismissal
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	content := strings.Join([]string{
+		titleStyle.Render(title),
+		"",
+		prayerStyle.Render(body),
+		"",
+		"",
+		cityStyle.Render("Press any key when you're back"),
+	}, "\n")
+
+	keys := make(chan struct{})
+	go func() {
+		buf := make([]byte, 8)
+		os.Stdin.Read(buf)
+		close(keys)
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		// Redraw so the overlay follows terminal resizes
+		width, height, err := term.GetSize(os.Stdout.Fd())
+		if err != nil {
+			width, height = 80, 24
+		}
+		screen := lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content)
+		fmt.Print("\x1b[H" + strings.ReplaceAll(screen, "\n", "\r\n"))
+
+		select {
+		case <-keys:
+			return nil
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// lockScreen locks the session with the platform's own tool.
+func lockScreen(ctx context.Context) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.CommandContext(ctx, "pmset", "displaysleepnow")
+	case "windows":
+		cmd = exec.CommandContext(ctx, "rundll32.exe", "user32.dll,LockWorkStation")
+	default:
+		if _, err := exec.LookPath("loginctl"); err == nil {
+			cmd = exec.CommandContext(ctx, "loginctl", "lock-session")
+		} else {
+			cmd = exec.CommandContext(ctx, "xdg-screensaver", "lock")
+		}
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to lock screen: %v", err)
+	}
+	return nil
+}
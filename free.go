@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Default time kept clear either side of a prayer
+const defaultMeetingBuffer = 10 * time.Minute
+
+// meetingBuffer is the time kept clear either side of a prayer.
+func (c Config) meetingBuffer() time.Duration {
+	if c.MeetingBuffer > 0 {
+		return c.MeetingBuffer
+	}
+	return defaultMeetingBuffer
+}
+
+// freeOptions are the free command's flags
+type freeOptions struct {
+	Duration time.Duration
+	Between  string
+	Buffer   time.Duration
+	Format   string
+}
+
+// freeSlot is a stretch of time a meeting fits in, starting no later than Latest
+type freeSlot struct {
+	Start  time.Time
+	End    time.Time
+	Latest time.Time
+}
+
+// prayerBlock is the time around a prayer kept free of meetings
+type prayerBlock struct {
+	Prayer string
+	Start  time.Time
+	End    time.Time
+}
+
+// freeJSON is the output of pray free --format json, for calendar bots
+type freeJSON struct {
+	City     string            `json:"city"`
+	Date     string            `json:"date"`
+	Duration string            `json:"duration"`
+	Buffer   string            `json:"buffer"`
+	Slots    []freeSlotJSON    `json:"slots"`
+	Blocked  []prayerBlockJSON `json:"blocked"`
+}
+
+type freeSlotJSON struct {
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	LatestStart string `json:"latest_start"`
+}
+
+type prayerBlockJSON struct {
+	Prayer string `json:"prayer"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+}
+
+// parseBetween reads a range like 13:00-17:00 on day.
+func parseBetween(between string, day time.Time) (time.Time, time.Time, error) {
+	from, to, ok := strings.Cut(between, "-")
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --between %q (expected HH:MM-HH:MM)", between)
+	}
+	start, err := parseTimeOn(strings.TrimSpace(from), day)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --between %q (expected HH:MM-HH:MM)", between)
+	}
+	end, err := parseTimeOn(strings.TrimSpace(to), day)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --between %q (expected HH:MM-HH:MM)", between)
+	}
+	if !end.After(start) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--between must end after it starts")
+	}
+	return start, end, nil
+}
+
+// prayerBlocks keeps each prayer clear from buffer before the adhan until buffer after
+// it's over: the iqama, if one is configured, and the prayer itself.
+func prayerBlocks(timings Timings, day time.Time, buffer time.Duration, cfg Config) []prayerBlock {
+	prayerTimes := map[string]string{
+		"Fajr":    timings.Fajr,
+		"Dhuhr":   timings.Dhuhr,
+		"Asr":     timings.Asr,
+		"Maghrib": timings.Maghrib,
+		"Isha":    timings.Isha,
+	}
+
+	var blocks []prayerBlock
+	for _, prayer := range []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"} {
+		adhan, err := parseTimeOn(prayerTimes[prayer], day)
+		if err != nil {
+			continue
+		}
+		over := adhan.Add(time.Duration(cfg.iqamaDelay(prayer))*time.Minute + athkarDelay)
+		blocks = append(blocks, prayerBlock{Prayer: prayer, Start: adhan.Add(-buffer), End: over.Add(buffer)})
+	}
+	return blocks
+}
+
+// freeSlots is what's left of start to end after the prayer blocks, in stretches of at
+// least duration.
+func freeSlots(start, end time.Time, duration time.Duration, blocks []prayerBlock) []freeSlot {
+	var slots []freeSlot
+	add := func(from, to time.Time) {
+		if to.Sub(from) >= duration {
+			slots = append(slots, freeSlot{Start: from, End: to, Latest: to.Add(-duration)})
+		}
+	}
+
+	cursor := start
+	for _, b := range blocks {
+		if !b.End.After(cursor) || !b.Start.Before(end) {
+			continue
+		}
+		if b.Start.After(cursor) {
+			add(cursor, b.Start)
+		}
+		cursor = b.End
+	}
+	if cursor.Before(end) {
+		add(cursor, end)
+	}
+	return slots
+}
+
+// showFree suggests today's meeting slots in a range that keep clear of the prayers.
+func showFree(ctx context.Context, opts freeOptions, city, country string, method int, cfg Config) error {
+	if opts.Duration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+	if opts.Format != "" && opts.Format != "json" {
+		return fmt.Errorf("unknown format %q (expected json)", opts.Format)
+	}
+	if opts.Buffer < 0 {
+		return fmt.Errorf("--buffer can't be negative")
+	}
+	buffer := opts.Buffer
+
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+
+	now := clock.Now()
+	start, end, err := parseBetween(opts.Between, now)
+	if err != nil {
+		return err
+	}
+	// Only what's still ahead today, from the next five minutes
+	if soon := now.Truncate(5 * time.Minute).Add(5 * time.Minute); start.Before(soon) {
+		start = soon
+	}
+
+	blocks := prayerBlocks(data.Data.Timings, now, buffer, cfg)
+	slots := freeSlots(start, end, opts.Duration, blocks)
+
+	if opts.Format == "json" {
+		out := freeJSON{
+			City:     city,
+			Date:     now.Format("2006-01-02"),
+			Duration: formatDuration(opts.Duration),
+			Buffer:   formatDuration(buffer),
+			Slots:    []freeSlotJSON{},
+			Blocked:  []prayerBlockJSON{},
+		}
+		for _, s := range slots {
+			out.Slots = append(out.Slots, freeSlotJSON{Start: s.Start.Format(time.RFC3339), End: s.End.Format(time.RFC3339), LatestStart: s.Latest.Format(time.RFC3339)})
+		}
+		for _, b := range blocks {
+			if b.End.After(start) && b.Start.Before(end) {
+				out.Blocked = append(out.Blocked, prayerBlockJSON{Prayer: b.Prayer, Start: b.Start.Format(time.RFC3339), End: b.End.Format(time.RFC3339)})
+			}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(out)
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("📅 Free for %s in %s, %s", formatDuration(opts.Duration), cityStyle.Render(city), opts.Between)))
+	fmt.Println(strings.Repeat("━", 50))
+	if !start.Before(end) {
+		fmt.Println(countdownStyle.Render(fmt.Sprintf("%s is already over for today", opts.Between)))
+		return nil
+	}
+	if len(slots) == 0 {
+		fmt.Println(countdownStyle.Render("No slot long enough between the prayers; try a wider range or a shorter meeting"))
+		return nil
+	}
+	for _, s := range slots {
+		when := fmt.Sprintf("%s – %s", s.Start.Format("15:04"), s.End.Format("15:04"))
+		hint := fmt.Sprintf("start by %s", s.Latest.Format("15:04"))
+		if s.Latest.Equal(s.Start) {
+			hint = "just fits"
+		}
+		fmt.Printf("%s %s\n", nextPrayerStyle.Render(padRight(when, 15)), prayerStyle.Render(hint))
+	}
+	fmt.Println()
+	for _, b := range blocks {
+		if b.End.After(start) && b.Start.Before(end) {
+			fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("%s kept clear %s – %s", prayerNames[b.Prayer], b.Start.Format("15:04"), b.End.Format("15:04"))))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package main
+
+import "math"
+
+// Coordinates of the Kaaba in Makkah
+const (
+	kaabaLatitude  = 21.4225
+	kaabaLongitude = 39.8262
+)
+
+// Mean radius of the Earth in kilometres
+const earthRadiusKm = 6371.0
+
+func toRadians(d float64) float64 { return d * math.Pi / 180 }
+func toDegrees(r float64) float64 { return r * 180 / math.Pi }
+
+// qiblaDirection returns the great-circle bearing to the Kaaba in degrees clockwise from true north.
+func qiblaDirection(latitude, longitude float64) float64 {
+	phi := toRadians(latitude)
+	phiK := toRadians(kaabaLatitude)
+	deltaLambda := toRadians(kaabaLongitude - longitude)
+
+	bearing := math.Atan2(math.Sin(deltaLambda), math.Cos(phi)*math.Tan(phiK)-math.Sin(phi)*math.Cos(deltaLambda))
+	return math.Mod(toDegrees(bearing)+360, 360)
+}
+
+// distanceKm is the haversine great-circle distance between two points.
+func distanceKm(lat1, lon1, lat2, lon2 float64) float64 {
+	dPhi := toRadians(lat2 - lat1)
+	dLambda := toRadians(lon2 - lon1)
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(toRadians(lat1))*math.Cos(toRadians(lat2))*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// IP geolocation lookup used by the ip location source
+const geoIPURL = "https://ipapi.co/json/"
+
+// How often the daemon re-checks its location unless location_interval is set
+const defaultLocationInterval = 30 * time.Minute
+
+// How far a GPS position has to move before timings are recomputed, unless location_threshold_km is set
+const defaultLocationThreshold = 5.0
+
+// position is where a location source thinks the machine is. IP lookups give a city,
+// GPS sources only coordinates.
+type position struct {
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+}
+
+func (p position) String() string {
+	if p.City != "" {
+		return p.City
+	}
+	return fmt.Sprintf("%.4f, %.4f", p.Latitude, p.Longitude)
+}
+
+// locationSource reports the machine's current position
+type locationSource interface {
+	locate(ctx context.Context) (position, error)
+}
+
+// newLocationSource picks the source from location_source. follow_location alone means ip.
+func newLocationSource(cfg Config) (locationSource, error) {
+	name := cfg.LocationSource
+	if name == "" && cfg.FollowLocation {
+		name = "ip"
+	}
+
+	switch name {
+	case "":
+		return nil, nil
+	case "ip":
+		return ipSource{}, nil
+	case "gpsd":
+		addr := cfg.GPSDAddr
+		if addr == "" {
+			addr = defaultGPSDAddr
+		}
+		return gpsdSource{addr: addr}, nil
+	case "corelocation":
+		command := cfg.LocationCommand
+		if len(command) == 0 {
+			command = defaultCoreLocationCommand
+		}
+		return commandSource{command: command}, nil
+	default:
+		return nil, fmt.Errorf("unknown location_source %q (expected ip, gpsd, or corelocation)", name)
+	}
+}
+
+type geoIPResponse struct {
+	City        string  `json:"city"`
+	CountryCode string  `json:"country_code"`
+	Latitude    float64 `json:"latitude"`
+	Longitude   float64 `json:"longitude"`
+	Error       bool    `json:"error"`
+	Reason      string  `json:"reason"`
+}
+
+// ipSource looks up the city of the machine's public IP address.
+type ipSource struct{}
+
+func (ipSource) locate(ctx context.Context) (position, error) {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, geoIPURL, nil)
+	if err != nil {
+		return position{}, err
+	}
+	req.Header.Set("User-Agent", "pray")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return position{}, fmt.Errorf("failed to look up location: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var geo geoIPResponse
+	if err := json.NewDecoder(resp.Body).Decode(&geo); err != nil {
+		return position{}, fmt.Errorf("failed to decode location: %v", err)
+	}
+	if geo.Error || resp.StatusCode != http.StatusOK {
+		return position{}, fmt.Errorf("failed to look up location: %s %s", resp.Status, geo.Reason)
+	}
+	if geo.City == "" || geo.CountryCode == "" {
+		return position{}, fmt.Errorf("failed to look up location: no city for this address")
+	}
+	return position{City: geo.City, Country: geo.CountryCode, Latitude: geo.Latitude, Longitude: geo.Longitude}, nil
+}
+
+// locationTracker follows the daemon's location as the machine travels.
+type locationTracker struct {
+	source    locationSource
+	pos       position
+	interval  time.Duration
+	threshold float64
+	lastCheck time.Time
+}
+
+// newLocationTracker returns nil unless a location source is configured.
+func newLocationTracker(city, country string, cfg Config) (*locationTracker, error) {
+	source, err := newLocationSource(cfg)
+	if source == nil || err != nil {
+		return nil, err
+	}
+
+	interval := cfg.LocationInterval
+	if interval <= 0 {
+		interval = defaultLocationInterval
+	}
+	threshold := cfg.LocationThreshold
+	if threshold <= 0 {
+		threshold = defaultLocationThreshold
+	}
+	return &locationTracker{
+		source:    source,
+		pos:       position{City: city, Country: country},
+		interval:  interval,
+		threshold: threshold,
+	}, nil
+}
+
+func (t *locationTracker) nextCheck() time.Time {
+	return t.lastCheck.Add(t.interval)
+}
+
+// check looks up the current position and reports whether the daemon has moved:
+// to another city for IP lookups, or beyond the threshold for coordinates.
+// Lookup failures keep the current position.
+func (t *locationTracker) check(ctx context.Context) bool {
+	t.lastCheck = clock.Now()
+
+	pos, err := t.source.locate(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(%v)", err)))
+		}
+		return false
+	}
+
+	if pos.City != "" {
+		if strings.EqualFold(pos.City, t.pos.City) && strings.EqualFold(pos.Country, t.pos.Country) {
+			return false
+		}
+	} else if t.pos.City == "" && distanceKm(t.pos.Latitude, t.pos.Longitude, pos.Latitude, pos.Longitude) < t.threshold {
+		return false
+	}
+
+	t.pos = pos
+	return true
+}
+
+// fetch gets today's timings for the tracked position, by coordinates when there is no city.
+func (t *locationTracker) fetch(ctx context.Context, method int, cfg Config) (*PrayerTimesResponse, error) {
+	if t.pos.City == "" {
+		return fetchPrayerTimesAt(ctx, t.pos.Latitude, t.pos.Longitude, method, cfg)
+	}
+	return fetchPrayerTimes(ctx, t.pos.City, t.pos.Country, method, cfg)
+}
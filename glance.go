@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Widest glance line, for watch complications and 128-pixel OLEDs
+const glanceWidth = 20
+
+// glanceText is the next prayer in at most glanceWidth characters, e.g. "Asr 15:32 -42m".
+func glanceText(timings Timings, now time.Time) (string, error) {
+	prayer, at, err := findNextPrayerAt(timings, now)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s -%s", prayer, at.Format("15:04"), glanceCountdown(at.Sub(now))), nil
+}
+
+// glanceCountdown is formatDuration without the space, down to whole hours from 10 hours
+// on so "Maghrib 18:45 -9h59m" is the longest line.
+func glanceCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = (d + time.Minute - 1) / time.Minute * time.Minute
+
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case hours >= 10:
+		return fmt.Sprintf("%dh", hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
+
+func showGlance(ctx context.Context, city, country string, method int, cfg Config) error {
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	text, err := glanceText(data.Data.Timings, clock.Now())
+	if err != nil {
+		return err
+	}
+	fmt.Println(text)
+	return nil
+}
+
+// handleGlance answers /v1/glance with the glance line as plain text, so a device can show
+// the body as it is.
+func (s *server) handleGlance(w http.ResponseWriter, r *http.Request) {
+	city, country, method, err := s.location(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := fetchPrayerTimes(r.Context(), city, country, method, s.cfg)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	text, err := glanceText(data.Data.Timings, cityNow(data.Data))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, text)
+}
@@ -0,0 +1,56 @@
+//go:build gpio && linux
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultGPIOPulse = time.Second
+	gpioRoot         = "/sys/class/gpio"
+)
+
+// pulseGPIO drives a pin high for its pulse duration through the sysfs GPIO interface.
+// The pin is always switched off again, even if ctx is cancelled mid-pulse.
+func pulseGPIO(ctx context.Context, pin GPIOPin) error {
+	dir := filepath.Join(gpioRoot, fmt.Sprintf("gpio%d", pin.Pin))
+
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		if err := writeGPIO(filepath.Join(gpioRoot, "export"), strconv.Itoa(pin.Pin)); err != nil {
+			return err
+		}
+		// udev needs a moment to fix up permissions on the new pin
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	activeLow := "0"
+	if pin.ActiveLow {
+		activeLow = "1"
+	}
+	if err := writeGPIO(filepath.Join(dir, "active_low"), activeLow); err != nil {
+		return err
+	}
+	if err := writeGPIO(filepath.Join(dir, "direction"), "out"); err != nil {
+		return err
+	}
+
+	value := filepath.Join(dir, "value")
+	if err := writeGPIO(value, "1"); err != nil {
+		return err
+	}
+	sleepContext(ctx, pin.Pulse)
+	return writeGPIO(value, "0")
+}
+
+func writeGPIO(path, value string) error {
+	if err := os.WriteFile(path, []byte(value), 0); err != nil {
+		return fmt.Errorf("failed to write GPIO %s: %v", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+//go:build !gpio || !linux
+
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const defaultGPIOPulse = time.Second
+
+func pulseGPIO(ctx context.Context, pin GPIOPin) error {
+	return errors.New("GPIO is not supported in this build, rebuild on Linux with -tags gpio")
+}
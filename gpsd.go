@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultGPSDAddr = "localhost:2947"
+
+// How long to wait for gpsd to report a fix
+const gpsFixTimeout = 30 * time.Second
+
+// CoreLocationCLI (brew install corelocationcli) prints the Mac's position from Location Services
+var defaultCoreLocationCommand = []string{"CoreLocationCLI", "--format", "%latitude %longitude"}
+
+// gpsdSource reads the current fix from a gpsd daemon.
+type gpsdSource struct {
+	addr string
+}
+
+type gpsdReport struct {
+	Class string  `json:"class"`
+	Mode  int     `json:"mode"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+}
+
+func (g gpsdSource) locate(ctx context.Context) (position, error) {
+	ctx, cancel := context.WithTimeout(ctx, gpsFixTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", g.addr)
+	if err != nil {
+		return position{}, fmt.Errorf("failed to connect to gpsd: %v", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if _, err := fmt.Fprint(conn, `?WATCH={"enable":true,"json":true};`); err != nil {
+		return position{}, fmt.Errorf("failed to talk to gpsd: %v", err)
+	}
+
+	// gpsd streams reports; wait for a time-position report with at least a 2D fix
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		var report gpsdReport
+		if json.Unmarshal(scanner.Bytes(), &report) != nil {
+			continue
+		}
+		if report.Class == "TPV" && report.Mode >= 2 {
+			return position{Latitude: report.Lat, Longitude: report.Lon}, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return position{}, fmt.Errorf("no GPS fix from gpsd: %v", err)
+	}
+	return position{}, fmt.Errorf("no GPS fix from gpsd")
+}
+
+// commandSource runs a helper that prints "latitude longitude", such as CoreLocationCLI on macOS.
+type commandSource struct {
+	command []string
+}
+
+func (c commandSource) locate(ctx context.Context) (position, error) {
+	ctx, cancel := context.WithTimeout(ctx, gpsFixTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, c.command[0], c.command[1:]...).Output()
+	if err != nil {
+		return position{}, fmt.Errorf("failed to run %s: %v", c.command[0], err)
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) < 2 {
+		return position{}, fmt.Errorf("unexpected output from %s: %q", c.command[0], out)
+	}
+	lat, latErr := strconv.ParseFloat(fields[0], 64)
+	lon, lonErr := strconv.ParseFloat(fields[1], 64)
+	if latErr != nil || lonErr != nil {
+		return position{}, fmt.Errorf("unexpected output from %s: %q", c.command[0], out)
+	}
+	return position{Latitude: lat, Longitude: lon}, nil
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Qibla is the direction and distance to the Kaaba from a point
+type Qibla struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Direction float64 `json:"direction"`
+	Distance  float64 `json:"distance"`
+}
+
+// HijriConversion is a tabular Hijri date returned by the hijri query
+type HijriConversion struct {
+	Year        int    `json:"year"`
+	Month       int    `json:"month"`
+	Day         int    `json:"day"`
+	MonthName   string `json:"monthName"`
+	MonthNameAr string `json:"monthNameAr"`
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+func stringFields(names ...string) graphql.Fields {
+	fields := graphql.Fields{}
+	for _, name := range names {
+		fields[name] = &graphql.Field{Type: graphql.String}
+	}
+	return fields
+}
+
+// graphqlSchema exposes timings, calendar, qibla, and Hijri conversion.
+func (s *server) graphqlSchema() (graphql.Schema, error) {
+	timingsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:   "Timings",
+		Fields: stringFields("fajr", "sunrise", "dhuhr", "asr", "sunset", "maghrib", "isha"),
+	})
+
+	nameType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "LocalizedName",
+		Fields: graphql.Fields{
+			"number": &graphql.Field{Type: graphql.Int},
+			"en":     &graphql.Field{Type: graphql.String},
+			"ar":     &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	calendarDateType := func(name string) *graphql.Object {
+		fields := stringFields("date", "day", "year")
+		fields["month"] = &graphql.Field{Type: nameType}
+		fields["weekday"] = &graphql.Field{Type: nameType}
+		return graphql.NewObject(graphql.ObjectConfig{Name: name, Fields: fields})
+	}
+
+	dateType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Date",
+		Fields: graphql.Fields{
+			"readable":  &graphql.Field{Type: graphql.String},
+			"gregorian": &graphql.Field{Type: calendarDateType("GregorianDate")},
+			"hijri":     &graphql.Field{Type: calendarDateType("HijriDate")},
+		},
+	})
+
+	methodType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Method",
+		Fields: graphql.Fields{
+			"id":   &graphql.Field{Type: graphql.Int},
+			"name": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	metaType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Meta",
+		Fields: graphql.Fields{
+			"latitude":  &graphql.Field{Type: graphql.Float},
+			"longitude": &graphql.Field{Type: graphql.Float},
+			"timezone":  &graphql.Field{Type: graphql.String},
+			"method":    &graphql.Field{Type: methodType},
+		},
+	})
+
+	dayType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Day",
+		Fields: graphql.Fields{
+			"timings": &graphql.Field{Type: timingsType},
+			"date":    &graphql.Field{Type: dateType},
+			"meta":    &graphql.Field{Type: metaType},
+		},
+	})
+
+	qiblaType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Qibla",
+		Fields: graphql.Fields{
+			"latitude":  &graphql.Field{Type: graphql.Float},
+			"longitude": &graphql.Field{Type: graphql.Float},
+			"direction": &graphql.Field{Type: graphql.Float, Description: "Degrees clockwise from true north"},
+			"distance":  &graphql.Field{Type: graphql.Float, Description: "Great-circle distance to the Kaaba in km"},
+		},
+	})
+
+	hijriType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "HijriConversion",
+		Fields: graphql.Fields{
+			"year":        &graphql.Field{Type: graphql.Int},
+			"month":       &graphql.Field{Type: graphql.Int},
+			"day":         &graphql.Field{Type: graphql.Int},
+			"monthName":   &graphql.Field{Type: graphql.String},
+			"monthNameAr": &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	locationArgs := graphql.FieldConfigArgument{
+		"city":    &graphql.ArgumentConfig{Type: graphql.String},
+		"country": &graphql.ArgumentConfig{Type: graphql.String},
+		"method":  &graphql.ArgumentConfig{Type: graphql.Int},
+	}
+
+	withArgs := func(base graphql.FieldConfigArgument, extra graphql.FieldConfigArgument) graphql.FieldConfigArgument {
+		args := graphql.FieldConfigArgument{}
+		for k, v := range base {
+			args[k] = v
+		}
+		for k, v := range extra {
+			args[k] = v
+		}
+		return args
+	}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"timings": &graphql.Field{
+				Type:        dayType,
+				Description: "Today's prayer times",
+				Args:        locationArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					city, country, method := s.locationArgs(p.Args)
+					data, err := fetchPrayerTimes(p.Context, city, country, method, s.cfg)
+					if err != nil {
+						return nil, err
+					}
+					return data.Data, nil
+				},
+			},
+			"calendar": &graphql.Field{
+				Type:        graphql.NewList(dayType),
+				Description: "Prayer times for every day of a month",
+				Args: withArgs(locationArgs, graphql.FieldConfigArgument{
+					"year":  &graphql.ArgumentConfig{Type: graphql.Int},
+					"month": &graphql.ArgumentConfig{Type: graphql.Int},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					city, country, method := s.locationArgs(p.Args)
+					now := clock.Now()
+					year, month := now.Year(), int(now.Month())
+					if v, ok := p.Args["year"].(int); ok {
+						year = v
+					}
+					if v, ok := p.Args["month"].(int); ok {
+						month = v
+					}
+					if month < 1 || month > 12 {
+						return nil, fmt.Errorf("invalid month %d", month)
+					}
+
+					calendar, err := fetchCalendar(p.Context, city, country, method, s.cfg, year, month)
+					if err != nil {
+						return nil, err
+					}
+					return calendar.Data, nil
+				},
+			},
+			"qibla": &graphql.Field{
+				Type:        qiblaType,
+				Description: "Qibla direction from coordinates, or from a city when no coordinates are given",
+				Args: withArgs(locationArgs, graphql.FieldConfigArgument{
+					"latitude":  &graphql.ArgumentConfig{Type: graphql.Float},
+					"longitude": &graphql.ArgumentConfig{Type: graphql.Float},
+				}),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					lat, hasLat := p.Args["latitude"].(float64)
+					lon, hasLon := p.Args["longitude"].(float64)
+					if !hasLat || !hasLon {
+						city, country, method := s.locationArgs(p.Args)
+						data, err := fetchPrayerTimes(p.Context, city, country, method, s.cfg)
+						if err != nil {
+							return nil, err
+						}
+						lat, lon = data.Data.Meta.Latitude, data.Data.Meta.Longitude
+					}
+
+					return Qibla{
+						Latitude:  lat,
+						Longitude: lon,
+						Direction: qiblaDirection(lat, lon),
+						Distance:  distanceKm(lat, lon, kaabaLatitude, kaabaLongitude),
+					}, nil
+				},
+			},
+			"hijri": &graphql.Field{
+				Type:        hijriType,
+				Description: "Convert a Gregorian date (YYYY-MM-DD) to the tabular Hijri calendar",
+				Args: graphql.FieldConfigArgument{
+					"date": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					date, err := time.Parse("2006-01-02", p.Args["date"].(string))
+					if err != nil {
+						return nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", p.Args["date"])
+					}
+
+					h := tabularHijri(addDays(date, s.cfg.HijriAdjustment))
+					return HijriConversion{
+						Year:        h.Year,
+						Month:       h.Month,
+						Day:         h.Day,
+						MonthName:   hijriMonthNames[h.Month],
+						MonthNameAr: hijriMonthNamesAr[h.Month],
+					}, nil
+				},
+			},
+			"gregorian": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Convert a tabular Hijri date to a Gregorian date (YYYY-MM-DD)",
+				Args: graphql.FieldConfigArgument{
+					"year":  &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"month": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"day":   &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					h := HijriDate{Year: p.Args["year"].(int), Month: p.Args["month"].(int), Day: p.Args["day"].(int)}
+					if !h.valid() {
+						return nil, fmt.Errorf("invalid Hijri date %d-%d-%d", h.Year, h.Month, h.Day)
+					}
+					return addDays(tabularGregorian(h), -s.cfg.HijriAdjustment).Format("2006-01-02"), nil
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// locationArgs is the GraphQL counterpart of location, using field arguments instead of the URL query.
+func (s *server) locationArgs(args map[string]interface{}) (string, string, int) {
+	city, country, method := s.city, s.country, s.method
+	if v, ok := args["city"].(string); ok && v != "" {
+		city = v
+	}
+	if v, ok := args["country"].(string); ok && v != "" {
+		country = v
+	}
+	if v, ok := args["method"].(int); ok {
+		method = v
+	}
+	return city, country, method
+}
+
+func (s *server) handleGraphQL(schema graphql.Schema) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req graphqlRequest
+
+		if r.Method == http.MethodGet {
+			req.Query = r.URL.Query().Get("query")
+			req.OperationName = r.URL.Query().Get("operationName")
+			if v := r.URL.Query().Get("variables"); v != "" {
+				if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+					writeError(w, http.StatusBadRequest, fmt.Errorf("invalid variables: %v", err))
+					return
+				}
+			}
+		} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid GraphQL request: %v", err))
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			OperationName:  req.OperationName,
+			VariableValues: req.Variables,
+			Context:        r.Context(),
+		})
+		writeJSON(w, http.StatusOK, result)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/isIbra/pray/pkg/praypb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// grpcServer answers the Prayer gRPC service with the same data as the REST API
+type grpcServer struct {
+	praypb.UnimplementedPrayerServer
+	s *server
+}
+
+// serveGRPC listens on addr and serves gRPC until ctx is done. Reflection is on, so
+// grpcurl and similar tools can list and call the service without the .proto file.
+func (s *server) serveGRPC(ctx context.Context, addr string) error {
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for gRPC: %v", err)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(logRPCs))
+	praypb.RegisterPrayerServer(srv, &grpcServer{s: s})
+	reflection.Register(srv)
+
+	go func() {
+		<-ctx.Done()
+		srv.GracefulStop()
+	}()
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			log.Printf("gRPC server stopped: %v", err)
+		}
+	}()
+	return nil
+}
+
+func logRPCs(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	log.Printf("gRPC %s %s (%s)", info.FullMethod, status.Code(err), time.Since(start).Round(time.Millisecond))
+	return resp, err
+}
+
+// location is the gRPC counterpart of server.location, falling back to the server defaults.
+func (g *grpcServer) location(l *praypb.Location) (string, string, int) {
+	city, country, method := g.s.city, g.s.country, g.s.method
+	if v := l.GetCity(); v != "" {
+		city = v
+	}
+	if v := l.GetCountry(); v != "" {
+		country = v
+	}
+	if v := l.GetMethod(); v != 0 {
+		method = int(v)
+	}
+	return city, country, method
+}
+
+// grpcError maps a failure to fetch timings to a status: an unknown city is NotFound, the
+// API failing is Unavailable.
+func grpcError(err error) error {
+	var unknown *unknownCityError
+	switch {
+	case errors.As(err, &unknown):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+	return status.Error(codes.Unavailable, err.Error())
+}
+
+func (g *grpcServer) Timings(ctx context.Context, req *praypb.TimingsRequest) (*praypb.Day, error) {
+	city, country, method := g.location(req.GetLocation())
+	data, err := fetchPrayerTimes(ctx, city, country, method, g.s.cfg)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	return dayMessage(city, country, data.Data), nil
+}
+
+func (g *grpcServer) NextPrayer(ctx context.Context, req *praypb.NextPrayerRequest) (*praypb.NextPrayerResponse, error) {
+	city, country, method := g.location(req.GetLocation())
+	data, err := fetchPrayerTimes(ctx, city, country, method, g.s.cfg)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+
+	now := cityNow(data.Data)
+	prayer, at, err := findNextPrayerAt(data.Data.Timings, now)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &praypb.NextPrayerResponse{
+		City:   city,
+		Prayer: prayer,
+		At:     timestamppb.New(at),
+		Until:  durationpb.New(at.Sub(now)),
+	}, nil
+}
+
+func (g *grpcServer) Calendar(ctx context.Context, req *praypb.CalendarRequest) (*praypb.CalendarResponse, error) {
+	city, country, method := g.location(req.GetLocation())
+	now := clock.Now()
+	year, month := now.Year(), int(now.Month())
+	if req.GetYear() != 0 {
+		year = int(req.GetYear())
+	}
+	if req.GetMonth() != 0 {
+		month = int(req.GetMonth())
+	}
+	if month < 1 || month > 12 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid month %d", month)
+	}
+
+	calendar, err := fetchCalendar(ctx, city, country, method, g.s.cfg, year, month)
+	if err != nil {
+		return nil, grpcError(err)
+	}
+	resp := &praypb.CalendarResponse{}
+	for _, day := range calendar.Data {
+		resp.Days = append(resp.Days, dayMessage(city, country, day))
+	}
+	return resp, nil
+}
+
+func (g *grpcServer) Qibla(ctx context.Context, req *praypb.QiblaRequest) (*praypb.QiblaResponse, error) {
+	lat, lon := req.GetLatitude(), req.GetLongitude()
+	if req.Latitude == nil || req.Longitude == nil {
+		city, country, method := g.location(req.GetLocation())
+		data, err := fetchPrayerTimes(ctx, city, country, method, g.s.cfg)
+		if err != nil {
+			return nil, grpcError(err)
+		}
+		lat, lon = data.Data.Meta.Latitude, data.Data.Meta.Longitude
+	}
+	if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid coordinates %.4f, %.4f", lat, lon)
+	}
+
+	return &praypb.QiblaResponse{
+		Latitude:   lat,
+		Longitude:  lon,
+		Direction:  qiblaDirection(lat, lon),
+		DistanceKm: distanceKm(lat, lon, kaabaLatitude, kaabaLongitude),
+	}, nil
+}
+
+// dayMessage converts a day of timings, turning each HH:MM into an instant in the city's
+// timezone.
+func dayMessage(city, country string, day Data) *praypb.Day {
+	loc := time.Local
+	if l, err := time.LoadLocation(day.Meta.Timezone); err == nil {
+		loc = l
+	}
+	date := isoDate(day.Date.Gregorian.Date)
+
+	msg := &praypb.Day{
+		City:       city,
+		Country:    country,
+		Date:       date,
+		Timezone:   day.Meta.Timezone,
+		Latitude:   day.Meta.Latitude,
+		Longitude:  day.Meta.Longitude,
+		Method:     int32(day.Meta.Method.Id),
+		MethodName: day.Meta.Method.Name,
+		Timings:    &praypb.Timings{},
+	}
+
+	year, _ := strconv.Atoi(day.Date.Hijri.Year)
+	hijriDay, _ := strconv.Atoi(day.Date.Hijri.Day)
+	msg.Hijri = &praypb.HijriDate{
+		Year:        int32(year),
+		Month:       int32(day.Date.Hijri.Month.Number),
+		Day:         int32(hijriDay),
+		MonthName:   day.Date.Hijri.Month.En,
+		MonthNameAr: day.Date.Hijri.Month.Ar,
+	}
+
+	noon, err := time.ParseInLocation("2006-01-02 15:04", date+" 12:00", loc)
+	if err != nil {
+		return msg
+	}
+	at := func(timing string, afterMidnight bool) *timestamppb.Timestamp {
+		t, err := parseTimeOn(timing, noon)
+		if err != nil {
+			return nil
+		}
+		// Midnight and the last third can fall early the next morning
+		if afterMidnight && t.Before(noon) {
+			t = addDays(t, 1)
+		}
+		return timestamppb.New(t)
+	}
+	msg.Timings = &praypb.Timings{
+		Fajr:      at(day.Timings.Fajr, false),
+		Sunrise:   at(day.Timings.Sunrise, false),
+		Dhuhr:     at(day.Timings.Dhuhr, false),
+		Asr:       at(day.Timings.Asr, false),
+		Sunset:    at(day.Timings.Sunset, false),
+		Maghrib:   at(day.Timings.Maghrib, false),
+		Isha:      at(day.Timings.Isha, false),
+		Imsak:     at(day.Timings.Imsak, false),
+		Midnight:  at(day.Timings.Midnight, true),
+		LastThird: at(day.Timings.Lastthird, true),
+	}
+	return msg
+}
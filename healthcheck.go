@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newHealthcheckCmd() *cobra.Command {
+	var addr, endpoint string
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "healthcheck",
+		Short: "Probe a running pray serve instance",
+		Long:  "Request the health endpoint of a running `pray serve` and exit 0 if it is healthy, 1 otherwise. Meant for Docker HEALTHCHECK and orchestrator exec probes.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := checkHealth(healthURL(addr, endpoint), timeout); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Println("healthy")
+		},
+	}
+	cmd.Flags().StringVar(&addr, "addr", ":8080", "Address the server listens on")
+	cmd.Flags().StringVar(&endpoint, "endpoint", "/healthz", "Path of the health endpoint")
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Second, "How long to wait for a response")
+	return cmd
+}
+
+// healthURL turns a listen address like ":8080" into a URL that reaches it locally.
+func healthURL(addr, endpoint string) string {
+	if strings.HasPrefix(addr, "http://") || strings.HasPrefix(addr, "https://") {
+		return strings.TrimSuffix(addr, "/") + endpoint
+	}
+
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "http://" + addr + endpoint
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port) + endpoint
+}
+
+func checkHealth(url string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("health check failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check failed: %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Julian day of 1 Muharram 1 AH in the civil (Friday) epoch
+const hijriEpochJD = 1948439.5
+
+// Hijri month names, indexed from 1
+var hijriMonthNames = []string{"",
+	"Muharram", "Safar", "Rabi al-Awwal", "Rabi al-Thani",
+	"Jumada al-Ula", "Jumada al-Akhirah", "Rajab", "Sha'ban",
+	"Ramadan", "Shawwal", "Dhu al-Qadah", "Dhu al-Hijjah",
+}
+
+var hijriMonthNamesAr = []string{"",
+	"محرم", "صفر", "ربيع الأول", "ربيع الآخر",
+	"جمادى الأولى", "جمادى الآخرة", "رجب", "شعبان",
+	"رمضان", "شوال", "ذو القعدة", "ذو الحجة",
+}
+
+// Hijri calendars the date can follow: the API's Umm al-Qura dates, or the tabular
+// calendar worked out offline
+const (
+	hijriUmmAlQura = "umm-al-qura"
+	hijriTabular   = "tabular"
+)
+
+// HijriDate is a date in the tabular Islamic calendar
+type HijriDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// civilJulianDay returns the Julian day at midnight starting the given calendar date.
+func civilJulianDay(year int, month time.Month, day int) float64 {
+	t := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return math.Floor(julianDay(t)-0.5) + 0.5
+}
+
+func hijriToJulianDay(year, month, day int) float64 {
+	return float64(day) +
+		math.Ceil(29.5*float64(month-1)) +
+		float64((year-1)*354) +
+		math.Floor(float64(3+11*year)/30) +
+		hijriEpochJD - 1
+}
+
+// tabularHijri converts a Gregorian date with the arithmetical (civil) Islamic calendar.
+// It can differ by a day or two from sighting-based or Umm al-Qura dates.
+func tabularHijri(date time.Time) HijriDate {
+	return hijriFromJulianDay(civilJulianDay(date.Year(), date.Month(), date.Day()))
+}
+
+// hijriFromJulianDay is the tabular Hijri date starting at midnight Julian day jd.
+func hijriFromJulianDay(jd float64) HijriDate {
+	year := int(math.Floor((30*(jd-hijriEpochJD) + 10646) / 10631))
+	month := int(math.Ceil((jd-(29+hijriToJulianDay(year, 1, 1)))/29.5)) + 1
+	if month > 12 {
+		month = 12
+	}
+	if month < 1 {
+		month = 1
+	}
+	day := int(jd-hijriToJulianDay(year, month, 1)) + 1
+
+	return HijriDate{Year: year, Month: month, Day: day}
+}
+
+// isHijriLeapYear reports whether a tabular Hijri year has 355 days, with a 30th of Dhu
+// al-Hijjah. Eleven years in each 30-year cycle do.
+func isHijriLeapYear(year int) bool {
+	return (14+11*year)%30 < 11
+}
+
+// hijriYearLength is 354 or 355.
+func hijriYearLength(year int) int {
+	if isHijriLeapYear(year) {
+		return 355
+	}
+	return 354
+}
+
+// monthLength is the number of days in h's month: the odd months have 30, the even
+// ones 29, and Dhu al-Hijjah 30 in leap years.
+func (h HijriDate) monthLength() int {
+	if h.Month%2 == 1 || (h.Month == 12 && isHijriLeapYear(h.Year)) {
+		return 30
+	}
+	return 29
+}
+
+// valid reports whether h is a real tabular date, so 30 Safar or 30 Dhu al-Hijjah in a
+// common year is refused rather than rolled over into the next month.
+func (h HijriDate) valid() bool {
+	return h.Year >= 1 && h.Month >= 1 && h.Month <= 12 && h.Day >= 1 && h.Day <= h.monthLength()
+}
+
+// addDays is the Hijri date n days after h, counted in Julian days.
+func (h HijriDate) addDays(n int) HijriDate {
+	return hijriFromJulianDay(hijriToJulianDay(h.Year, h.Month, h.Day) + float64(n))
+}
+
+// nextMonth returns the first day of the following Hijri month.
+func (h HijriDate) nextMonth() HijriDate {
+	if h.Month == 12 {
+		return HijriDate{Year: h.Year + 1, Month: 1, Day: 1}
+	}
+	return HijriDate{Year: h.Year, Month: h.Month + 1, Day: 1}
+}
+
+// tabularGregorian converts a tabular Hijri date back to a Gregorian date (midnight UTC).
+func tabularGregorian(h HijriDate) time.Time {
+	return fromJulianDay(hijriToJulianDay(h.Year, h.Month, h.Day))
+}
+
+// apiHijri is h in the shape the API gives Hijri dates in.
+func (h HijriDate) apiHijri() Hijri {
+	return Hijri{
+		Date:  fmt.Sprintf("%02d-%02d-%d", h.Day, h.Month, h.Year),
+		Day:   fmt.Sprintf("%02d", h.Day),
+		Month: Month{Number: h.Month, En: hijriMonthNames[h.Month], Ar: hijriMonthNamesAr[h.Month]},
+		Year:  strconv.Itoa(h.Year),
+	}
+}
+
+// sameHijri reports whether two Hijri dates are the same day, however their day is padded.
+func sameHijri(a, b Hijri) bool {
+	dayA, _ := strconv.Atoi(a.Day)
+	dayB, _ := strconv.Atoi(b.Day)
+	return dayA == dayB && a.Month.Number == b.Month.Number && a.Year == b.Year
+}
+
+// hijriDates is a day's Hijri date in the calendar the config follows, and the other
+// calendar's date if the two disagree, as they often do around the start of a month.
+func (c Config) hijriDates(date Date) (Hijri, *Hijri) {
+	day, err := time.Parse("02-01-2006", date.Gregorian.Date)
+	if err != nil {
+		return date.Hijri, nil
+	}
+	ummAlQura, tabular := date.Hijri, tabularHijri(addDays(day, c.HijriAdjustment)).apiHijri()
+	tabular.Weekday = date.Hijri.Weekday
+	if sameHijri(ummAlQura, tabular) {
+		return ummAlQura, nil
+	}
+	if c.HijriCalendar == hijriTabular {
+		return tabular, &ummAlQura
+	}
+	return ummAlQura, &tabular
+}
+
+// hijriDate is a day's Hijri date in the calendar the config follows.
+func (c Config) hijriDate(date Date) Hijri {
+	h, _ := c.hijriDates(date)
+	return h
+}
+
+// otherHijriCalendar names the calendar the config doesn't follow.
+func (c Config) otherHijriCalendar() string {
+	if c.HijriCalendar == hijriTabular {
+		return hijriUmmAlQura
+	}
+	return hijriTabular
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTabularHijri(t *testing.T) {
+	tests := []struct {
+		date string
+		want HijriDate
+	}{
+		{"2025-03-01", HijriDate{1446, 9, 1}},   // 1 Ramadan
+		{"2025-02-28", HijriDate{1446, 8, 29}},  // the last of Sha'ban
+		{"2024-07-08", HijriDate{1446, 1, 1}},   // 1 Muharram
+		{"2024-07-07", HijriDate{1445, 12, 30}}, // a leap year's 30 Dhu al-Hijjah
+		{"2000-01-01", HijriDate{1420, 9, 24}},
+	}
+	for _, tt := range tests {
+		date, _ := time.Parse("2006-01-02", tt.date)
+		if got := tabularHijri(date); got != tt.want {
+			t.Errorf("tabularHijri(%s) = %+v, want %+v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestIsHijriLeapYear(t *testing.T) {
+	// Years 2, 5, 7, 10, 13, 16, 18, 21, 24, 26, and 29 of each 30-year cycle
+	leap := map[int]bool{2: true, 5: true, 7: true, 10: true, 13: true, 16: true, 18: true, 21: true, 24: true, 26: true, 29: true}
+	for year := 1; year <= 1500; year++ {
+		if got, want := isHijriLeapYear(year), leap[(year-1)%30+1]; got != want {
+			t.Errorf("isHijriLeapYear(%d) = %v, want %v", year, got, want)
+		}
+		days := 0
+		for month := 1; month <= 12; month++ {
+			days += HijriDate{Year: year, Month: month, Day: 1}.monthLength()
+		}
+		if days != hijriYearLength(year) {
+			t.Errorf("year %d: months add up to %d days, want %d", year, days, hijriYearLength(year))
+		}
+	}
+}
+
+func TestDhuAlHijjah30(t *testing.T) {
+	// 1445 is a leap year, 1446 isn't
+	if !(HijriDate{1445, 12, 30}).valid() {
+		t.Error("30 Dhu al-Hijjah 1445 is invalid, want valid")
+	}
+	if (HijriDate{1446, 12, 30}).valid() {
+		t.Error("30 Dhu al-Hijjah 1446 is valid, want invalid")
+	}
+	if (HijriDate{1446, 2, 30}).valid() {
+		t.Error("30 Safar is valid, want invalid")
+	}
+
+	tests := []struct {
+		from HijriDate
+		n    int
+		want HijriDate
+	}{
+		{HijriDate{1445, 12, 29}, 1, HijriDate{1445, 12, 30}},
+		{HijriDate{1445, 12, 30}, 1, HijriDate{1446, 1, 1}},
+		{HijriDate{1446, 12, 29}, 1, HijriDate{1447, 1, 1}},
+		{HijriDate{1446, 1, 1}, -1, HijriDate{1445, 12, 30}},
+		{HijriDate{1446, 9, 1}, 29, HijriDate{1446, 9, 30}},
+		{HijriDate{1446, 9, 1}, 30, HijriDate{1446, 10, 1}},
+		{HijriDate{1446, 1, 1}, 354, HijriDate{1447, 1, 1}},
+	}
+	for _, tt := range tests {
+		if got := tt.from.addDays(tt.n); got != tt.want {
+			t.Errorf("%+v.addDays(%d) = %+v, want %+v", tt.from, tt.n, got, tt.want)
+		}
+	}
+
+	if got := tabularGregorian(HijriDate{1445, 12, 30}).Format("2006-01-02"); got != "2024-07-07" {
+		t.Errorf("30 Dhu al-Hijjah 1445 = %s, want 2024-07-07", got)
+	}
+}
+
+// Every day from 1800 to 2300 survives the trip to the tabular calendar and back, and
+// consecutive days stay consecutive.
+func TestTabularRoundTrip(t *testing.T) {
+	start := time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)
+	prev := tabularHijri(start.AddDate(0, 0, -1))
+	for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+		h := tabularHijri(date)
+		if !h.valid() {
+			t.Fatalf("tabularHijri(%s) = %+v, not a valid date", date.Format("2006-01-02"), h)
+		}
+		if back := tabularGregorian(h); !back.Equal(date) {
+			t.Fatalf("%s → %+v → %s", date.Format("2006-01-02"), h, back.Format("2006-01-02"))
+		}
+		if next := prev.addDays(1); next != h {
+			t.Fatalf("%+v.addDays(1) = %+v, want %+v", prev, next, h)
+		}
+		prev = h
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// timingsOptions are the flags of the timings view: another day than today, such as one
+// in the past, and a time on it to place among that day's prayers
+type timingsOptions struct {
+	Date string
+	At   string
+}
+
+// day reads --date, like 2023-04-10; zero when it isn't given.
+func (o timingsOptions) day() (time.Time, error) {
+	if o.Date == "" {
+		return time.Time{}, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", o.Date, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --date %q (expected YYYY-MM-DD)", o.Date)
+	}
+	return day, nil
+}
+
+// check validates --date and --at before anything is fetched.
+func (o timingsOptions) check() error {
+	if _, err := o.day(); err != nil {
+		return err
+	}
+	if _, err := time.Parse("15:04", o.At); o.At != "" && err != nil {
+		return fmt.Errorf("invalid --at time %q (expected HH:MM)", o.At)
+	}
+	return nil
+}
+
+// today is whether the timings are for today, so the next prayer and countdown apply.
+func (o timingsOptions) today() bool {
+	return o.Date == ""
+}
+
+// fetch fetches the timings for --date, or today's.
+func (o timingsOptions) fetch(ctx context.Context, city, country string, method int, cfg Config) (*PrayerTimesResponse, error) {
+	day, err := o.day()
+	if err != nil {
+		return nil, err
+	}
+	if day.IsZero() {
+		return fetchPrayerTimes(ctx, city, country, method, cfg)
+	}
+	return fetchPrayerTimesOn(ctx, day, city, country, method, cfg)
+}
+
+// placeMoment says which of a day's prayers --at fell between, like "14:30 was after Dhuhr
+// (12:05) and before Asr (15:28)", reading the time in the city's timezone.
+func (o timingsOptions) placeMoment(data Data) (string, error) {
+	at, err := time.Parse("15:04", o.At)
+	if err != nil {
+		return "", fmt.Errorf("invalid --at time %q (expected HH:MM)", o.At)
+	}
+	loc, err := time.LoadLocation(data.Meta.Timezone)
+	if err != nil {
+		loc = time.Local
+	}
+	day, err := time.ParseInLocation("02-01-2006", data.Date.Gregorian.Date, loc)
+	if err != nil {
+		return "", fmt.Errorf("unexpected date %q from the API", data.Date.Gregorian.Date)
+	}
+	moment := wallClock(day, at.Hour(), at.Minute(), "")
+
+	timings := map[string]string{
+		"Fajr":    data.Timings.Fajr,
+		"Sunrise": data.Timings.Sunrise,
+		"Dhuhr":   data.Timings.Dhuhr,
+		"Asr":     data.Timings.Asr,
+		"Maghrib": data.Timings.Maghrib,
+		"Isha":    data.Timings.Isha,
+	}
+	var before, after string
+	var beforeTime, afterTime time.Time
+	for _, prayer := range prayerOrder {
+		t, err := parseTimeOn(timings[prayer], day)
+		if err != nil {
+			return "", err
+		}
+		if t.After(moment) {
+			before, beforeTime = prayer, t
+			break
+		}
+		after, afterTime = prayer, t
+	}
+
+	prayerAt := func(prayer string, t time.Time) string {
+		return fmt.Sprintf("%s (%s)", prayer, t.Format("15:04"))
+	}
+	verb := "was"
+	if !moment.Before(clock.Now()) {
+		verb = "is"
+	}
+	switch {
+	case after == "":
+		return fmt.Sprintf("%s %s before %s", o.At, verb, prayerAt(before, beforeTime)), nil
+	case before == "":
+		return fmt.Sprintf("%s %s after %s", o.At, verb, prayerAt(after, afterTime)), nil
+	}
+	return fmt.Sprintf("%s %s after %s and before %s", o.At, verb, prayerAt(after, afterTime), prayerAt(before, beforeTime)), nil
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// How long a hook may run before it is killed
+const hookTimeout = time.Minute
+
+// runHooks runs each command through the shell, passing the event in PRAY_* variables.
+// A failing hook is reported and doesn't stop the others.
+func runHooks(ctx context.Context, commands []string, event daemonEvent, city string) {
+	for _, command := range commands {
+		if err := runHook(ctx, command, event, city); err != nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("(hook %q: %v)", command, err)))
+		}
+	}
+}
+
+func runHook(ctx context.Context, command string, event daemonEvent, city string) error {
+	return runCommand(ctx, command,
+		"PRAY_EVENT="+event.Kind,
+		"PRAY_PRAYER="+event.Prayer,
+		"PRAY_TIME="+event.At.Format("15:04"),
+		"PRAY_CITY="+city,
+	)
+}
+
+// runCommand runs a command through the shell with extra environment variables, returning
+// its output with the error when it fails.
+func runCommand(ctx context.Context, command string, env ...string) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Env = append(os.Environ(), env...)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%v: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// hookNotifier runs the on_notify hooks for every notification, with its text in
+// PRAY_TITLE and PRAY_BODY, for backends pray doesn't have.
+type hookNotifier struct {
+	commands []string
+}
+
+func (hookNotifier) name() string { return "hook" }
+
+func (h hookNotifier) notify(ctx context.Context, n notification) error {
+	var failed []string
+	for _, command := range h.commands {
+		if err := runCommand(ctx, command, "PRAY_TITLE="+n.Title, "PRAY_BODY="+n.Body); err != nil {
+			failed = append(failed, fmt.Sprintf("%q: %v", command, err))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("hook %s", strings.Join(failed, "; "))
+	}
+	return nil
+}
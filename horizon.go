@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// Format of an observed sunrise or sunset in the config
+const observedLayout = "2006-01-02 15:04"
+
+// HorizonConfig is the horizon at home when hills or buildings hide the sea-level one, so
+// pray sun gives the sunrise and sunset actually seen there
+type HorizonConfig struct {
+	// Degrees the horizon is raised where the sun rises and where it sets; negative when
+	// it's lower, as from a height
+	Sunrise float64 `yaml:"sunrise"`
+	Sunset  float64 `yaml:"sunset"`
+
+	// A sunrise and sunset seen from home, like 2026-03-14 06:41, to work the horizon out
+	// from instead
+	ObservedSunrise string `yaml:"observed_sunrise"`
+	ObservedSunset  string `yaml:"observed_sunset"`
+
+	// Minutes to move pray sun's sunrise and sunset by after that. Prayer times aren't
+	// moved; tune does that for Maghrib and the rest.
+	SunriseOffset int `yaml:"sunrise_offset"`
+	SunsetOffset  int `yaml:"sunset_offset"`
+}
+
+// set is whether there's anything to change the sea-level sunrise and sunset by.
+func (h HorizonConfig) set() bool {
+	return h.Sunrise != 0 || h.Sunset != 0 || h.ObservedSunrise != "" || h.ObservedSunset != "" || h.SunriseOffset != 0 || h.SunsetOffset != 0
+}
+
+// check validates the observed times' format.
+func (h HorizonConfig) check() error {
+	for key, s := range map[string]string{"observed_sunrise": h.ObservedSunrise, "observed_sunset": h.ObservedSunset} {
+		if _, err := time.Parse(observedLayout, s); s != "" && err != nil {
+			return fmt.Errorf("invalid horizon.%s %q (expected %s)", key, s, observedLayout)
+		}
+	}
+	return nil
+}
+
+// altitudes is the sun's altitude at the sunrise and sunset seen at a place: where it
+// was at an observed one, or sunriseAltitude raised by the configured degrees.
+func (h HorizonConfig) altitudes(latitude, longitude float64, loc *time.Location) (rise, set float64, err error) {
+	observed := func(key, s string, morning bool, raise float64) (float64, error) {
+		if s == "" {
+			return sunriseAltitude + raise, nil
+		}
+		t, err := time.ParseInLocation(observedLayout, s, loc)
+		if err != nil {
+			return 0, fmt.Errorf("invalid horizon.%s %q (expected %s)", key, s, observedLayout)
+		}
+		altitude, hourAngle, _ := sunAltitude(t, latitude, longitude)
+		if (hourAngle < 0) != morning {
+			half := "morning"
+			if morning {
+				half = "afternoon"
+			}
+			return 0, fmt.Errorf("horizon.%s %s is in the %s", key, s, half)
+		}
+		return altitude, nil
+	}
+	if rise, err = observed("observed_sunrise", h.ObservedSunrise, true, h.Sunrise); err != nil {
+		return 0, 0, err
+	}
+	if set, err = observed("observed_sunset", h.ObservedSunset, false, h.Sunset); err != nil {
+		return 0, 0, err
+	}
+	return rise, set, nil
+}
+
+// visibleSunDay is the sun's day at home with the horizon folded in: the sunrise and
+// sunset at its altitudes, then moved by the offsets.
+func (h HorizonConfig) visibleSunDay(date time.Time, latitude, longitude, rise, set float64) sunDay {
+	d := computeSunDay(date, latitude, longitude, rise, set)
+	if !d.Sunrise.IsZero() {
+		d.Sunrise = d.Sunrise.Add(time.Duration(h.SunriseOffset) * time.Minute)
+		d.Sunset = d.Sunset.Add(time.Duration(h.SunsetOffset) * time.Minute)
+	}
+	return d
+}
+
+// describeHorizon sums up the horizon's altitudes, like 3.2° up in the east, 1.5° up in the west.
+func describeHorizon(rise, set float64) string {
+	side := func(altitude float64, direction string) string {
+		raise := altitude - sunriseAltitude
+		switch {
+		case raise > 0.05:
+			return fmt.Sprintf("%.1f° up in the %s", raise, direction)
+		case raise < -0.05:
+			return fmt.Sprintf("%.1f° down in the %s", -raise, direction)
+		}
+		return "level in the " + direction
+	}
+	return side(rise, "east") + ", " + side(set, "west")
+}
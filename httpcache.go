@@ -0,0 +1,345 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cached responses not used for this long are removed
+const httpCacheMaxAge = 31 * 24 * time.Hour
+
+// A fetch lock older than this was left by a process that died, and is taken over. It
+// outlasts a fetch with all its retries.
+const staleFetchLock = 2 * time.Minute
+
+// How often a process waiting on another's fetch checks whether it's done
+const fetchLockPoll = 50 * time.Millisecond
+
+// cachedResponse is an API response kept on disk with what's needed to revalidate it
+type cachedResponse struct {
+	URL          string    `json:"url"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Fetched      time.Time `json:"fetched"`
+	Expires      time.Time `json:"expires"`
+	Body         []byte    `json:"body"`
+}
+
+// fresh reports whether the response can be used without asking the server.
+func (r *cachedResponse) fresh(now time.Time) bool {
+	return now.Before(r.Expires)
+}
+
+// revalidates reports whether the server can answer 304 Not Modified for the response.
+func (r *cachedResponse) revalidates() bool {
+	return r.ETag != "" || r.LastModified != ""
+}
+
+// update takes the validators and lifetime from the headers of a 200 or 304.
+func (r *cachedResponse) update(header http.Header, now time.Time) {
+	if etag := header.Get("ETag"); etag != "" {
+		r.ETag = etag
+	}
+	if modified := header.Get("Last-Modified"); modified != "" {
+		r.LastModified = modified
+	}
+	r.Fetched = now
+	midnight := r.midnight(now)
+	// Calculated timings don't change during the day, so when the server doesn't say how
+	// long to keep them they stay fresh until midnight. This lets a shell prompt show the
+	// next prayer without going to the network.
+	if header.Get("Cache-Control") == "" {
+		r.Expires = midnight
+		return
+	}
+	r.Expires = now.Add(maxAge(header.Get("Cache-Control")))
+	// Today's timings are asked for without a date, so never keep using them past midnight
+	if r.Expires.After(midnight) {
+		r.Expires = midnight
+	}
+}
+
+// midnight is the next midnight here or in the city the timings are for, whichever is
+// first: after either, "today" is another day for one of them.
+func (r *cachedResponse) midnight(now time.Time) time.Time {
+	midnight := dayStart(addDays(now, 1))
+
+	var timings struct {
+		Data struct {
+			Meta struct {
+				Timezone string `json:"timezone"`
+			} `json:"meta"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(r.Body, &timings); err != nil || timings.Data.Meta.Timezone == "" {
+		return midnight
+	}
+	loc, err := time.LoadLocation(timings.Data.Meta.Timezone)
+	if err != nil {
+		return midnight
+	}
+	there := now.In(loc)
+	if cityMidnight := dayStart(addDays(there, 1)); cityMidnight.Before(midnight) {
+		return cityMidnight
+	}
+	return midnight
+}
+
+// maxAge reads max-age from a Cache-Control header; no-cache, or no max-age, is 0.
+func maxAge(cacheControl string) time.Duration {
+	var age time.Duration
+	for _, directive := range strings.Split(cacheControl, ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(name) {
+		case "no-cache":
+			return 0
+		case "max-age":
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				age = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return age
+}
+
+// noStore reports whether a response asks not to be kept.
+func noStore(header http.Header) bool {
+	for _, directive := range strings.Split(header.Get("Cache-Control"), ",") {
+		if strings.EqualFold(strings.TrimSpace(directive), "no-store") {
+			return true
+		}
+	}
+	return false
+}
+
+// responseCache stores API responses by URL. Backends are best effort: one that can't be
+// read or written is the same as an empty one.
+type responseCache interface {
+	// load returns the cached response for url, or nil.
+	load(url string) *cachedResponse
+	store(r *cachedResponse)
+
+	// lock makes the processes fetching url take turns, so when the daemon, a widget, and
+	// the CLI ask for the same timings at once only the first goes to the network; the
+	// others wait and then find its response in the cache. Within a process,
+	// apiClient.get already shares one call.
+	lock(ctx context.Context, url string) (func(), error)
+}
+
+// Cache backends for the cache setting, besides a redis:// URL
+const (
+	cacheDisk   = "disk"
+	cacheMemory = "memory"
+)
+
+// newResponseCache picks the backend for the cache setting: disk (the default) for the
+// CLI, memory where nothing should be written, or a Redis URL shared by several
+// instances of pray serve.
+func newResponseCache(backend string) (responseCache, error) {
+	switch {
+	case backend == "" || backend == cacheDisk:
+		return &diskCache{}, nil
+	case backend == cacheMemory:
+		return newMemoryCache(), nil
+	case strings.HasPrefix(backend, "redis://") || strings.HasPrefix(backend, "rediss://"):
+		return newRedisCache(backend)
+	}
+	return nil, fmt.Errorf("unknown cache %q (expected disk, memory, or a redis:// URL)", backend)
+}
+
+// lockKey is where a cache keeps the fetch lock for url.
+func lockKey(url string) string {
+	return cacheKey(url) + ".lock"
+}
+
+// cacheKey names a URL's entry; URLs can be long and full of characters files can't have.
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
+
+// waitForLock retries tryLock until it takes the lock or ctx is done.
+func waitForLock(ctx context.Context, tryLock func() bool) error {
+	for !tryLock() {
+		if err := sleepContext(ctx, fetchLockPoll); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Most responses the memory cache keeps; past that the oldest go first
+const memoryCacheEntries = 1024
+
+// memoryCache keeps responses for as long as the process runs, for pray serve where the
+// filesystem is read-only or shouldn't be written to.
+type memoryCache struct {
+	mu        sync.Mutex
+	responses map[string]*cachedResponse
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{responses: map[string]*cachedResponse{}}
+}
+
+func (c *memoryCache) load(url string) *cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, ok := c.responses[url]
+	if !ok {
+		return nil
+	}
+	// A copy, so revalidating it doesn't change what other callers see
+	copied := *r
+	return &copied
+}
+
+func (c *memoryCache) store(r *cachedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.responses[r.URL]; !ok && len(c.responses) >= memoryCacheEntries {
+		var oldest *cachedResponse
+		for _, cached := range c.responses {
+			if oldest == nil || cached.Fetched.Before(oldest.Fetched) {
+				oldest = cached
+			}
+		}
+		delete(c.responses, oldest.URL)
+	}
+	copied := *r
+	c.responses[r.URL] = &copied
+}
+
+// lock doesn't wait: a memory cache isn't shared with other processes.
+func (c *memoryCache) lock(ctx context.Context, url string) (func(), error) {
+	return func() {}, nil
+}
+
+// diskCache keeps API responses on disk, one file per URL, so the daemon, the widgets, and
+// the CLI all revalidate the same copy instead of downloading it again.
+type diskCache struct {
+	prune sync.Once
+}
+
+// httpCacheDir is pray/http in the user cache directory (~/.cache on Linux).
+func httpCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "pray", "http"), nil
+}
+
+// lock takes a lock file next to the response. Without a usable cache directory, lock
+// doesn't wait.
+func (c *diskCache) lock(ctx context.Context, url string) (func(), error) {
+	dir, err := httpCacheDir()
+	if err != nil {
+		return func() {}, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return func() {}, nil
+	}
+	path := filepath.Join(dir, lockKey(url))
+
+	usable := true
+	err = waitForLock(ctx, func() bool {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return true
+		}
+		if !os.IsExist(err) {
+			usable = false
+			return true
+		}
+		// Left by a process that died
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > staleFetchLock {
+			os.Remove(path)
+		}
+		return false
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !usable {
+		return func() {}, nil
+	}
+	return func() { os.Remove(path) }, nil
+}
+
+func (c *diskCache) load(url string) *cachedResponse {
+	dir, err := httpCacheDir()
+	if err != nil {
+		return nil
+	}
+	path := filepath.Join(dir, cacheKey(url)+".json")
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var r cachedResponse
+	if err := json.Unmarshal(raw, &r); err != nil || r.URL != url {
+		return nil
+	}
+	return &r
+}
+
+// store writes a response atomically, so another process never reads half of it.
+func (c *diskCache) store(r *cachedResponse) {
+	dir, err := httpCacheDir()
+	if err != nil {
+		return
+	}
+	path := filepath.Join(dir, cacheKey(r.URL)+".json")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return
+	}
+	c.prune.Do(func() { pruneHTTPCache(dir) })
+
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(raw)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// pruneHTTPCache removes responses that haven't been written for a month, like past
+// days' timings.
+func pruneHTTPCache(dir string) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-httpCacheMaxAge)
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(filepath.Join(dir, entry.Name()))
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Duration styles for the durations setting
+const (
+	durationsExact     = "exact"
+	durationsHumanized = "humanized"
+)
+
+// Durations under this are "now" in humanized phrasing
+const humanizedNow = time.Minute
+
+// humanizers phrase a signed duration relative to now, per language. Positive durations
+// are in the future, negative ones in the past.
+var humanizers = map[string]func(time.Duration) string{
+	"en": humanizeEnglish,
+	"ar": humanizeArabic,
+}
+
+// humanizeDuration phrases d like "in about an hour and a half" or "started 5 minutes ago",
+// falling back to English for languages without a humanizer.
+func humanizeDuration(lang string, d time.Duration) string {
+	humanize, ok := humanizers[lang]
+	if !ok {
+		humanize = humanizeEnglish
+	}
+	return humanize(d)
+}
+
+// relativeDuration is the countdown text for the configured durations style:
+// "in 1h 5m" or "started 20m ago" when exact, "in about an hour" when humanized.
+func relativeDuration(cfg Config, d, unit time.Duration) string {
+	if cfg.Durations == durationsHumanized {
+		return cfg.digits(humanizeDuration(cfg.Language, d))
+	}
+	if d < 0 {
+		return "started " + cfg.digits(formatDurationTo((-d).Truncate(unit), unit)) + " ago"
+	}
+	return "in " + cfg.digits(formatDurationTo(d, unit))
+}
+
+// capitalize upper-cases the first letter, for phrases that start a line.
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// halfHours rounds an hour or more to the nearest half hour. exact reports whether no
+// rounding was needed, so the phrase can drop "about".
+func halfHours(d time.Duration) (hours int, half, exact bool) {
+	halves := int((d + 15*time.Minute) / (30 * time.Minute))
+	exact = d%(30*time.Minute) == 0
+	return halves / 2, halves%2 == 1, exact
+}
+
+func humanizeEnglish(d time.Duration) string {
+	past := d < 0
+	if past {
+		d = -d
+	}
+	if d < humanizedNow {
+		return "starting now"
+	}
+
+	var phrase string
+	if d < time.Hour {
+		// Round up like the exact countdown, so "in 1 minute" holds until the minute is over
+		minutes := int((d + time.Minute - 1) / time.Minute)
+		if past {
+			minutes = int(d / time.Minute)
+		}
+		if minutes == 60 {
+			phrase = "an hour"
+		} else if minutes == 1 {
+			phrase = "a minute"
+		} else {
+			phrase = fmt.Sprintf("%d minutes", minutes)
+		}
+	} else {
+		hours, half, exact := halfHours(d)
+		switch {
+		case hours == 1 && half:
+			phrase = "an hour and a half"
+		case hours == 1:
+			phrase = "an hour"
+		case half:
+			phrase = fmt.Sprintf("%d and a half hours", hours)
+		default:
+			phrase = fmt.Sprintf("%d hours", hours)
+		}
+		if !exact {
+			phrase = "about " + phrase
+		}
+	}
+
+	if past {
+		return "started " + phrase + " ago"
+	}
+	return "in " + phrase
+}
+
+// arabicCount picks the Arabic noun form for n: singular, dual, plural for 3–10, and
+// the singular accusative for 11 and over.
+func arabicCount(n int, one, two, few, many string) string {
+	switch {
+	case n == 1:
+		return one
+	case n == 2:
+		return two
+	case n%100 >= 3 && n%100 <= 10:
+		return fmt.Sprintf("%d %s", n, few)
+	default:
+		return fmt.Sprintf("%d %s", n, many)
+	}
+}
+
+func humanizeArabic(d time.Duration) string {
+	past := d < 0
+	if past {
+		d = -d
+	}
+	if d < humanizedNow {
+		return "الآن"
+	}
+
+	var phrase string
+	if d < time.Hour {
+		minutes := int((d + time.Minute - 1) / time.Minute)
+		if past {
+			minutes = int(d / time.Minute)
+		}
+		if minutes == 60 {
+			phrase = "ساعة"
+		} else {
+			phrase = arabicCount(minutes, "دقيقة", "دقيقتين", "دقائق", "دقيقة")
+		}
+	} else {
+		hours, half, exact := halfHours(d)
+		phrase = arabicCount(hours, "ساعة", "ساعتين", "ساعات", "ساعة")
+		if half {
+			phrase += " ونصف"
+		}
+		if !exact {
+			phrase += " تقريبًا"
+		}
+	}
+
+	if past {
+		return "بدأت منذ " + phrase
+	}
+	return "بعد " + phrase
+}
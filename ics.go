@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limits on the days query parameter of the iCalendar feed
+const (
+	defaultFeedDays = 30
+	maxFeedDays     = 366
+)
+
+// How often subscribed calendars are asked to refresh the feed
+const feedRefresh = "PT12H"
+
+// handleICS serves the next days of prayers as an iCalendar feed. Calendar apps subscribe
+// to it (webcal://) and refresh it on their own, so it stays current without re-importing.
+func (s *server) handleICS(w http.ResponseWriter, r *http.Request) {
+	city, country, method, err := s.location(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	days := defaultFeedDays
+	if v := r.URL.Query().Get("days"); v != "" {
+		if days, err = strconv.Atoi(v); err != nil || days < 1 || days > maxFeedDays {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid days %q (expected 1 to %d)", v, maxFeedDays))
+			return
+		}
+	}
+
+	feed, err := s.prayerFeed(r, city, country, method, days)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="prayer-times.ics"`)
+	w.Header().Set("Cache-Control", "max-age=3600")
+	w.Write([]byte(feed))
+}
+
+// prayerFeed fetches the months covering the next days and builds the calendar.
+func (s *server) prayerFeed(r *http.Request, city, country string, method, days int) (string, error) {
+	start := clock.Now()
+	end := addDays(start, days-1)
+	first, last := start.Format("2006-01-02"), end.Format("2006-01-02")
+
+	var cal icsWriter
+	cal.line("BEGIN:VCALENDAR")
+	cal.line("VERSION:2.0")
+	cal.line("PRODID:-//pray//Prayer Times//EN")
+	cal.line("CALSCALE:GREGORIAN")
+	cal.line("METHOD:PUBLISH")
+	cal.property("X-WR-CALNAME", "Prayer times · "+city)
+	cal.line("REFRESH-INTERVAL;VALUE=DURATION:" + feedRefresh)
+	cal.line("X-PUBLISHED-TTL:" + feedRefresh)
+
+	stamp := time.Now().UTC().Format("20060102T150405Z")
+	year, month := start.Year(), start.Month()
+	for year < end.Year() || (year == end.Year() && month <= end.Month()) {
+		calendar, err := fetchCalendar(r.Context(), city, country, method, s.cfg, year, int(month))
+		if err != nil {
+			return "", err
+		}
+		for _, day := range calendar.Data {
+			date := isoDate(day.Date.Gregorian.Date)
+			if date < first || date > last {
+				continue
+			}
+			s.writeDayEvents(&cal, day, date, city, stamp)
+		}
+		if month++; month > time.December {
+			year, month = year+1, time.January
+		}
+	}
+
+	cal.line("END:VCALENDAR")
+	return cal.String(), nil
+}
+
+// writeDayEvents adds a day's five prayers, each lasting until the prayer is over: the
+// iqama, if configured, plus ten minutes. Times are in UTC, so calendars in any timezone
+// show them right.
+func (s *server) writeDayEvents(cal *icsWriter, day Data, date, city, stamp string) {
+	loc := time.Local
+	if l, err := time.LoadLocation(day.Meta.Timezone); err == nil {
+		loc = l
+	}
+	noon, err := time.ParseInLocation("2006-01-02 15:04", date+" 12:00", loc)
+	if err != nil {
+		return
+	}
+
+	prayerTimes := map[string]string{
+		"Fajr":    day.Timings.Fajr,
+		"Dhuhr":   day.Timings.Dhuhr,
+		"Asr":     day.Timings.Asr,
+		"Maghrib": day.Timings.Maghrib,
+		"Isha":    day.Timings.Isha,
+	}
+	for _, prayer := range []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"} {
+		adhan, err := parseTimeOn(prayerTimes[prayer], noon)
+		if err != nil {
+			continue
+		}
+		over := adhan.Add(time.Duration(s.cfg.iqamaDelay(prayer))*time.Minute + athkarDelay)
+
+		cal.line("BEGIN:VEVENT")
+		// Stable per city, day, and prayer, so a refresh updates events instead of adding them
+		cal.property("UID", fmt.Sprintf("%s-%s-%s@pray", date, strings.ToLower(prayer), strings.ToLower(strings.ReplaceAll(city, " ", "-"))))
+		cal.line("DTSTAMP:" + stamp)
+		cal.line("DTSTART:" + adhan.UTC().Format("20060102T150405Z"))
+		cal.line("DTEND:" + over.UTC().Format("20060102T150405Z"))
+		cal.property("SUMMARY", prayer)
+		cal.property("LOCATION", city)
+		cal.line("TRANSP:TRANSPARENT")
+		cal.line("END:VEVENT")
+	}
+}
+
+// icsWriter builds iCalendar text: CRLF line endings, long lines folded at 75 octets,
+// and text values escaped.
+type icsWriter struct {
+	strings.Builder
+}
+
+func (w *icsWriter) line(s string) {
+	// Continuation lines start with a space, which counts towards their 75
+	limit := 75
+	for len(s) > limit {
+		// Fold without splitting a UTF-8 sequence
+		cut := limit
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(s[:cut] + "\r\n ")
+		s = s[cut:]
+		limit = 74
+	}
+	w.WriteString(s + "\r\n")
+}
+
+func (w *icsWriter) property(name, value string) {
+	escaped := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(value)
+	w.line(name + ":" + escaped)
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Directory of the tmux plugin under the TPM plugins directory, and the name it's listed by
+const tmuxPluginName = "tmux-pray"
+
+// tmuxPlugin is a TPM plugin that replaces #{pray_next} in the status line with a
+// command tmux runs every status-interval. %s is the command.
+const tmuxPlugin = `#!/usr/bin/env bash
+# Written by pray install tmux. Replaces #{pray_next} in status-left and status-right
+# with the next prayer, e.g. "Asr 15:32 -42m".
+
+pray_next=%s
+pattern='#{pray_next}'
+
+interpolate() {
+	local value
+	value="$(tmux show-option -gqv "$1")"
+	tmux set-option -gq "$1" "${value//"$pattern"/$pray_next}"
+}
+
+interpolate status-left
+interpolate status-right
+`
+
+func newInstallCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install integrations with other tools",
+	}
+	cmd.AddCommand(newInstallTmuxCmd())
+	return cmd
+}
+
+func newInstallTmuxCmd() *cobra.Command {
+	var dir string
+
+	cmd := &cobra.Command{
+		Use:   "tmux",
+		Short: "Install a tmux plugin that shows the next prayer in the status line",
+		Long:  "Write a small TPM plugin that replaces #{pray_next} in status-left and status-right with pray glance, and print the lines to add to tmux.conf. Location flags given here are passed on to pray glance.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if dir == "" {
+				var err error
+				if dir, err = tpmPluginsDir(); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+			}
+			path, err := installTmuxPlugin(dir, tmuxGlanceCommand(cmd))
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println(titleStyle.Render("🕌 tmux plugin installed"))
+			fmt.Println(strings.Repeat("━", 50))
+			fmt.Println(prayerStyle.Render(path))
+			fmt.Println()
+			fmt.Println(prayerStyle.Render("Add to tmux.conf, above the line that runs TPM:"))
+			fmt.Println()
+			fmt.Println(prayerStyle.Render("set -g @plugin '" + tmuxPluginName + "'"))
+			fmt.Println(prayerStyle.Render("set -g status-right '#{pray_next} | %H:%M'"))
+			fmt.Println()
+			fmt.Println(prayerStyle.Render("Without TPM, run the plugin after setting the status line instead:"))
+			fmt.Println()
+			fmt.Println(prayerStyle.Render("run-shell " + path))
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "TPM plugins directory (default $TMUX_PLUGIN_MANAGER_PATH or ~/.tmux/plugins)")
+	return cmd
+}
+
+// tpmPluginsDir is where TPM keeps plugins: $TMUX_PLUGIN_MANAGER_PATH, or next to an XDG
+// tmux.conf, or ~/.tmux/plugins.
+func tpmPluginsDir() (string, error) {
+	if dir := os.Getenv("TMUX_PLUGIN_MANAGER_PATH"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %v", err)
+	}
+	xdgConfig := os.Getenv("XDG_CONFIG_HOME")
+	if xdgConfig == "" {
+		xdgConfig = filepath.Join(home, ".config")
+	}
+	if _, err := os.Stat(filepath.Join(xdgConfig, "tmux", "tmux.conf")); err == nil {
+		return filepath.Join(xdgConfig, "tmux", "plugins"), nil
+	}
+	return filepath.Join(home, ".tmux", "plugins"), nil
+}
+
+// tmuxGlanceCommand is the status line command: pray glance by its full path, since tmux
+// may not have the same PATH, with the location flags given to pray install tmux.
+func tmuxGlanceCommand(cmd *cobra.Command) string {
+	exe, err := os.Executable()
+	if err != nil {
+		exe = "pray"
+	}
+	args := []string{shellQuote(exe), "glance"}
+	for _, name := range []string{"city", "country", "method"} {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			args = append(args, "--"+name, shellQuote(flag.Value.String()))
+		}
+	}
+	return "#(" + strings.Join(args, " ") + ")"
+}
+
+// installTmuxPlugin writes the plugin script and returns its path.
+func installTmuxPlugin(dir, command string) (string, error) {
+	pluginDir := filepath.Join(dir, tmuxPluginName)
+	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create %s: %v", pluginDir, err)
+	}
+	path := filepath.Join(pluginDir, "pray.tmux")
+	script := fmt.Sprintf(tmuxPlugin, shellQuote(command))
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		return "", fmt.Errorf("failed to write %s: %v", path, err)
+	}
+	// WriteFile keeps the mode of a file that already exists
+	if err := os.Chmod(path, 0o755); err != nil {
+		return "", fmt.Errorf("failed to make %s executable: %v", path, err)
+	}
+	return path, nil
+}
+
+// shellQuote quotes s for sh, so paths and city names with spaces survive.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
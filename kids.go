@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Stickers earned for a streak of days with all five prayers, biggest first
+var streakStickers = []struct {
+	Days    int
+	Sticker string
+	Name    string
+}{
+	{30, "🏆", "Champion"},
+	{14, "🥇", "Gold"},
+	{7, "🥈", "Silver"},
+	{3, "🥉", "Bronze"},
+	{1, "⭐", "Star"},
+}
+
+// Friendly names for the prayers, said the way a child would hear them
+var kidsPrayerHints = map[string]string{
+	"Fajr":    "at dawn",
+	"Dhuhr":   "at midday",
+	"Asr":     "in the afternoon",
+	"Maghrib": "at sunset",
+	"Isha":    "at night",
+}
+
+// streak is how many days in a row, up to today, a user logged all five prayers as prayed
+// or late. Today only counts once it's complete, so an unfinished day doesn't break it.
+func (s *Store) streak(user string, now time.Time) int {
+	complete := s.completeDays(user)
+
+	day := now
+	if !complete[day.Format("2006-01-02")] {
+		day = addDays(day, -1)
+	}
+	n := 0
+	for complete[day.Format("2006-01-02")] {
+		n++
+		day = addDays(day, -1)
+	}
+	return n
+}
+
+// streakSticker is the biggest sticker a streak has earned, or "" for none.
+func streakSticker(days int) (sticker, name string) {
+	for _, s := range streakStickers {
+		if days >= s.Days {
+			return s.Sticker, s.Name
+		}
+	}
+	return "", ""
+}
+
+// showKidsTimes renders today's five prayers for children: big, simple, with a tick for
+// each prayer logged today and a sticker for their streak. user is a name from pray log
+// --user, or "you" for the machine's own user.
+func showKidsTimes(city, user string, data *PrayerTimesResponse) error {
+	user = normalizeUser(user)
+	if user == userLabel("") {
+		user = ""
+	}
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+	now := clock.Now()
+
+	timings := map[string]string{
+		"Fajr":    data.Data.Timings.Fajr,
+		"Dhuhr":   data.Data.Timings.Dhuhr,
+		"Asr":     data.Data.Timings.Asr,
+		"Maghrib": data.Data.Timings.Maghrib,
+		"Isha":    data.Data.Timings.Isha,
+	}
+
+	greeting := "Salam!"
+	if user != "" {
+		greeting = fmt.Sprintf("Salam, %s!", capitalize(user))
+	}
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 %s Here are today's prayers", greeting)))
+	fmt.Println()
+
+	next := ""
+	var nextTime time.Time
+	for _, prayer := range trackedPrayers {
+		t, err := parseTime(timings[prayer])
+		if err != nil {
+			return err
+		}
+		if next == "" && t.After(now) {
+			next, nextTime = prayer, t
+		}
+
+		mark := "⬜"
+		switch store.prayerStatus(user, prayer, now) {
+		case statusPrayed, statusLate:
+			mark = "✅"
+		}
+		line := fmt.Sprintf("%s %s  %s", mark, padRight(prayerNames[prayer], 12), t.Format("3:04 PM"))
+		if prayer == next {
+			fmt.Println(nextPrayerStyle.Render(line + "  👈 next!"))
+		} else {
+			fmt.Println(prayerStyle.Render(line))
+		}
+	}
+	fmt.Println()
+
+	if next != "" {
+		d := nextTime.Sub(clock.Now())
+		fmt.Println(countdownStyle.Render(fmt.Sprintf("%s is %s, in:", prayerNames[next], kidsPrayerHints[next])))
+		fmt.Println(lipgloss.NewStyle().PaddingLeft(2).Render(timeStyle.Render(bigText(fmt.Sprintf("%d:%02d", int(d.Hours()), int(d.Minutes())%60)))))
+		fmt.Println(cityStyle.PaddingLeft(2).Render("hours : minutes"))
+	} else {
+		fmt.Println(countdownStyle.Render("All done for today! See you at Fajr 🌙"))
+	}
+	fmt.Println()
+
+	days := store.streak(user, now)
+	if sticker, name := streakSticker(days); sticker != "" {
+		dayWord := "days"
+		if days == 1 {
+			dayWord = "day"
+		}
+		fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s %s sticker! All five prayers %d %s in a row", sticker, name, days, dayWord)))
+		if days < streakStickers[0].Days {
+			for i := len(streakStickers) - 1; i >= 0; i-- {
+				if streakStickers[i].Days > days {
+					fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("%d more to earn %s", streakStickers[i].Days-days, streakStickers[i].Sticker)))
+					break
+				}
+			}
+		}
+	} else {
+		fmt.Println(cityStyle.PaddingLeft(2).Render("Pray all five today to earn your first ⭐"))
+	}
+	fmt.Println(cityStyle.PaddingLeft(2).Render("📍 " + city))
+	return nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+)
+
+// 3x5 block glyphs for the big countdown
+var bigGlyphs = map[rune][]string{
+	'0': {"###", "# #", "# #", "# #", "###"},
+	'1': {" # ", "## ", " # ", " # ", "###"},
+	'2': {"###", "  #", "###", "#  ", "###"},
+	'3': {"###", "  #", "###", "  #", "###"},
+	'4': {"# #", "# #", "###", "  #", "  #"},
+	'5': {"###", "#  ", "###", "  #", "###"},
+	'6': {"###", "#  ", "###", "# #", "###"},
+	'7': {"###", "  #", "  #", "  #", "  #"},
+	'8': {"###", "# #", "###", "# #", "###"},
+	'9': {"###", "# #", "###", "  #", "###"},
+	':': {" ", "#", " ", "#", " "},
+}
+
+// Refetch interval when the API is unreachable
+const kioskRetry = time.Minute
+
+// bigText renders digits and colons as block letters, each cell two columns wide.
+func bigText(s string) string {
+	rows := make([]string, 5)
+	for _, r := range s {
+		glyph, ok := bigGlyphs[r]
+		if !ok {
+			continue
+		}
+		for i, line := range glyph {
+			line = strings.ReplaceAll(line, "#", "██")
+			line = strings.ReplaceAll(line, " ", "  ")
+			rows[i] += line + "  "
+		}
+	}
+	return strings.Join(rows, "\n")
+}
+
+func runKiosk(ctx context.Context, city, country string, method int, cfg Config, interval time.Duration) {
+	showKiosk(ctx, city, country, method, cfg, interval, os.Stdout, func() (int, int) {
+		width, height, err := term.GetSize(os.Stdout.Fd())
+		if err != nil {
+			return 80, 24
+		}
+		return width, height
+	})
+}
+
+// showKiosk draws the kiosk slides on out until ctx is done, sized to the terminal on
+// every redraw.
+func showKiosk(ctx context.Context, city, country string, method int, cfg Config, interval time.Duration, out io.Writer, size func() (width, height int)) {
+	// Alternate screen, hidden cursor; restored on exit
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	hijri := func(data *PrayerTimesResponse, city string) string { return kioskHijri(data, city, cfg) }
+	slides := []func(*PrayerTimesResponse, string) string{kioskTable, kioskCountdown, hijri}
+
+	var data *PrayerTimesResponse
+	var fetchedDay int
+	var lastAttempt time.Time
+	var lastErr error
+	slide := 0
+	slideStart := clock.Now()
+
+	for {
+		now := clock.Now()
+
+		// Fetch on start and whenever the day rolls over
+		if (data == nil || fetchedDay != now.YearDay()) && now.Sub(lastAttempt) >= kioskRetry {
+			lastAttempt = now
+			fresh, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+			if err == nil {
+				data, fetchedDay = fresh, now.YearDay()
+			}
+			lastErr = err
+		}
+
+		if now.Sub(slideStart) >= interval {
+			slide = (slide + 1) % len(slides)
+			slideStart = now
+		}
+
+		var content string
+		if data == nil {
+			content = countdownStyle.Render(fmt.Sprintf("Unable to load prayer times\n\n%v", lastErr))
+		} else {
+			content = slides[slide](data, city)
+		}
+
+		width, height := size()
+		fmt.Fprint(out, "\x1b[H"+lipgloss.Place(width, height, lipgloss.Center, lipgloss.Center, content))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func kioskTable(data *PrayerTimesResponse, city string) string {
+	nextPrayer, _, err := findNextPrayer(data.Data.Timings)
+	if err != nil {
+		nextPrayer = ""
+	}
+
+	timings := map[string]string{
+		"Fajr":    data.Data.Timings.Fajr,
+		"Sunrise": data.Data.Timings.Sunrise,
+		"Dhuhr":   data.Data.Timings.Dhuhr,
+		"Asr":     data.Data.Timings.Asr,
+		"Maghrib": data.Data.Timings.Maghrib,
+		"Isha":    data.Data.Timings.Isha,
+	}
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("🕌 Prayer Times for %s", cityStyle.Render(city))),
+		strings.Repeat("━", 40),
+		"",
+	}
+	for _, prayer := range prayerOrder {
+		timeStr := strings.Split(timings[prayer], " ")[0]
+		label := padRight(prayerNames[prayer], 15) + " " + timeStr
+		if prayer == nextPrayer {
+			lines = append(lines, "▶ "+nextPrayerStyle.Render(label))
+		} else {
+			lines = append(lines, "  "+prayerStyle.Render(label))
+		}
+	}
+	lines = append(lines, "", clock.Now().Format("15:04:05"))
+
+	return strings.Join(lines, "\n")
+}
+
+func kioskCountdown(data *PrayerTimesResponse, city string) string {
+	nextPrayer, nextTime, err := findNextPrayer(data.Data.Timings)
+	if err != nil {
+		return countdownStyle.Render(err.Error())
+	}
+
+	remaining := nextTime.Sub(clock.Now())
+	if remaining < 0 {
+		remaining = 0
+	}
+	hours := int(remaining.Hours())
+	minutes := int(remaining.Minutes()) % 60
+	seconds := int(remaining.Seconds()) % 60
+
+	return lipgloss.JoinVertical(lipgloss.Center,
+		nextPrayerStyle.Render(fmt.Sprintf("%s at %s", prayerNames[nextPrayer], nextTime.Format("15:04"))),
+		"",
+		timeStyle.Render(bigText(fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds))),
+		"",
+		cityStyle.Render("📍 "+city),
+	)
+}
+
+func kioskHijri(data *PrayerTimesResponse, city string, cfg Config) string {
+	hijri, other := cfg.hijriDates(data.Data.Date)
+
+	lines := []string{
+		titleStyle.Render("📅 " + hijri.Weekday.En),
+		"",
+		timeStyle.Render(bigText(hijri.Day)),
+		"",
+		nextPrayerStyle.Render(fmt.Sprintf("%s %s AH", hijri.Month.En, hijri.Year)),
+		cityStyle.Render(fmt.Sprintf("%s  ·  %s", hijri.Month.Ar, cfg.gregorianDate(data.Data.Date.Gregorian))),
+	}
+	if other != nil {
+		lines = append(lines, prayerStyle.Render(fmt.Sprintf("≠ %s (%s)", cfg.hijriText(*other, false), tr(cfg.Language, cfg.otherHijriCalendar()))))
+	}
+	return lipgloss.JoinVertical(lipgloss.Center, lines...)
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+)
+
+// Terminal widths where the timings table switches layout
+const (
+	narrowLayoutWidth = 40
+	wideLayoutWidth   = 72
+)
+
+// Labels for the extended timings in the wide layout, which are not prayers
+var extendedNames = map[string]string{
+	"Imsak":     "Imsak",
+	"Midnight":  "Midnight",
+	"Lastthird": "Last third",
+}
+
+// Emoji sets for the emoji setting. minimal uses single-width symbols for fonts that
+// draw emoji at the wrong width; none leaves the names bare.
+var emojiSets = map[string]map[string]string{
+	"default": {
+		"Fajr":    "🌅",
+		"Sunrise": "☀️",
+		"Dhuhr":   "🌞",
+		"Asr":     "🌤️",
+		"Maghrib": "🌅",
+		"Isha":    "🌙",
+	},
+	"minimal": {
+		"Fajr":    "◐",
+		"Sunrise": "○",
+		"Dhuhr":   "●",
+		"Asr":     "◑",
+		"Maghrib": "◒",
+		"Isha":    "◓",
+	},
+	"none": {},
+}
+
+// prayerLabels builds the prayer names shown in tables from the emoji set, with any
+// per-prayer emojis from the config on top.
+func (c Config) prayerLabels() (map[string]string, error) {
+	set := c.Emoji
+	if set == "" {
+		set = "default"
+	}
+	emojis, ok := emojiSets[set]
+	if !ok {
+		return nil, fmt.Errorf("unknown emoji set %q (expected default, minimal, or none)", set)
+	}
+
+	labels := map[string]string{}
+	for _, prayer := range prayerOrder {
+		emoji := emojis[prayer]
+		if custom, ok := c.Emojis[prayer]; ok {
+			emoji = custom
+		}
+		if emoji == "" {
+			labels[prayer] = prayer
+		} else {
+			labels[prayer] = emoji + " " + prayer
+		}
+	}
+	return labels, nil
+}
+
+// padRight pads s with spaces to width terminal cells. Unlike %-15s it counts double-width
+// emoji and combining characters as the terminal draws them.
+func padRight(s string, width int) string {
+	if w := lipgloss.Width(s); w < width {
+		return s + strings.Repeat(" ", width-w)
+	}
+	return s
+}
+
+// terminalWidth is the width of the terminal on stdout. ok is false when stdout isn't a
+// terminal, such as when piped.
+func terminalWidth() (width int, ok bool) {
+	width, _, err := term.GetSize(os.Stdout.Fd())
+	if err != nil || width <= 0 {
+		return 0, false
+	}
+	return width, true
+}
@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Rows of an LED matrix module, and the bits of a column byte
+const ledRows = 8
+
+// ledFont is a 5x7 font for printable ASCII, five column bytes per character with bit 0
+// the top row, as in the HD44780 and most LED matrix libraries
+var ledFont = [95][5]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00}, // space
+	{0x00, 0x00, 0x5F, 0x00, 0x00}, // !
+	{0x00, 0x07, 0x00, 0x07, 0x00}, // "
+	{0x14, 0x7F, 0x14, 0x7F, 0x14}, // #
+	{0x24, 0x2A, 0x7F, 0x2A, 0x12}, // $
+	{0x23, 0x13, 0x08, 0x64, 0x62}, // %
+	{0x36, 0x49, 0x55, 0x22, 0x50}, // &
+	{0x00, 0x05, 0x03, 0x00, 0x00}, // '
+	{0x00, 0x1C, 0x22, 0x41, 0x00}, // (
+	{0x00, 0x41, 0x22, 0x1C, 0x00}, // )
+	{0x08, 0x2A, 0x1C, 0x2A, 0x08}, // *
+	{0x08, 0x08, 0x3E, 0x08, 0x08}, // +
+	{0x00, 0x50, 0x30, 0x00, 0x00}, // ,
+	{0x08, 0x08, 0x08, 0x08, 0x08}, // -
+	{0x00, 0x60, 0x60, 0x00, 0x00}, // .
+	{0x20, 0x10, 0x08, 0x04, 0x02}, // /
+	{0x3E, 0x51, 0x49, 0x45, 0x3E}, // 0
+	{0x00, 0x42, 0x7F, 0x40, 0x00}, // 1
+	{0x42, 0x61, 0x51, 0x49, 0x46}, // 2
+	{0x21, 0x41, 0x45, 0x4B, 0x31}, // 3
+	{0x18, 0x14, 0x12, 0x7F, 0x10}, // 4
+	{0x27, 0x45, 0x45, 0x45, 0x39}, // 5
+	{0x3C, 0x4A, 0x49, 0x49, 0x30}, // 6
+	{0x01, 0x71, 0x09, 0x05, 0x03}, // 7
+	{0x36, 0x49, 0x49, 0x49, 0x36}, // 8
+	{0x06, 0x49, 0x49, 0x29, 0x1E}, // 9
+	{0x00, 0x36, 0x36, 0x00, 0x00}, // :
+	{0x00, 0x56, 0x36, 0x00, 0x00}, // ;
+	{0x08, 0x14, 0x22, 0x41, 0x00}, // <
+	{0x14, 0x14, 0x14, 0x14, 0x14}, // =
+	{0x00, 0x41, 0x22, 0x14, 0x08}, // >
+	{0x02, 0x01, 0x51, 0x09, 0x06}, // ?
+	{0x32, 0x49, 0x79, 0x41, 0x3E}, // @
+	{0x7E, 0x11, 0x11, 0x11, 0x7E}, // A
+	{0x7F, 0x49, 0x49, 0x49, 0x36}, // B
+	{0x3E, 0x41, 0x41, 0x41, 0x22}, // C
+	{0x7F, 0x41, 0x41, 0x22, 0x1C}, // D
+	{0x7F, 0x49, 0x49, 0x49, 0x41}, // E
+	{0x7F, 0x09, 0x09, 0x01, 0x01}, // F
+	{0x3E, 0x41, 0x41, 0x51, 0x32}, // G
+	{0x7F, 0x08, 0x08, 0x08, 0x7F}, // H
+	{0x00, 0x41, 0x7F, 0x41, 0x00}, // I
+	{0x20, 0x40, 0x41, 0x3F, 0x01}, // J
+	{0x7F, 0x08, 0x14, 0x22, 0x41}, // K
+	{0x7F, 0x40, 0x40, 0x40, 0x40}, // L
+	{0x7F, 0x02, 0x04, 0x02, 0x7F}, // M
+	{0x7F, 0x04, 0x08, 0x10, 0x7F}, // N
+	{0x3E, 0x41, 0x41, 0x41, 0x3E}, // O
+	{0x7F, 0x09, 0x09, 0x09, 0x06}, // P
+	{0x3E, 0x41, 0x51, 0x21, 0x5E}, // Q
+	{0x7F, 0x09, 0x19, 0x29, 0x46}, // R
+	{0x46, 0x49, 0x49, 0x49, 0x31}, // S
+	{0x01, 0x01, 0x7F, 0x01, 0x01}, // T
+	{0x3F, 0x40, 0x40, 0x40, 0x3F}, // U
+	{0x1F, 0x20, 0x40, 0x20, 0x1F}, // V
+	{0x7F, 0x20, 0x18, 0x20, 0x7F}, // W
+	{0x63, 0x14, 0x08, 0x14, 0x63}, // X
+	{0x03, 0x04, 0x78, 0x04, 0x03}, // Y
+	{0x61, 0x51, 0x49, 0x45, 0x43}, // Z
+	{0x00, 0x7F, 0x41, 0x41, 0x00}, // [
+	{0x02, 0x04, 0x08, 0x10, 0x20}, // backslash
+	{0x00, 0x41, 0x41, 0x7F, 0x00}, // ]
+	{0x04, 0x02, 0x01, 0x02, 0x04}, // ^
+	{0x40, 0x40, 0x40, 0x40, 0x40}, // _
+	{0x00, 0x01, 0x02, 0x04, 0x00}, // `
+	{0x20, 0x54, 0x54, 0x54, 0x78}, // a
+	{0x7F, 0x48, 0x44, 0x44, 0x38}, // b
+	{0x38, 0x44, 0x44, 0x44, 0x20}, // c
+	{0x38, 0x44, 0x44, 0x48, 0x7F}, // d
+	{0x38, 0x54, 0x54, 0x54, 0x18}, // e
+	{0x08, 0x7E, 0x09, 0x01, 0x02}, // f
+	{0x0C, 0x52, 0x52, 0x52, 0x3E}, // g
+	{0x7F, 0x08, 0x04, 0x04, 0x78}, // h
+	{0x00, 0x44, 0x7D, 0x40, 0x00}, // i
+	{0x20, 0x40, 0x44, 0x3D, 0x00}, // j
+	{0x7F, 0x10, 0x28, 0x44, 0x00}, // k
+	{0x00, 0x41, 0x7F, 0x40, 0x00}, // l
+	{0x7C, 0x04, 0x18, 0x04, 0x78}, // m
+	{0x7C, 0x08, 0x04, 0x04, 0x78}, // n
+	{0x38, 0x44, 0x44, 0x44, 0x38}, // o
+	{0x7C, 0x14, 0x14, 0x14, 0x08}, // p
+	{0x08, 0x14, 0x14, 0x18, 0x7C}, // q
+	{0x7C, 0x08, 0x04, 0x04, 0x08}, // r
+	{0x48, 0x54, 0x54, 0x54, 0x20}, // s
+	{0x04, 0x3F, 0x44, 0x40, 0x20}, // t
+	{0x3C, 0x40, 0x40, 0x20, 0x7C}, // u
+	{0x1C, 0x20, 0x40, 0x20, 0x1C}, // v
+	{0x3C, 0x40, 0x30, 0x40, 0x3C}, // w
+	{0x44, 0x28, 0x10, 0x28, 0x44}, // x
+	{0x0C, 0x50, 0x50, 0x50, 0x3C}, // y
+	{0x44, 0x64, 0x54, 0x4C, 0x44}, // z
+	{0x00, 0x08, 0x36, 0x41, 0x00}, // {
+	{0x00, 0x00, 0x7F, 0x00, 0x00}, // |
+	{0x00, 0x41, 0x36, 0x08, 0x00}, // }
+	{0x08, 0x04, 0x08, 0x10, 0x08}, // ~
+}
+
+// Defaults for pray render led
+const (
+	defaultLEDModules = 4
+	defaultLEDSpeed   = 50 * time.Millisecond
+)
+
+type ledOptions struct {
+	Modules    int
+	Format     string
+	SPI        string
+	Speed      time.Duration
+	Brightness int
+}
+
+// ledMatrix is a chain of LED matrix modules that shows one frame at a time
+type ledMatrix interface {
+	// show lights a frame: one byte per column from the left, bit 0 the top row.
+	show(frame []byte) error
+	Close() error
+}
+
+// ledColumns lays out text in the 5x7 font with a blank column after each character.
+// Characters the font doesn't have are shown as ?.
+func ledColumns(text string) []byte {
+	var columns []byte
+	for _, r := range text {
+		if r < ' ' || r > '~' {
+			r = '?'
+		}
+		columns = append(columns, ledFont[r-' '][:]...)
+		columns = append(columns, 0)
+	}
+	return columns
+}
+
+// ledFrames is text on a display width columns wide: centered when it fits, otherwise
+// scrolling in from the right one column per frame until it has left on the left.
+func ledFrames(columns []byte, width int) [][]byte {
+	// The trailing gap doesn't count towards fitting
+	if len(columns) > 0 && len(columns)-1 <= width {
+		frame := make([]byte, width)
+		copy(frame[(width-len(columns)+1)/2:], columns)
+		return [][]byte{frame}
+	}
+
+	strip := make([]byte, width, 2*width+len(columns))
+	strip = append(strip, columns...)
+	strip = append(strip, make([]byte, width)...)
+	// From the first column showing on the right to the last lit one leaving on the left
+	frames := make([][]byte, 0, width+len(columns))
+	for i := 1; i < width+len(columns)-1; i++ {
+		frames = append(frames, strip[i:i+width])
+	}
+	return frames
+}
+
+// ledText is what the matrix shows: the glance line, like "Asr 15:32 -42m".
+func ledText(ctx context.Context, city, country string, method int, cfg Config) (string, error) {
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return "", err
+	}
+	return glanceText(data.Data.Timings, clock.Now())
+}
+
+func renderLED(ctx context.Context, city, country string, method int, cfg Config, opts ledOptions) error {
+	if opts.Modules < 1 {
+		return fmt.Errorf("invalid number of modules %d", opts.Modules)
+	}
+	if opts.Format != "text" && opts.Format != "hex" {
+		return fmt.Errorf("unknown format %q (expected text or hex)", opts.Format)
+	}
+	if opts.Brightness < 0 || opts.Brightness > 15 {
+		return fmt.Errorf("invalid brightness %d (expected 0 to 15)", opts.Brightness)
+	}
+
+	if opts.SPI != "" {
+		return runLEDMatrix(ctx, city, country, method, cfg, opts)
+	}
+
+	text, err := ledText(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	for i, frame := range ledFrames(ledColumns(text), ledRows*opts.Modules) {
+		if opts.Format == "hex" {
+			fmt.Printf("%x\n", frame)
+			continue
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Print(ledPreview(frame))
+	}
+	return nil
+}
+
+// ledPreview draws a frame with # for a lit LED and . for a dark one.
+func ledPreview(frame []byte) string {
+	var b strings.Builder
+	for row := 0; row < ledRows; row++ {
+		for _, column := range frame {
+			if column&(1<<row) != 0 {
+				b.WriteByte('#')
+			} else {
+				b.WriteByte('.')
+			}
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// runLEDMatrix scrolls the next prayer across a MAX7219 chain until ctx is done, updating
+// the countdown after every pass. Timings come from the response cache, so that costs no
+// request. If an update fails, the last text keeps scrolling.
+func runLEDMatrix(ctx context.Context, city, country string, method int, cfg Config, opts ledOptions) error {
+	text, err := ledText(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	matrix, err := openMAX7219(opts.SPI, opts.Modules, opts.Brightness)
+	if err != nil {
+		return err
+	}
+	defer matrix.Close()
+
+	for {
+		frames := ledFrames(ledColumns(text), ledRows*opts.Modules)
+		// A line that fits doesn't move; it's only redrawn for the countdown
+		delay := opts.Speed
+		if len(frames) == 1 {
+			delay = time.Second
+		}
+		for _, frame := range frames {
+			if err := matrix.show(frame); err != nil {
+				return err
+			}
+			if err := sleepContext(ctx, delay); err != nil {
+				return nil
+			}
+		}
+		if updated, err := ledText(ctx, city, country, method, cfg); err == nil {
+			text = updated
+		}
+	}
+}
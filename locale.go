@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Translated strings keyed by language, then message key
+var translations = map[string]map[string]string{
+	"en": {
+		"adhan_title":     "🕌 Time for %s",
+		"adhan_body":      "It is time for %s in %s.",
+		"iqama_window":    "Iqama in %d minutes.",
+		"iqama_title":     "🕌 Iqama for %s",
+		"iqama_body":      "The iqama for %s is now.",
+		"adhan_speech":    "It is time for %s.",
+		"iqama_speech":    "The iqama for %s is now.",
+		"dua_after_adhan": "O Allah, Lord of this perfect call and the prayer to be established, grant Muhammad the intercession and the favour, and raise him to the praised station You have promised him.",
+		"dua_reminder":    "Supplication between the adhan and the iqama is not rejected.",
+		"athkar_title":    "📿 Athkar after %s",
+		"athkar_body":     "Take a moment for the adhkar after prayer.",
+		"daily_title":     "📖 Ayah of the day",
+		"moved_title":     "📍 Location changed to %s",
+		"moved_body":      "Prayer times updated for %s.",
+		"window_title":    "⏳ %s time ends soon",
+		"window_body":     "%d minutes left to pray %s.",
+		"alarm_title":     "⏰ Wake up for %s",
+		"alarm_body":      "%s is in %d minutes.",
+		"change_title":    "🕰️ Prayer times changed",
+		"change_earlier":  "%s is %d minutes earlier, at %s.",
+		"change_later":    "%s is %d minutes later, at %s.",
+		"umm-al-qura":     "Umm al-Qura",
+		"tabular":         "tabular",
+		"hijri_differs":   "* the %s calendar has a different day",
+	},
+	"ar": {
+		"adhan_title":     "🕌 حان وقت %s",
+		"adhan_body":      "حان الآن وقت صلاة %s في %s.",
+		"iqama_window":    "الإقامة بعد %d دقيقة.",
+		"iqama_title":     "🕌 إقامة صلاة %s",
+		"iqama_body":      "حان وقت إقامة صلاة %s.",
+		"adhan_speech":    "حان الآن وقت صلاة %s",
+		"iqama_speech":    "حان وقت إقامة صلاة %s",
+		"dua_after_adhan": "اللَّهُمَّ رَبَّ هَذِهِ الدَّعْوَةِ التَّامَّةِ، وَالصَّلَاةِ الْقَائِمَةِ، آتِ مُحَمَّدًا الْوَسِيلَةَ وَالْفَضِيلَةَ، وَابْعَثْهُ مَقَامًا مَحْمُودًا الَّذِي وَعَدْتَهُ",
+		"dua_reminder":    "الدُّعَاءُ لَا يُرَدُّ بَيْنَ الْأَذَانِ وَالْإِقَامَةِ",
+		"athkar_title":    "📿 أذكار بعد صلاة %s",
+		"athkar_body":     "لا تنس أذكار ما بعد الصلاة.",
+		"daily_title":     "📖 آية اليوم",
+		"moved_title":     "📍 تغير الموقع إلى %s",
+		"moved_body":      "تم تحديث مواقيت الصلاة لـ %s.",
+		"window_title":    "⏳ قرب خروج وقت %s",
+		"window_body":     "بقي %d دقيقة على خروج وقت صلاة %s.",
+		"alarm_title":     "⏰ استيقظ لصلاة %s",
+		"alarm_body":      "صلاة %s بعد %d دقيقة.",
+		"change_title":    "🕰️ تغيرت مواقيت الصلاة",
+		"change_earlier":  "موعد %s أبكر بـ %d دقيقة، الساعة %s.",
+		"change_later":    "موعد %s متأخر %d دقيقة، الساعة %s.",
+		"umm-al-qura":     "تقويم أم القرى",
+		"tabular":         "التقويم الحسابي",
+		"hijri_differs":   "* يختلف اليوم في %s",
+	},
+}
+
+// Prayer names per language
+var localizedPrayerNames = map[string]map[string]string{
+	"ar": {
+		"Fajr":    "الفجر",
+		"Sunrise": "الشروق",
+		"Dhuhr":   "الظهر",
+		"Asr":     "العصر",
+		"Maghrib": "المغرب",
+		"Isha":    "العشاء",
+	},
+}
+
+// tr looks up a message in the given language, falling back to English
+func tr(lang, key string, args ...interface{}) string {
+	msg, ok := translations[lang][key]
+	if !ok {
+		msg = translations["en"][key]
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+func localPrayerName(lang, prayer string) string {
+	if name, ok := localizedPrayerNames[lang][prayer]; ok {
+		return name
+	}
+	return prayer
+}
+
+// Numeral styles for the numerals setting
+const (
+	numeralsLatin  = "latin"
+	numeralsArabic = "arabic"
+)
+
+// Eastern Arabic digits, replacing 0-9
+var arabicDigits = strings.NewReplacer(
+	"0", "٠", "1", "١", "2", "٢", "3", "٣", "4", "٤",
+	"5", "٥", "6", "٦", "7", "٧", "8", "٨", "9", "٩",
+)
+
+// Gregorian month names in Arabic, as used across the Gulf and Egypt
+var arabicMonths = []string{
+	"يناير", "فبراير", "مارس", "أبريل", "مايو", "يونيو",
+	"يوليو", "أغسطس", "سبتمبر", "أكتوبر", "نوفمبر", "ديسمبر",
+}
+
+// Gregorian weekday names in Arabic, from Sunday as time.Weekday counts
+var arabicWeekdays = []string{
+	"الأحد", "الاثنين", "الثلاثاء", "الأربعاء", "الخميس", "الجمعة", "السبت",
+}
+
+// arabicDates reports whether dates are written with Arabic day and month names.
+func (c Config) arabicDates() bool {
+	return c.Language == "ar" || c.Numerals == numeralsArabic
+}
+
+// dateDigits is digits for dates: Arabic dates use Eastern Arabic numerals unless numerals
+// is set to latin.
+func (c Config) dateDigits(s string) string {
+	if c.Numerals == numeralsArabic || (c.Language == "ar" && c.Numerals == "") {
+		return arabicDigits.Replace(s)
+	}
+	return s
+}
+
+// gregorianDate writes an API date in full in the configured language, like "Friday,
+// 14 March 2025" or "الجمعة، ١٤ مارس ٢٠٢٥", rather than the API's English readable
+// string.
+func (c Config) gregorianDate(g Gregorian) string {
+	t, err := time.Parse("02-01-2006", g.Date)
+	if err != nil {
+		return strings.TrimSpace(fmt.Sprintf("%s %s %s", strings.TrimLeft(g.Day, "0"), g.Month.En, g.Year))
+	}
+	if !c.arabicDates() {
+		return t.Format("Monday, 2 January 2006")
+	}
+	return c.dateDigits(fmt.Sprintf("%s، %d %s %d", arabicWeekdays[t.Weekday()], t.Day(), arabicMonths[t.Month()-1], t.Year()))
+}
+
+// monthYear is a Gregorian month heading, like "March 2025" or "مارس ٢٠٢٥".
+func (c Config) monthYear(g Gregorian) string {
+	if !c.arabicDates() || g.Month.Number < 1 || g.Month.Number > 12 {
+		return fmt.Sprintf("%s %s", g.Month.En, g.Year)
+	}
+	return c.dateDigits(fmt.Sprintf("%s %s", arabicMonths[g.Month.Number-1], g.Year))
+}
+
+// shortWeekday is a day's name for narrow columns: "Fri", or "الجمعة" in full, since
+// Arabic day names don't abbreviate.
+func (c Config) shortWeekday(g Gregorian) string {
+	t, err := time.Parse("02-01-2006", g.Date)
+	if err != nil {
+		return fmt.Sprintf("%.3s", g.Weekday.En)
+	}
+	if c.arabicDates() {
+		return arabicWeekdays[t.Weekday()]
+	}
+	return t.Format("Mon")
+}
+
+// digits writes the numbers in s with the configured numerals.
+func (c Config) digits(s string) string {
+	if c.Numerals == numeralsArabic {
+		return arabicDigits.Replace(s)
+	}
+	return s
+}
+
+// rtl reports whether the timings table is laid out right to left.
+func (c Config) rtl() bool {
+	return c.Language == "ar" && c.Numerals == numeralsArabic
+}
+
+// dateLine is the Gregorian and Hijri date under the timings header, in Arabic with
+// language: ar or Arabic numerals. When the Hijri calendars disagree, the other one's day
+// follows, marked with its name.
+func (c Config) dateLine(date Date) string {
+	hijri, other := c.hijriDates(date)
+	line := c.gregorianDate(date.Gregorian) + " | " + c.hijriText(hijri, true)
+	if other != nil {
+		line += fmt.Sprintf(" ≠ %s (%s)", c.hijriText(*other, false), tr(c.Language, c.otherHijriCalendar()))
+	}
+	return line
+}
+
+// hijriText is a Hijri date, in Arabic with language: ar or Arabic numerals, and with or
+// without its year.
+func (c Config) hijriText(h Hijri, year bool) string {
+	if !c.arabicDates() {
+		if !year {
+			return fmt.Sprintf("%s %s", h.Day, h.Month.En)
+		}
+		return fmt.Sprintf("%s %s, %s AH", h.Day, h.Month.En, h.Year)
+	}
+
+	month := h.Month.Ar
+	if month == "" {
+		month = h.Month.En
+	}
+	if !year {
+		return c.dateDigits(fmt.Sprintf("%s %s", strings.TrimLeft(h.Day, "0"), month))
+	}
+	return c.dateDigits(fmt.Sprintf("%s %s %s هـ", strings.TrimLeft(h.Day, "0"), month, h.Year))
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
@@ -67,11 +72,25 @@ type Timings struct {
 	Sunset  string `json:"Sunset"`
 	Maghrib string `json:"Maghrib"`
 	Isha    string `json:"Isha"`
+
+	// Extended timings, shown in the wide layout
+	Imsak     string `json:"Imsak"`
+	Midnight  string `json:"Midnight"`
+	Lastthird string `json:"Lastthird"`
 }
 
 type Date struct {
-	Readable string `json:"readable"`
-	Hijri    Hijri  `json:"hijri"`
+	Readable  string    `json:"readable"`
+	Gregorian Gregorian `json:"gregorian"`
+	Hijri     Hijri     `json:"hijri"`
+}
+
+type Gregorian struct {
+	Date    string  `json:"date"`
+	Day     string  `json:"day"`
+	Weekday Weekday `json:"weekday"`
+	Month   Month   `json:"month"`
+	Year    string  `json:"year"`
 }
 
 type Hijri struct {
@@ -116,9 +135,9 @@ type Location struct {
 // Prayer names with emojis
 var prayerNames = map[string]string{
 	"Fajr":    "🌅 Fajr",
-	"Sunrise": "☀️  Sunrise",
+	"Sunrise": "☀️ Sunrise",
 	"Dhuhr":   "🌞 Dhuhr", 
-	"Asr":     "🌤️  Asr",
+	"Asr":     "🌤️ Asr",
 	"Maghrib": "🌅 Maghrib",
 	"Isha":    "🌙 Isha",
 }
@@ -131,73 +150,784 @@ func main() {
 	var country string
 	var method int
 
+	cfg, err := loadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if prayerNames, err = cfg.prayerLabels(); err != nil {
+		log.Fatal(err)
+	}
+	storeEncryption.Enabled, storeEncryption.KeyFile = cfg.EncryptStore, cfg.StoreKeyFile
+	if api.cache, err = newResponseCache(cfg.Cache); err != nil {
+		log.Fatal(err)
+	}
+
+	var kids, authorityName string
+	var timingsOpts timingsOptions
 	var rootCmd = &cobra.Command{
 		Use:   "pray",
 		Short: "🕌 Prayer times in your terminal",
 		Long:  "A beautiful CLI tool to display Islamic prayer times with accurate calculations based on your location.",
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			if err := useCityFlag(cmd, &city, &country, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if cmd.Flags().Changed("authority") {
+				a, err := lookupAuthority(authorityName)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				cfg.useAuthority(a)
+				cfg.Authority = authorityName
+				if !cmd.Flags().Changed("method") {
+					method = a.Method
+				}
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			showPrayerTimes(city, country, method)
+			if cmd.Flags().Changed("kids") {
+				data, err := fetchPrayerTimes(cmd.Context(), city, country, method, cfg)
+				if err == nil {
+					err = showKidsTimes(city, kids, data)
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+			if err := timingsOpts.check(); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			showPrayerTimes(cmd.Context(), city, country, method, cfg, timingsOpts)
+			if cfg.Daily {
+				fmt.Println()
+				if err := showDaily(clock.Now()); err != nil {
+					fmt.Printf("Error: %v\n", err)
+				}
+			}
 		},
 	}
 
+	var nextOpts nextOptions
 	var nextCmd = &cobra.Command{
 		Use:   "next",
 		Short: "Show the next prayer time with countdown",
 		Run: func(cmd *cobra.Command, args []string) {
-			showNextPrayer(city, country, method)
+			if nextOpts.Format != "" && nextOpts.Format != "shortcuts" {
+				fmt.Printf("Error: unknown format %q (expected shortcuts)\n", nextOpts.Format)
+				os.Exit(1)
+			}
+			if nextOpts.Precision != "m" && nextOpts.Precision != "s" {
+				fmt.Printf("Error: unknown precision %q (expected m or s)\n", nextOpts.Precision)
+				os.Exit(1)
+			}
+			showNextPrayer(cmd.Context(), city, country, method, cfg, nextOpts)
+		},
+	}
+	nextCmd.Flags().StringVar(&nextOpts.Format, "format", "", "Output format: shortcuts for a flat JSON dictionary (iOS Shortcuts over SSH)")
+	nextCmd.Flags().StringVar(&nextOpts.Precision, "precision", "m", "Countdown precision: m for minutes, s for seconds")
+	nextCmd.Flags().BoolVar(&nextOpts.Live, "live", false, "Keep the countdown running until the prayer time")
+	nextCmd.Flags().BoolVar(&nextOpts.Context, "context", false, "Also show the previous prayer and how long is left to pray it")
+
+	var glanceCmd = &cobra.Command{
+		Use:   "glance",
+		Short: "Show the next prayer in at most 20 characters, e.g. Asr 15:32 -42m",
+		Long:  "Show the next prayer, its time, and the countdown in at most 20 characters, for smartwatch complications, tiny OLED displays, and status bars.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showGlance(cmd.Context(), city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	var widgetTarget string
+	var widgetCmd = &cobra.Command{
+		Use:   "widget",
+		Short: "Print today's timings for a desktop widget",
+		Long:  "Print today's timings in the markup of a desktop widget or overlay, with the next prayer highlighted. --target conky is for ${execpi 60 pray widget --target conky} in conky.text; argos and kargos print the BitBar protocol for GNOME Argos and KDE Kargos panel scripts.",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := validWidgetTarget(widgetTarget)
+			if err == nil {
+				err = showWidget(cmd.Context(), city, country, method, cfg, widgetTarget)
+			}
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	widgetCmd.Flags().StringVar(&widgetTarget, "target", widgetConky, "Widget to print for: conky, argos, or kargos")
+
+	var rpcStdio bool
+	var rpcCmd = &cobra.Command{
+		Use:   "rpc",
+		Short: "Answer JSON-RPC requests for editor plugins",
+		Long:  "Speak JSON-RPC 2.0 over standard input and output, one message per line, so editor plugins such as a Neovim statusline or a VS Code extension can keep one pray process running. Methods: get_next, get_today, and subscribe, which sends a \"next\" notification every minute. Each takes optional city, country, and method params.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if !rpcStdio {
+				fmt.Println("Error: no transport given (use --stdio)")
+				os.Exit(1)
+			}
+			if err := runRPC(cmd.Context(), city, country, method, cfg, os.Stdin, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	rpcCmd.Flags().BoolVar(&rpcStdio, "stdio", false, "Use standard input and output")
+
+	var deckUser string
+	var streamDeckCmd = &cobra.Command{
+		Use:   "streamdeck",
+		Short: "Drive Stream Deck or macro pad keys",
+		Long:  "Write the title and state of six keys as a line of JSON at start, every minute, and after each button press: the five prayers, as upcoming, due, snoozed, unlogged, prayed, late, or missed, and the countdown to the next. Read button presses from standard input, one JSON action per line: {\"action\": \"log\", \"key\": 2} or {\"action\": \"snooze\", \"key\": 2, \"minutes\": 10}.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runStreamDeck(cmd.Context(), city, country, method, deckUser, cfg, os.Stdin, os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	streamDeckCmd.Flags().StringVar(&deckUser, "user", "", "Household member to log for (default: you)")
+
+	var promptFile string
+	var promptCmd = &cobra.Command{
+		Use:   "prompt",
+		Short: "Print the next prayer for a shell prompt",
+		Long:  "Print the next prayer like pray glance, but print nothing rather than an error, so a prompt stays clean offline. With --async-file, read the line the daemon keeps in prompt_file instead, which never waits on the network or the cache.",
+		Run: func(cmd *cobra.Command, args []string) {
+			showPrompt(cmd.Context(), city, country, method, cfg, promptFile)
+		},
+	}
+	promptCmd.Flags().StringVar(&promptFile, "async-file", "", "Read the daemon's prompt_file, e.g. ~/.cache/pray/prompt")
+
+	var renderCmd = &cobra.Command{
+		Use:   "render",
+		Short: "Render the schedule as an image for small displays",
+	}
+
+	var einkSize, einkOut string
+	var einkCmd = &cobra.Command{
+		Use:   "eink",
+		Short: "Render today's schedule as a 1-bit PNG for an e-paper display",
+		Long:  "Render today's timings as a black and white PNG sized for an e-paper module, with the next prayer highlighted. Run it from cron and push the frame to the display with its driver.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := renderEink(cmd.Context(), city, country, method, cfg, einkSize, einkOut); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	einkCmd.Flags().StringVar(&einkSize, "size", "296x128", "Display size in pixels, e.g. 250x122, 296x128, 400x300, or 800x480")
+	einkCmd.Flags().StringVarP(&einkOut, "out", "o", "frame.png", "Where to write the PNG (- for standard output)")
+
+	var ledOpts ledOptions
+	var ledCmd = &cobra.Command{
+		Use:   "led",
+		Short: "Scroll the next prayer across an LED matrix",
+		Long:  "Render the next prayer and its countdown as scrolling frames for a chain of 8x8 LED matrix modules: a preview with --format text, or one line of column bytes per frame with --format hex for a microcontroller. With --spi, drive a MAX7219 chain directly (Linux builds with -tags max7219).",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := renderLED(cmd.Context(), city, country, method, cfg, ledOpts); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	ledCmd.Flags().IntVar(&ledOpts.Modules, "modules", defaultLEDModules, "Number of chained 8x8 modules")
+	ledCmd.Flags().StringVar(&ledOpts.Format, "format", "text", "Frame output: text or hex")
+	ledCmd.Flags().StringVar(&ledOpts.SPI, "spi", "", "Drive a MAX7219 chain on this SPI device, e.g. /dev/spidev0.0")
+	ledCmd.Flags().DurationVar(&ledOpts.Speed, "speed", defaultLEDSpeed, "Time per scroll step with --spi")
+	ledCmd.Flags().IntVar(&ledOpts.Brightness, "brightness", 4, "Brightness with --spi, 0 to 15")
+	renderCmd.AddCommand(einkCmd, ledCmd)
+
+	var titleOnce bool
+	var titleCmd = &cobra.Command{
+		Use:   "title",
+		Short: "Keep the next prayer's countdown in the terminal window title",
+		Long:  "Keep the next prayer and its countdown, like Asr -42m, in the terminal's window title, and in tmux the window name (with allow-rename on), updated every minute until interrupted. Run it in the background, as pray title &, to keep the countdown in view while another program has the pane. With --once, set the title and exit.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runTitle(cmd.Context(), titleOnce, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	titleCmd.Flags().BoolVar(&titleOnce, "once", false, "Set the title once and exit")
+
+	var focusWork, focusRest time.Duration
+	var focusCmd = &cobra.Command{
+		Use:   "focus",
+		Short: "Run work and break cycles that end with a break at the next prayer",
+		Long:  "Run pomodoro-style work and break cycles up to the next prayer, fitted so the last break ends exactly at the prayer time, with a live timer and the terminal bell between stretches. Time left over from whole cycles makes a shorter first one.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runFocus(cmd.Context(), focusWork, focusRest, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	focusCmd.Flags().DurationVar(&focusWork, "work", 25*time.Minute, "Length of a work stretch")
+	focusCmd.Flags().DurationVar(&focusRest, "break", 5*time.Minute, "Length of a break")
+
+	var tasksCmd = &cobra.Command{
+		Use:   "tasks",
+		Short: "Keep a task for each prayer in Taskwarrior or Todoist",
+	}
+
+	var tasksDays int
+	var tasksSyncCmd = &cobra.Command{
+		Use:   "sync",
+		Short: "Add a task due at each prayer time, and complete the ones logged",
+		Long:  "Add a \"Pray Fajr\" style task for each prayer of the next --days to the to-do app in tasks.backend, due at its time, and complete the tasks of prayers pray log has recorded. pray log completes a prayer's task itself once synced; run this daily, from cron or a timer, to keep the tasks coming.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := syncTasks(cmd.Context(), tasksDays, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	tasksSyncCmd.Flags().IntVar(&tasksDays, "days", 1, "Days of prayers to add tasks for, from today")
+	tasksCmd.AddCommand(tasksSyncCmd)
+
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export today's timings for other tools",
+	}
+
+	var noteTemplate, noteOut string
+	var noteForce bool
+	var dailyNoteCmd = &cobra.Command{
+		Use:   "daily-note",
+		Short: "Fill a Markdown daily note with today's timings and prayer checkboxes",
+		Long:  "Fill a Markdown template with today's timings, Gregorian and Hijri dates, and a checkbox for each prayer, ticked when pray log has it, for planning the day in Obsidian or Logseq. The template uses Go's text/template syntax; without --template a simple built-in one is used.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := exportDailyNote(cmd.Context(), noteTemplate, noteOut, noteForce, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	dailyNoteCmd.Flags().StringVar(&noteTemplate, "template", "", "Markdown template to fill (default: the built-in one)")
+	dailyNoteCmd.Flags().StringVarP(&noteOut, "out", "o", "-", "Where to write the note (- for standard output)")
+	dailyNoteCmd.Flags().BoolVar(&noteForce, "force", false, "Replace the note if it already exists")
+	exportCmd.AddCommand(dailyNoteCmd)
+
+	var daemonSimulate string
+	var daemonCmd = &cobra.Command{
+		Use:   "daemon",
+		Short: "Run in the background and notify at prayer times",
+		Run: func(cmd *cobra.Command, args []string) {
+			if daemonSimulate != "" {
+				start, err := parseSimulateTime(daemonSimulate, clock.Now())
+				if err == nil {
+					err = simulateDaemon(cmd.Context(), city, country, method, cfg, start)
+				}
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+			runDaemon(cmd.Context(), city, country, method, cfg)
+		},
+	}
+	daemonCmd.Flags().StringVar(&daemonSimulate, "simulate", "", "Print what would fire over a day from this time, like 2025-03-01T17:55, without sending anything")
+	daemonCmd.AddCommand(newDaemonStatusCmd())
+
+	var kioskInterval time.Duration
+	var kioskCmd = &cobra.Command{
+		Use:   "kiosk",
+		Short: "Fullscreen display for mosque screens",
+		Long:  "Fullscreen, auto-refreshing display that cycles between today's timings, a large countdown to the next prayer, and the Hijri date. Press Ctrl-C to exit.",
+		Run: func(cmd *cobra.Command, args []string) {
+			runKiosk(cmd.Context(), city, country, method, cfg, kioskInterval)
+		},
+	}
+	kioskCmd.Flags().DurationVar(&kioskInterval, "interval", 10*time.Second, "How long each screen is shown")
+
+	var diffCmd = &cobra.Command{
+		Use:   "diff",
+		Short: "Show how timings would change with another method or location",
+		Long:  "Compare today's and this week's timings under the configured location and method with those given by --method, --city, and --country, e.g. pray diff --method 3, before changing the config.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showDiff(cmd.Context(), city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	var serveOpts serveOptions
+	var serveCmd = &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a web dashboard and JSON API",
+		Long:  "Serve a web dashboard with a live countdown and monthly calendar at /, and JSON at /api/timings and /api/calendar. Query parameters city, country, and method override the flags.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runServer(cmd.Context(), serveOpts, city, country, method, cfg); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	serveCmd.Flags().StringVar(&serveOpts.Addr, "addr", ":8080", "Address to listen on")
+	serveCmd.Flags().StringVar(&serveOpts.HealthPath, "healthcheck-endpoint", "/healthz", "Path of the health probe endpoint (empty to disable)")
+	serveCmd.Flags().StringVar(&serveOpts.SlackSecret, "slack-signing-secret", "", "Enable the Slack slash command at /slack/command (default $PRAY_SLACK_SIGNING_SECRET)")
+	serveCmd.Flags().StringVar(&serveOpts.GRPCAddr, "grpc-addr", "", "Also serve the gRPC API on this address, e.g. :9090")
+
+	var sshOpts sshKioskOptions
+	var serveSSHCmd = &cobra.Command{
+		Use:   "serve-ssh",
+		Short: "Serve the kiosk display over SSH",
+		Long:  "Run an SSH server that shows the kiosk display to anyone who connects, so other machines on the network can ssh prayer@host without installing pray. Press q to disconnect.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runSSHKiosk(cmd.Context(), sshOpts, city, country, method, cfg); err != nil {
+				log.Fatal(err)
+			}
+		},
+	}
+	serveSSHCmd.Flags().StringVar(&sshOpts.Addr, "addr", ":2222", "Address to listen on")
+	serveSSHCmd.Flags().StringVar(&sshOpts.HostKey, "host-key", "", "Host key, generated if it doesn't exist (default ssh_host_ed25519_key in the data directory)")
+	serveSSHCmd.Flags().StringVar(&sshOpts.AuthorizedKeys, "authorized-keys", "", "Only let in the keys in this authorized_keys file")
+	serveSSHCmd.Flags().DurationVar(&sshOpts.Interval, "interval", 10*time.Second, "How long each screen is shown")
+
+	var notifyCmd = &cobra.Command{
+		Use:   "notify",
+		Short: "Manage daemon notifications",
+	}
+
+	var notifyTestCmd = &cobra.Command{
+		Use:   "test <prayer>",
+		Short: "Send a test adhan notification through the configured backends",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := testNotification(cmd.Context(), args[0], city, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	notifyCmd.AddCommand(notifyTestCmd)
+
+	var alarmBefore time.Duration
+	var alarmDays int
+	var alarmCmd = &cobra.Command{
+		Use:   "alarm <prayer>",
+		Short: "Schedule a wake-up alarm before a prayer",
+		Long:  "Schedule a system alarm (systemd timer, at, or launchd) that rings --before the prayer on each of the next --days days, with the sound getting louder each round. It runs even when the daemon isn't, and setting it again replaces the previous alarm.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			prayer, err := canonicalPrayer(args[0])
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			times, err := alarmTimes(cmd.Context(), prayer, alarmBefore, alarmDays, city, country, method, cfg)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			scheduler, err := setAlarm(cmd.Context(), prayer, alarmBefore, times)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println(titleStyle.Render(fmt.Sprintf("⏰ %s alarm, %s before", prayerNames[prayer], formatDuration(alarmBefore))))
+			fmt.Println(strings.Repeat("━", 50))
+			for _, t := range times {
+				fmt.Printf("  %s %s\n", prayerStyle.Render(t.Format("Mon 02 Jan")), timeStyle.Render(t.Format("15:04")))
+			}
+			fmt.Println()
+			fmt.Println(cityStyle.Render(fmt.Sprintf("Scheduled with %s. Remove it with pray alarm cancel.", scheduler)))
+		},
+	}
+	alarmCmd.Flags().DurationVar(&alarmBefore, "before", 20*time.Minute, "How long before the prayer to ring")
+	alarmCmd.Flags().IntVar(&alarmDays, "days", 7, "Number of days to schedule")
+
+	var alarmRingCmd = &cobra.Command{
+		Use:    "ring <prayer>",
+		Short:  "Ring the alarm now (run by the scheduler)",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			prayer, err := canonicalPrayer(args[0])
+			if err == nil {
+				err = ringAlarm(cmd.Context(), prayer, alarmBefore, cfg)
+			}
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	alarmRingCmd.Flags().DurationVar(&alarmBefore, "before", 20*time.Minute, "How long before the prayer the alarm rings")
+
+	var alarmCancelCmd = &cobra.Command{
+		Use:   "cancel",
+		Short: "Remove the scheduled alarm",
+		Run: func(cmd *cobra.Command, args []string) {
+			cancelAlarm(cmd.Context())
+			fmt.Println(cityStyle.Render("Alarm cancelled."))
+		},
+	}
+	alarmCmd.AddCommand(alarmRingCmd, alarmCancelCmd)
+
+	var yearFormat string
+	var yearCmd = &cobra.Command{
+		Use:   "year [year]",
+		Short: "Show a whole year of prayer times",
+		Long:  "Show a year of timings (this year by default) one month at a time; use the arrow keys or n/p to page and q to quit. When piped, or with --format, the whole year is printed as text, CSV, or JSON.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			year := clock.Now().Year()
+			if len(args) == 1 {
+				n, err := strconv.Atoi(args[0])
+				if err != nil || n < 1 {
+					fmt.Printf("Error: invalid year %q\n", args[0])
+					os.Exit(1)
+				}
+				year = n
+			}
+			if err := showYear(cmd.Context(), year, yearFormat, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	yearCmd.Flags().StringVar(&yearFormat, "format", "", "Output format: text, csv, or json (default: pager in a terminal, text when piped)")
+
+	var compareMethods []int
+	var compareCmd = &cobra.Command{
+		Use:   "compare",
+		Short: "Compare today's timings under several calculation methods",
+		Long:  "Show today's timings under each of --methods side by side, with how far apart they are, to help match your local mosque and pick a method.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showCompare(cmd.Context(), compareMethods, city, country, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	compareCmd.Flags().IntSliceVar(&compareMethods, "methods", []int{2, 3, 4, 5}, "Comma-separated calculation methods to compare")
+
+	var batchConcurrency int
+	var batchCmd = &cobra.Command{
+		Use:   "batch",
+		Short: "Fetch today's timings for many locations from stdin",
+		Long:  "Read one location per line from stdin as city[,country][,method], with --country and --method as the defaults, and print a JSON object per line with the timings or the error. Locations are fetched concurrently and printed in input order. Exits 1 if any line failed.",
+		Run: func(cmd *cobra.Command, args []string) {
+			ok, err := runBatch(cmd.Context(), os.Stdin, os.Stdout, country, method, batchConcurrency, cfg)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+	batchCmd.Flags().IntVar(&batchConcurrency, "concurrency", 4, "How many locations to fetch at once")
+
+	var teamRoster string
+	var teamCmd = &cobra.Command{
+		Use:   "team",
+		Short: "Show the next prayer at each office of a team",
+		Long:  "Show the next prayer at every office in the team roster (locations.yaml next to the config file, or --roster), in the office's local time and yours, to schedule meetings around prayers. Offices without a country or method use --country and --method.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showTeam(cmd.Context(), teamRoster, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	teamCmd.Flags().StringVar(&teamRoster, "roster", "", "Roster file (default: locations.yaml in the config directory)")
+
+	var annotateStdin bool
+	var annotateCmd = &cobra.Command{
+		Use:   "annotate [text]",
+		Short: "Tag text with the current prayer period and Hijri date",
+		Long:  "Write text with the current prayer period and Hijri date before it, like [Asr · 5 Jumādá al-ūlá, 1448 AH], for journaling tools such as jrnl. The text comes from the arguments, or standard input with --stdin.",
+		Run: func(cmd *cobra.Command, args []string) {
+			text := io.Reader(strings.NewReader(strings.Join(args, " ")))
+			if annotateStdin {
+				if len(args) > 0 {
+					fmt.Println("Error: give the text as arguments or on standard input, not both")
+					os.Exit(1)
+				}
+				text = os.Stdin
+			}
+			if err := runAnnotate(cmd.Context(), text, city, country, method, cfg, os.Stdout); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	annotateCmd.Flags().BoolVar(&annotateStdin, "stdin", false, "Read the text from standard input")
+
+	var worldCmd = &cobra.Command{
+		Use:   "world",
+		Short: "Show the next prayer for each family member, world clock style",
+		Long:  "Show each family member's local time and the countdown to their next prayer, whoever prays next first. List them in the config under family:, each with a name and city; country and method default to --country and --method.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showWorld(cmd.Context(), country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	var freeOpts freeOptions
+	var freeCmd = &cobra.Command{
+		Use:   "free",
+		Short: "Suggest meeting slots that keep clear of the prayers",
+		Long:  "Find today's slots in --between that fit a meeting of --duration without running into a prayer. Each prayer is kept clear from --buffer before the adhan until --buffer after it's over (the iqama, if configured, plus ten minutes). Use --format json for calendar bots.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if !cmd.Flags().Changed("buffer") {
+				freeOpts.Buffer = cfg.meetingBuffer()
+			}
+			if err := showFree(cmd.Context(), freeOpts, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	freeCmd.Flags().DurationVar(&freeOpts.Duration, "duration", 30*time.Minute, "Length of the meeting")
+	freeCmd.Flags().StringVar(&freeOpts.Between, "between", "09:00-17:00", "Time range to look in, HH:MM-HH:MM")
+	freeCmd.Flags().DurationVar(&freeOpts.Buffer, "buffer", defaultMeetingBuffer, "Time kept clear either side of a prayer (default: meeting_buffer from the config, or 10m)")
+	freeCmd.Flags().StringVar(&freeOpts.Format, "format", "", "Output format: json")
+
+	var sunCmd = &cobra.Command{
+		Use:   "sun",
+		Short: "Show sunrise, solar noon, sunset, and golden hours",
+		Long:  "Show today's sunrise, solar noon (zawal), sunset, morning and evening golden hours, and day length, calculated offline. Coordinates come from the built-in list of major cities, or from the prayer times API for other places.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showSun(cmd.Context(), city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	var flightFrom, flightTo, flightDepart string
+	var flightDuration time.Duration
+	var flightCmd = &cobra.Command{
+		Use:   "flight",
+		Short: "Estimate prayer times during a flight",
+		Long:  "Estimate the prayer times that fall during a flight, following the great-circle route from --from to --to and working out the sun over the plane offline. Places are airport codes like DXB or built-in cities; --depart is local time at the departure.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showFlight(flightFrom, flightTo, flightDepart, flightDuration, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	flightCmd.Flags().StringVar(&flightFrom, "from", "", "Departure airport code or city")
+	flightCmd.Flags().StringVar(&flightTo, "to", "", "Arrival airport code or city")
+	flightCmd.Flags().StringVar(&flightDepart, "depart", "", "Departure time, 15:04 today or 2006-01-02T15:04, local to --from")
+	flightCmd.Flags().DurationVar(&flightDuration, "duration", 0, "Flight time, like 13h or 7h30m")
+
+	var calibrateFile string
+	var calibrateMethods []int
+	var calibrateCmd = &cobra.Command{
+		Use:   "calibrate",
+		Short: "Find the method and offsets that match your mosque",
+		Long:  "Enter your mosque's announced times for a few days (or pass them with --file, one day per line: YYYY-MM-DD Fajr Dhuhr Asr Maghrib Isha) and get the calculation method whose API timings fit them best, with per-prayer tune offsets: each prayer's average difference from the method, rounded to a minute. The methods' own parameters, like their twilight angles, aren't fitted.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runCalibrate(cmd.Context(), calibrateFile, calibrateMethods, city, country, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	calibrateCmd.Flags().StringVar(&calibrateFile, "file", "", "Read announced times from a file instead of stdin")
+	calibrateCmd.Flags().IntSliceVar(&calibrateMethods, "methods", calibrationMethods, "Calculation methods to try")
+
+	var verifyAgainst string
+	var verifyTolerance time.Duration
+	var verifyCmd = &cobra.Command{
+		Use:   "verify",
+		Short: "Check timings against a recorded official timetable",
+		Long:  "Compare the timings the API gives for --city and --method, including tune offsets, with a recorded timetable in CSV (a date column and a column per prayer, as written by pray year --format csv). Reports the average and maximum deviation per prayer and exits with an error when a time is off by more than --tolerance.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runVerify(cmd.Context(), verifyAgainst, verifyTolerance, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	verifyCmd.Flags().StringVar(&verifyAgainst, "against", "", "Timetable CSV to compare with")
+	verifyCmd.Flags().DurationVar(&verifyTolerance, "tolerance", 2*time.Minute, "Largest deviation allowed")
+	verifyCmd.MarkFlagRequired("against")
+
+	var sleepCmd = &cobra.Command{
+		Use:   "sleep",
+		Short: "Suggest bedtimes for waking up at Fajr",
+		Long:  "Suggest bedtimes between tonight's Isha and tomorrow's Fajr that end a whole number of 90-minute sleep cycles at Fajr, or at the start of the last third of the night.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := showSleep(cmd.Context(), city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
 		},
 	}
 
+	var botCmd = &cobra.Command{
+		Use:   "bot",
+		Short: "Run pray as a chat bot",
+	}
+
+	var discordToken, discordChannel, discordPostAt string
+	var discordCmd = &cobra.Command{
+		Use:   "discord",
+		Short: "Run a Discord bot",
+		Long:  "Run a Discord bot that answers /prayer times [city] [country] and, with --channel, posts the day's timings to a channel every day at --post-at.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runDiscordBot(cmd.Context(), discordToken, discordChannel, discordPostAt, city, country, method, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	discordCmd.Flags().StringVar(&discordToken, "token", "", "Bot token (default $PRAY_DISCORD_TOKEN)")
+	discordCmd.Flags().StringVar(&discordChannel, "channel", "", "Channel ID to post daily timings to")
+	discordCmd.Flags().StringVar(&discordPostAt, "post-at", "05:00", "Local time to post daily timings (HH:MM)")
+	botCmd.AddCommand(discordCmd)
+
 	rootCmd.AddCommand(nextCmd)
+	rootCmd.AddCommand(glanceCmd)
+	rootCmd.AddCommand(renderCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(tasksCmd)
+	rootCmd.AddCommand(focusCmd)
+	rootCmd.AddCommand(titleCmd)
+	rootCmd.AddCommand(widgetCmd)
+	rootCmd.AddCommand(rpcCmd)
+	rootCmd.AddCommand(promptCmd)
+	rootCmd.AddCommand(streamDeckCmd)
+	rootCmd.AddCommand(daemonCmd)
+	rootCmd.AddCommand(kioskCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(serveCmd)
+	rootCmd.AddCommand(serveSSHCmd)
+	rootCmd.AddCommand(botCmd)
+	rootCmd.AddCommand(notifyCmd)
+	rootCmd.AddCommand(alarmCmd)
+	rootCmd.AddCommand(sleepCmd)
+	rootCmd.AddCommand(yearCmd)
+	rootCmd.AddCommand(compareCmd)
+	rootCmd.AddCommand(batchCmd)
+	rootCmd.AddCommand(teamCmd)
+	rootCmd.AddCommand(worldCmd)
+	rootCmd.AddCommand(annotateCmd)
+	rootCmd.AddCommand(freeCmd)
+	rootCmd.AddCommand(calibrateCmd)
+	rootCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(newHealthcheckCmd())
+	rootCmd.AddCommand(newInstallCmd())
+	rootCmd.AddCommand(newRecentCmd())
+	rootCmd.AddCommand(newAthkarCmd())
+	rootCmd.AddCommand(newTasbihCmd())
+	rootCmd.AddCommand(newTimerCmd(cfg))
+	rootCmd.AddCommand(newStatsCmd(cfg))
+	rootCmd.AddCommand(newLogCmd(cfg))
+	rootCmd.AddCommand(newReportCmd(cfg))
+	rootCmd.AddCommand(newStoreCmd())
+	rootCmd.AddCommand(newClockCmd(cfg))
+	rootCmd.AddCommand(newDailyCmd())
+	rootCmd.AddCommand(newNamesCmd())
+	rootCmd.AddCommand(newMoonCmd(cfg))
+	rootCmd.AddCommand(sunCmd)
+	rootCmd.AddCommand(flightCmd)
 	
-	rootCmd.PersistentFlags().StringVar(&city, "city", "Riyadh", "City name for prayer times")
-	rootCmd.PersistentFlags().StringVar(&country, "country", "SA", "Country code (default: SA for Saudi Arabia)")
-	rootCmd.PersistentFlags().IntVar(&method, "method", 4, "Calculation method (4 = Umm Al-Qura)")
+	rootCmd.Flags().StringVar(&kids, "kids", "", "Big, simple view of the five prayers for children, with streak stickers from a child's `name` in pray log")
+	rootCmd.Flags().Lookup("kids").NoOptDefVal = userLabel("")
+	rootCmd.Flags().StringVar(&timingsOpts.Date, "date", "", "Show the timings for another day, such as a past one, as YYYY-MM-DD")
+	rootCmd.Flags().StringVar(&timingsOpts.At, "at", "", "Say which prayers a time, as HH:MM, fell between on --date or today")
+	rootCmd.PersistentFlags().StringVar(&city, "city", cfg.City, "City name for prayer times, or - for the previous one (see pray recent)")
+	rootCmd.PersistentFlags().StringVar(&country, "country", cfg.Country, "Country code (default: SA for Saudi Arabia)")
+	rootCmd.PersistentFlags().IntVar(&method, "method", cfg.Method, "Calculation method (4 = Umm Al-Qura)")
+	rootCmd.PersistentFlags().StringVar(&authorityName, "authority", cfg.Authority, "Country preset for the method, school, tune offsets, and Hijri adjustment: "+authorityNames())
+	rootCmd.PersistentFlags().BoolVar(&cfg.A11y, "a11y", cfg.A11y, "Screen reader friendly output: plain sentences without emoji or tables")
+	rootCmd.PersistentFlags().StringVar(&cfg.Language, "lang", cfg.Language, "Language of notifications and dates: en or ar")
+
+	// Ctrl-C cancels in-flight requests and stops long-running commands
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func fetchPrayerTimes(city, country string, method int) (*PrayerTimesResponse, error) {
-	url := fmt.Sprintf("http://api.aladhan.com/v1/timingsByCity?city=%s&country=%s&method=%d", city, country, method)
-	
-	resp, err := http.Get(url)
+func fetchPrayerTimes(ctx context.Context, city, country string, method int, cfg Config) (*PrayerTimesResponse, error) {
+	url := fmt.Sprintf("%s/%s&method=%d", cfg.apiURL(), byLocation("timings", "", city, country), method)
+	data, err := fetchTimings(ctx, url+cfg.apiParams())
+	return data, cityError(err, city, country)
+}
+
+// fetchPrayerTimesOn fetches the timings for another day than today.
+func fetchPrayerTimesOn(ctx context.Context, day time.Time, city, country string, method int, cfg Config) (*PrayerTimesResponse, error) {
+	url := fmt.Sprintf("%s/%s&method=%d", cfg.apiURL(), byLocation("timings", "/"+day.Format("02-01-2006"), city, country), method)
+	data, err := fetchTimings(ctx, url+cfg.apiParams())
+	return data, cityError(err, city, country)
+}
+
+// fetchPrayerTimesAt fetches today's timings for coordinates, for location sources without a city.
+func fetchPrayerTimesAt(ctx context.Context, latitude, longitude float64, method int, cfg Config) (*PrayerTimesResponse, error) {
+	url := fmt.Sprintf("%s/timings?latitude=%.4f&longitude=%.4f&method=%d", cfg.apiURL(), latitude, longitude, method)
+	return fetchTimings(ctx, url+cfg.apiParams())
+}
+
+func fetchTimings(ctx context.Context, url string) (*PrayerTimesResponse, error) {
+	status, body, err := api.get(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch prayer times: %v", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d for URL: %s", resp.StatusCode, url)
+	envelope, decodeErr := decodeAPIResponse(bytes.NewReader(body))
+	if status != http.StatusOK {
+		return nil, newStatusError(status, url, envelope, decodeErr)
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
 	}
 
-	var prayerData PrayerTimesResponse
-	if err := json.NewDecoder(resp.Body).Decode(&prayerData); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+	data, err := envelope.day()
+	if err != nil {
+		return nil, err
 	}
 
-	return &prayerData, nil
+	return &PrayerTimesResponse{Code: int(envelope.Code), Status: envelope.Status, Data: data}, nil
 }
 
 func parseTime(timeStr string) (time.Time, error) {
-	// Remove timezone info if present
-	timeStr = strings.Split(timeStr, " ")[0]
+	return parseTimeOn(timeStr, clock.Now())
+}
+
+// parseTimeOn places an API timing like "05:12 (+03)" on the given day, in that day's location.
+func parseTimeOn(timeStr string, day time.Time) (time.Time, error) {
+	// Split off the timezone info, which only matters on the days the clocks change
+	timeStr, zone, _ := strings.Cut(timeStr, " ")
+	zone = strings.Trim(zone, "()")
 	
-	today := time.Now()
 	parsed, err := time.Parse("15:04", timeStr)
 	if err != nil {
 		return time.Time{}, err
 	}
 	
-	// Set the date to today
-	return time.Date(today.Year(), today.Month(), today.Day(), 
-		parsed.Hour(), parsed.Minute(), 0, 0, today.Location()), nil
+	return wallClock(day, parsed.Hour(), parsed.Minute(), zone), nil
 }
 
 func findNextPrayer(timings Timings) (string, time.Time, error) {
-	now := time.Now()
-	
+	return findNextPrayerAt(timings, clock.Now())
+}
+
+// findNextPrayerAt finds the next prayer after now, reading the timings in now's location.
+func findNextPrayerAt(timings Timings, now time.Time) (string, time.Time, error) {
 	prayerTimes := map[string]string{
 		"Fajr":    timings.Fajr,
 		"Dhuhr":   timings.Dhuhr,
@@ -207,7 +937,7 @@ func findNextPrayer(timings Timings) (string, time.Time, error) {
 	}
 
 	for _, prayer := range []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"} {
-		prayerTime, err := parseTime(prayerTimes[prayer])
+		prayerTime, err := parseTimeOn(prayerTimes[prayer], now)
 		if err != nil {
 			continue
 		}
@@ -218,21 +948,117 @@ func findNextPrayer(timings Timings) (string, time.Time, error) {
 	}
 	
 	// If no prayer found today, return tomorrow's Fajr
-	tomorrow := now.AddDate(0, 0, 1)
-	fajrTime, err := parseTime(timings.Fajr)
+	fajrTime, err := parseTimeOn(timings.Fajr, addDays(now, 1))
 	if err != nil {
 		return "", time.Time{}, err
 	}
 	
-	tomorrowFajr := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(),
-		fajrTime.Hour(), fajrTime.Minute(), 0, 0, tomorrow.Location())
-	
-	return "Fajr", tomorrowFajr, nil
+	return "Fajr", fajrTime, nil
+}
+
+// currentPrayerAt returns the most recent prayer at now and when its window closes: Fajr at
+// sunrise, Isha at the next Fajr, and the others at the following prayer. Before Fajr the
+// most recent prayer is last night's Isha, taken as today's Isha time moved back a day.
+func currentPrayerAt(timings Timings, now time.Time) (string, time.Time, time.Time, error) {
+	prayerTimes := map[string]string{
+		"Fajr":    timings.Fajr,
+		"Sunrise": timings.Sunrise,
+		"Dhuhr":   timings.Dhuhr,
+		"Asr":     timings.Asr,
+		"Maghrib": timings.Maghrib,
+		"Isha":    timings.Isha,
+	}
+
+	starts := make([]time.Time, len(prayerOrder))
+	for i, prayer := range prayerOrder {
+		t, err := parseTimeOn(prayerTimes[prayer], now)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, err
+		}
+		starts[i] = t
+	}
+
+	for i := len(prayerOrder) - 1; i >= 0; i-- {
+		if starts[i].After(now) {
+			continue
+		}
+		switch prayerOrder[i] {
+		case "Sunrise":
+			// Fajr's window has closed, but it is still the last prayer
+			return "Fajr", starts[0], starts[i], nil
+		case "Isha":
+			fajr, err := parseTimeOn(timings.Fajr, addDays(now, 1))
+			return "Isha", starts[i], fajr, err
+		default:
+			return prayerOrder[i], starts[i], starts[i+1], nil
+		}
+	}
+
+	isha, err := parseTimeOn(timings.Isha, addDays(now, -1))
+	return "Isha", isha, starts[0], err
+}
+
+// liveCountdown redraws the countdown in place every second until at, or until ctx is done,
+// and returns the time left.
+func liveCountdown(ctx context.Context, at time.Time, unit time.Duration) time.Duration {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		left := at.Sub(clock.Now())
+		if left <= 0 {
+			fmt.Print("\r\x1b[K")
+			return left
+		}
+		fmt.Print("\r\x1b[K" + countdownStyle.Render(fmt.Sprintf("⏰ In %s", formatDurationTo(left, unit))))
+
+		select {
+		case <-ctx.Done():
+			fmt.Print("\r\x1b[K")
+			return at.Sub(clock.Now())
+		case <-ticker.C:
+		}
+	}
+}
+
+// sleepContext sleeps for d, returning early with an error if ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
 
 func formatDuration(d time.Duration) string {
+	return formatDurationTo(d, time.Minute)
+}
+
+// formatDurationTo formats d down to unit (time.Minute or time.Second). A partial unit
+// rounds up, so a countdown never shows 0m while there is still time left.
+func formatDurationTo(d, unit time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = (d + unit - 1) / unit * unit
+
 	hours := int(d.Hours())
 	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	if unit < time.Minute {
+		if hours > 0 {
+			return fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
+		}
+		if minutes > 0 {
+			return fmt.Sprintf("%dm %ds", minutes, seconds)
+		}
+		return fmt.Sprintf("%ds", seconds)
+	}
 	
 	if hours > 0 {
 		return fmt.Sprintf("%dh %dm", hours, minutes)
@@ -240,90 +1066,196 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
-func showPrayerTimes(city, country string, method int) {
-	data, err := fetchPrayerTimes(city, country, method)
+func showPrayerTimes(ctx context.Context, city, country string, method int, cfg Config, opts timingsOptions) {
+	data, err := opts.fetch(ctx, city, country, method, cfg)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
+	var moment string
+	if opts.At != "" {
+		if moment, err = opts.placeMoment(data.Data); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if cfg.A11y {
+		speakPrayerTimes(city, data, cfg, opts.today(), moment)
+		return
+	}
+
+	// Pick the layout from the terminal width: a compact list on narrow terminals, and
+	// iqama times with the extended timings on wide ones
+	width, ok := terminalWidth()
+	if !ok {
+		// Piped output keeps the standard layout
+		width = 50
+	}
+	ruleWidth := 50
+	if width < ruleWidth {
+		ruleWidth = width
+	}
 
 	// Header
 	header := titleStyle.Render(fmt.Sprintf("🕌 Prayer Times for %s", cityStyle.Render(city)))
-	dateInfo := fmt.Sprintf("📅 %s | %s %s, %s AH", 
-		data.Data.Date.Readable,
-		data.Data.Date.Hijri.Day,
-		data.Data.Date.Hijri.Month.En,
-		data.Data.Date.Hijri.Year)
+	dateInfo := "📅 " + cfg.dateLine(data.Data.Date)
 	
 	fmt.Println(header)
-	fmt.Println(strings.Repeat("━", 50))
-	fmt.Println(cityStyle.Render(dateInfo))
+	fmt.Println(strings.Repeat("━", ruleWidth))
+	if lipgloss.Width(dateInfo) > width {
+		// One date per line when both don't fit
+		for _, part := range strings.Split(dateInfo, " | ") {
+			fmt.Println(cityStyle.Render(part))
+		}
+	} else {
+		fmt.Println(cityStyle.Render(dateInfo))
+	}
 	fmt.Println()
 
-	// Find next prayer
-	nextPrayer, nextTime, err := findNextPrayer(data.Data.Timings)
+	// Find next prayer, when the timings are today's
 	var nextPrayerName string
-	if err == nil {
-		nextPrayerName = nextPrayer
+	var nextTime time.Time
+	if opts.today() {
+		if prayer, at, err := findNextPrayer(data.Data.Timings); err == nil {
+			nextPrayerName, nextTime = prayer, at
+		}
 	}
 
 	// Display prayers
 	timings := map[string]string{
-		"Fajr":    data.Data.Timings.Fajr,
-		"Sunrise": data.Data.Timings.Sunrise,
-		"Dhuhr":   data.Data.Timings.Dhuhr,
-		"Asr":     data.Data.Timings.Asr,
-		"Maghrib": data.Data.Timings.Maghrib,
-		"Isha":    data.Data.Timings.Isha,
+		"Imsak":     data.Data.Timings.Imsak,
+		"Fajr":      data.Data.Timings.Fajr,
+		"Sunrise":   data.Data.Timings.Sunrise,
+		"Dhuhr":     data.Data.Timings.Dhuhr,
+		"Asr":       data.Data.Timings.Asr,
+		"Maghrib":   data.Data.Timings.Maghrib,
+		"Isha":      data.Data.Timings.Isha,
+		"Midnight":  data.Data.Timings.Midnight,
+		"Lastthird": data.Data.Timings.Lastthird,
+	}
+
+	// Right to left, the name goes on the right and the table is right-aligned
+	rtlRow := lipgloss.NewStyle().Width(ruleWidth).Align(lipgloss.Right)
+	rtlName := lipgloss.NewStyle().Width(7).Align(lipgloss.Right)
+
+	rows := prayerOrder
+	wide := width >= wideLayoutWidth && !cfg.rtl()
+	if wide {
+		rows = []string{"Imsak", "Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha", "Midnight", "Lastthird"}
+		if len(cfg.Iqama) > 0 {
+			fmt.Println(cityStyle.Render(fmt.Sprintf("    %s %-7s %s", padRight("", 15), "Adhan", "Iqama")))
+		}
 	}
 
-	for _, prayer := range prayerOrder {
-		timeStr := strings.Split(timings[prayer], " ")[0] // Remove timezone
+	for _, prayer := range rows {
+		if timings[prayer] == "" {
+			continue
+		}
+		timeStr := cfg.digits(strings.Split(timings[prayer], " ")[0]) // Remove timezone
 		prayerName := prayerNames[prayer]
+		isNext := prayer == nextPrayerName && prayer != "Sunrise"
 		
-		if prayer == nextPrayerName && prayer != "Sunrise" {
-			line := fmt.Sprintf("%s %s", emojiStyle.Render("▶"), nextPrayerStyle.Render(fmt.Sprintf("%-15s %s", prayerName, timeStyle.Render(timeStr))))
-			fmt.Println(line)
-		} else {
-			line := fmt.Sprintf("  %s %s", prayerStyle.Render(fmt.Sprintf("%-15s", prayerName)), timeStyle.Render(timeStr))
-			fmt.Println(line)
+		switch {
+		case cfg.rtl():
+			name := rtlName.Render(localPrayerName(cfg.Language, prayer))
+			if isNext {
+				fmt.Println(rtlRow.Render(fmt.Sprintf("%s  %s %s", timeStyle.Render(timeStr), nextPrayerStyle.UnsetPaddingLeft().Render(name), emojiStyle.UnsetPaddingRight().Render("◀"))))
+			} else {
+				fmt.Println(rtlRow.Render(fmt.Sprintf("%s  %s  ", timeStyle.Render(timeStr), prayerStyle.UnsetPaddingLeft().Render(name))))
+			}
+		case width < narrowLayoutWidth:
+			// Bare names, no padding beyond the longest name
+			line := padRight(prayer, 8) + " " + timeStyle.Render(timeStr)
+			if isNext {
+				fmt.Println(emojiStyle.Render("▶") + nextPrayerStyle.UnsetPaddingLeft().Render(line))
+			} else {
+				fmt.Println("  " + line)
+			}
+		default:
+			if extendedNames[prayer] != "" {
+				prayerName = extendedNames[prayer]
+			}
+			line := padRight(prayerName, 15) + " " + timeStyle.Render(timeStr)
+			if wide {
+				line = padRight(prayerName, 15) + " " + timeStyle.Render(padRight(timeStr, 7))
+				if delay := cfg.iqamaDelay(prayer); delay > 0 {
+					if adhan, err := parseTime(timings[prayer]); err == nil {
+						line += " " + cityStyle.Render(cfg.digits(adhan.Add(time.Duration(delay)*time.Minute).Format("15:04")))
+					}
+				}
+			}
+
+			if isNext {
+				fmt.Println(emojiStyle.Render("▶") + nextPrayerStyle.Render(line))
+			} else if extendedNames[prayer] != "" {
+				fmt.Println("  " + cityStyle.PaddingLeft(2).Render(line))
+			} else {
+				fmt.Println("  " + prayerStyle.Render(line))
+			}
 		}
 	}
 
 	// Show countdown to next prayer
-	if err == nil && nextPrayerName != "Sunrise" {
-		duration := time.Until(nextTime)
+	if nextPrayerName != "" && nextPrayerName != "Sunrise" {
+		duration := nextTime.Sub(clock.Now())
 		if duration > 0 {
 			fmt.Println()
-			countdown := fmt.Sprintf("⏰ Next prayer in %s", formatDuration(duration))
+			countdown := fmt.Sprintf("⏰ Next prayer %s", relativeDuration(cfg, duration, time.Minute))
 			fmt.Println(countdownStyle.Render(countdown))
 		}
 	}
 
+	if opts.today() {
+		showWeatherPanel(ctx, data, cfg)
+	}
+
+	if moment != "" {
+		fmt.Println()
+		fmt.Println(countdownStyle.Render("🕰️  " + cfg.digits(moment)))
+	}
+
+	showTravelPanel(ctx, data, method, cfg)
+
 	// Footer with method info
 	fmt.Println()
-	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(strings.Repeat("━", ruleWidth))
 	methodInfo := fmt.Sprintf("📍 Method: %s", data.Data.Meta.Method.Name)
 	fmt.Println(prayerStyle.Render(methodInfo))
 }
 
-func showNextPrayer(city, country string, method int) {
-	data, err := fetchPrayerTimes(city, country, method)
+// nextOptions are the flags of the next command
+type nextOptions struct {
+	Format    string
+	Precision string
+	Live      bool
+	Context   bool
+}
+
+// unit is the smallest unit the countdown shows.
+func (o nextOptions) unit() time.Duration {
+	if o.Precision == "s" {
+		return time.Second
+	}
+	return time.Minute
+}
+
+func showNextPrayer(ctx context.Context, city, country string, method int, cfg Config, opts nextOptions) {
+	format := opts.Format
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		os.Exit(1)
+		exitNext(format, err)
 	}
 
 	nextPrayer, nextTime, err := findNextPrayer(data.Data.Timings)
 	if err != nil {
-		fmt.Printf("Error finding next prayer: %v\n", err)
-		os.Exit(1)
+		exitNext(format, fmt.Errorf("finding next prayer: %v", err))
 	}
 
 	// Skip sunrise for prayer notifications
 	if nextPrayer == "Sunrise" {
 		// Find the prayer after sunrise
-		now := time.Now()
+		now := clock.Now()
 		timings := map[string]string{
 			"Dhuhr":   data.Data.Timings.Dhuhr,
 			"Asr":     data.Data.Timings.Asr,
@@ -345,24 +1277,53 @@ func showNextPrayer(city, country string, method int) {
 		}
 	}
 
-	duration := time.Until(nextTime)
+	duration := nextTime.Sub(clock.Now())
+
+	if format == "shortcuts" {
+		printShortcutsNext(city, nextPrayer, nextTime, duration, data.Data)
+		return
+	}
+	if cfg.A11y {
+		// No live countdown: rewriting the line in place is noise to a screen reader
+		speakNextPrayer(city, data, nextPrayer, nextTime, opts)
+		return
+	}
 	
 	// Header
 	fmt.Println(titleStyle.Render("🕌 Next Prayer"))
 	fmt.Println(strings.Repeat("━", 30))
 	fmt.Println()
 
+	// Most recent prayer, and whether there is still time to pray it
+	if opts.Context {
+		prev, start, end, err := currentPrayerAt(data.Data.Timings, clock.Now())
+		if err == nil {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("%s at %s, %s", prayerNames[prev], cfg.digits(start.Format("15:04")), relativeDuration(cfg, start.Sub(clock.Now()), time.Minute))))
+			if until := end.Sub(clock.Now()); until > 0 {
+				fmt.Println(countdownStyle.Render(fmt.Sprintf("%s time ends %s", prayerNames[prev], relativeDuration(cfg, until, opts.unit()))))
+			} else {
+				fmt.Println(cityStyle.Render(fmt.Sprintf("%s time ended at %s", prayerNames[prev], cfg.digits(end.Format("15:04")))))
+			}
+			fmt.Println()
+		}
+	}
+
 	// Prayer info
 	prayerName := prayerNames[nextPrayer]
-	timeStr := nextTime.Format("15:04")
+	timeStr := cfg.digits(nextTime.Format("15:04"))
 	
 	fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s at %s", prayerName, timeStyle.Render(timeStr))))
 	fmt.Println()
 	
 	// Countdown
+	if opts.Live && duration > 0 {
+		duration = liveCountdown(ctx, nextTime, opts.unit())
+	}
 	if duration > 0 {
-		countdown := fmt.Sprintf("⏰ In %s", formatDuration(duration))
+		countdown := fmt.Sprintf("⏰ %s", capitalize(relativeDuration(cfg, duration, opts.unit())))
 		fmt.Println(countdownStyle.Render(countdown))
+	} else if cfg.Durations == durationsHumanized {
+		fmt.Println(countdownStyle.Render(fmt.Sprintf("🔔 %s", capitalize(humanizeDuration(cfg.Language, duration)))))
 	} else {
 		fmt.Println(countdownStyle.Render("🔔 Prayer time has arrived!"))
 	}
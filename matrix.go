@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// matrixNotifier posts reminders to a Matrix room through the client-server API.
+type matrixNotifier struct {
+	config MatrixConfig
+}
+
+type matrixMessage struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+func (matrixNotifier) name() string { return "matrix" }
+
+func (m matrixNotifier) notify(ctx context.Context, n notification) error {
+	payload, err := json.Marshal(matrixMessage{
+		MsgType:       "m.text",
+		Body:          n.Title + "\n" + n.Body,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: fmt.Sprintf("<b>%s</b><br>%s", html.EscapeString(n.Title), html.EscapeString(n.Body)),
+	})
+	if err != nil {
+		return err
+	}
+
+	// The transaction ID makes retries of the same send idempotent
+	txn := fmt.Sprintf("pray-%d", time.Now().UnixNano())
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		strings.TrimSuffix(m.config.Homeserver, "/"), url.PathEscape(m.config.RoomID), txn)
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to send Matrix message: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+m.config.AccessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send Matrix message: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var matrixErr struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&matrixErr)
+		return fmt.Errorf("failed to send Matrix message: %s %s", resp.Status, matrixErr.Error)
+	}
+	return nil
+}
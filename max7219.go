@@ -0,0 +1,103 @@
+//go:build max7219 && linux
+
+package main
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// MAX7219 registers
+const (
+	max7219Digit0      = 0x01
+	max7219DecodeMode  = 0x09
+	max7219Intensity   = 0x0A
+	max7219ScanLimit   = 0x0B
+	max7219Shutdown    = 0x0C
+	max7219DisplayTest = 0x0F
+)
+
+// SPI_IOC_WR_MAX_SPEED_HZ from linux/spi/spidev.h
+const spiIocWrMaxSpeedHz = 0x40046b04
+
+// The MAX7219 is rated to 10 MHz; 1 MHz is plenty and tolerates long jumper wires
+const max7219SpeedHz = 1000000
+
+// max7219 drives a chain of 8x8 modules through spidev, FC-16 style: each digit register
+// is a row with the leftmost column in bit 7, and the module wired to DIN is the
+// rightmost.
+type max7219 struct {
+	spi     *os.File
+	modules int
+}
+
+func openMAX7219(path string, modules, brightness int) (ledMatrix, error) {
+	spi, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SPI device: %v", err)
+	}
+	speed := uint32(max7219SpeedHz)
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, spi.Fd(), spiIocWrMaxSpeedHz, uintptr(unsafe.Pointer(&speed))); errno != 0 {
+		spi.Close()
+		return nil, fmt.Errorf("failed to set SPI speed on %s: %v", path, errno)
+	}
+
+	m := &max7219{spi: spi, modules: modules}
+	for _, cmd := range [][2]byte{
+		{max7219DisplayTest, 0},
+		{max7219DecodeMode, 0},
+		{max7219ScanLimit, 7},
+		{max7219Intensity, byte(brightness)},
+		{max7219Shutdown, 1},
+	} {
+		if err := m.command(cmd[0], cmd[1]); err != nil {
+			spi.Close()
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// command writes the same register on every module.
+func (m *max7219) command(register, value byte) error {
+	buf := make([]byte, 0, 2*m.modules)
+	for i := 0; i < m.modules; i++ {
+		buf = append(buf, register, value)
+	}
+	return m.write(buf)
+}
+
+func (m *max7219) show(frame []byte) error {
+	for row := 0; row < ledRows; row++ {
+		// The first bytes out are shifted along to the far end of the chain, the leftmost module
+		buf := make([]byte, 0, 2*m.modules)
+		for module := 0; module < m.modules; module++ {
+			var bits byte
+			for col := 0; col < 8; col++ {
+				if i := 8*module + col; i < len(frame) && frame[i]&(1<<row) != 0 {
+					bits |= 0x80 >> col
+				}
+			}
+			buf = append(buf, byte(max7219Digit0+row), bits)
+		}
+		if err := m.write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *max7219) write(buf []byte) error {
+	if _, err := m.spi.Write(buf); err != nil {
+		return fmt.Errorf("failed to write to MAX7219: %v", err)
+	}
+	return nil
+}
+
+// Close blanks the display and releases the SPI device.
+func (m *max7219) Close() error {
+	m.command(max7219Shutdown, 0)
+	return m.spi.Close()
+}
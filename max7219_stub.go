@@ -0,0 +1,9 @@
+//go:build !max7219 || !linux
+
+package main
+
+import "errors"
+
+func openMAX7219(path string, modules, brightness int) (ledMatrix, error) {
+	return nil, errors.New("MAX7219 displays are not supported in this build, rebuild on Linux with -tags max7219")
+}
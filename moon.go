@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type moonPhase struct {
+	Limit float64 // upper bound of the phase as a fraction of the lunar month
+	Emoji string
+	Name  string
+}
+
+var moonPhases = []moonPhase{
+	{0.0339, "🌑", "New Moon"},
+	{0.2160, "🌒", "Waxing Crescent"},
+	{0.2840, "🌓", "First Quarter"},
+	{0.4660, "🌔", "Waxing Gibbous"},
+	{0.5340, "🌕", "Full Moon"},
+	{0.7160, "🌖", "Waning Gibbous"},
+	{0.7840, "🌗", "Last Quarter"},
+	{0.9661, "🌘", "Waning Crescent"},
+	{1.0001, "🌑", "New Moon"},
+}
+
+func newMoonCmd(cfg Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "moon",
+		Short: "Show the lunar phase and the next new moon",
+		Long:  "Show the current lunar phase and illumination, and the astronomical new moon (conjunction) ahead of the next Hijri month. Calculated offline.",
+		Run: func(cmd *cobra.Command, args []string) {
+			showMoon(clock.Now(), cfg.HijriAdjustment)
+		},
+	}
+}
+
+func phaseOf(age time.Duration) moonPhase {
+	fraction := age.Hours() / 24 / synodicMonth
+	for _, phase := range moonPhases {
+		if fraction < phase.Limit {
+			return phase
+		}
+	}
+	return moonPhases[len(moonPhases)-1]
+}
+
+func showMoon(now time.Time, hijriAdjustment int) {
+	prev, next := surroundingNewMoons(now)
+	age := now.Sub(prev)
+	phase := phaseOf(age)
+	illumination := moonIllumination(now) * 100
+
+	hijri := tabularHijri(addDays(now, hijriAdjustment))
+	upcoming := hijri.nextMonth()
+
+	// Header
+	fmt.Println(titleStyle.Render("🌙 Moon"))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println()
+
+	fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%s %s", phase.Emoji, phase.Name)))
+	fmt.Println()
+
+	rows := [][2]string{
+		{"Illumination", fmt.Sprintf("%.0f%%", illumination)},
+		{"Age", fmt.Sprintf("%.1f days", age.Hours()/24)},
+		{"New moon", fmt.Sprintf("%s (in %s)", next.Local().Format("Mon 02 Jan 2006 15:04"), formatLongDuration(next.Sub(now)))},
+	}
+	for _, row := range rows {
+		fmt.Printf("  %s %s\n", prayerStyle.Render(fmt.Sprintf("%-15s", row[0])), timeStyle.Render(row[1]))
+	}
+
+	// The crescent is usually first seen the evening after conjunction, starting the month the next day
+	start := addDays(next.Local(), 1)
+	fmt.Println()
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("📅 %s %d AH expected to begin around %s",
+		hijriMonthNames[upcoming.Month], upcoming.Year, start.Format("02 Jan"))))
+	fmt.Println(prayerStyle.Render("(±1 day, subject to local moon sighting)"))
+}
+
+// formatLongDuration is formatDuration with a days component for spans over a day.
+func formatLongDuration(d time.Duration) string {
+	days := int(d.Hours()) / 24
+	if days == 0 {
+		return formatDuration(d)
+	}
+	return fmt.Sprintf("%dd %dh", days, int(d.Hours())%24)
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+//go:embed data/names.json
+var namesData []byte
+
+// Name is one of the 99 Names of Allah (Asmaa ul-Husna)
+type Name struct {
+	Number          int    `json:"number"`
+	Arabic          string `json:"arabic"`
+	Transliteration string `json:"transliteration"`
+	Meaning         string `json:"meaning"`
+}
+
+func newNamesCmd() *cobra.Command {
+	var today bool
+
+	cmd := &cobra.Command{
+		Use:   "names [n]",
+		Short: "Browse the 99 Names of Allah",
+		Long:  "List the 99 Names of Allah with Arabic, transliteration, and meaning, or show a single name by number.",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			names, err := loadNames()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			switch {
+			case len(args) == 1:
+				n, err := strconv.Atoi(args[0])
+				if err != nil || n < 1 || n > len(names) {
+					fmt.Printf("Error: name number must be between 1 and %d\n", len(names))
+					os.Exit(1)
+				}
+				showName("✨ Name", names[n-1])
+			case today:
+				showName("✨ Name of the Day", nameOfTheDay(names, clock.Now()))
+			default:
+				showNames(names)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&today, "today", false, "Show only the name of the day")
+	return cmd
+}
+
+func loadNames() ([]Name, error) {
+	var names []Name
+	if err := json.Unmarshal(namesData, &names); err != nil {
+		return nil, fmt.Errorf("failed to decode names data: %v", err)
+	}
+	return names, nil
+}
+
+// nameOfTheDay rotates through the names, one per calendar day.
+func nameOfTheDay(names []Name, date time.Time) Name {
+	return names[dayIndex(date)%len(names)]
+}
+
+func showName(title string, name Name) {
+	fmt.Println(titleStyle.Render(title))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println()
+	fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("%d. %s", name.Number, name.Arabic)))
+	fmt.Println(prayerStyle.Render(name.Transliteration))
+	fmt.Println(cityStyle.PaddingLeft(2).Render(name.Meaning))
+}
+
+func showNames(names []Name) {
+	today := nameOfTheDay(names, clock.Now())
+
+	fmt.Println(titleStyle.Render("✨ Asmaa ul-Husna — The 99 Names of Allah"))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println()
+
+	for _, name := range names {
+		label := fmt.Sprintf("%2d. %-22s %s", name.Number, name.Transliteration, name.Meaning)
+		if name.Number == today.Number {
+			fmt.Printf("%s %s %s\n", emojiStyle.Render("▶"), nextPrayerStyle.Render(label), timeStyle.Render(name.Arabic))
+		} else {
+			fmt.Printf("  %s %s\n", prayerStyle.Render(label), timeStyle.Render(name.Arabic))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("▶ Name of the day: %s — %s", today.Transliteration, today.Meaning)))
+}
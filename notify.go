@@ -0,0 +1,257 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// notification is what the daemon sends for an event. Speech is the plain sentence read
+// aloud by speech backends, and is empty for events that shouldn't be announced.
+type notification struct {
+	Title  string
+	Body   string
+	Speech string
+	Sound  string
+}
+
+// notifier delivers a notification through one backend
+type notifier interface {
+	// name is the backend's name in the notifiers setting
+	name() string
+	notify(ctx context.Context, n notification) error
+}
+
+// delivery is how sending a notification through one backend went
+type delivery struct {
+	Notifier string
+	Err      error
+}
+
+// dispatch sends n through every backend at once, so a slow one such as email or speech
+// doesn't hold up the others, and returns how each went, in the order of notifiers.
+func dispatch(ctx context.Context, notifiers []notifier, n notification) []delivery {
+	deliveries := make([]delivery, len(notifiers))
+	var wg sync.WaitGroup
+	for i, backend := range notifiers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			deliveries[i] = delivery{Notifier: backend.name(), Err: backend.notify(ctx, n)}
+		}()
+	}
+	wg.Wait()
+	return deliveries
+}
+
+// newNotifiers builds the backends listed in the config, defaulting to desktop notifications.
+func newNotifiers(cfg Config) ([]notifier, error) {
+	names := cfg.Notifiers
+	if len(names) == 0 {
+		names = []string{"desktop"}
+	}
+
+	var notifiers []notifier
+	if len(cfg.Sounds) > 0 {
+		notifiers = append(notifiers, soundNotifier{})
+	}
+	for _, name := range names {
+		switch name {
+		case "desktop":
+			notifiers = append(notifiers, desktopNotifier{})
+		case "speech":
+			notifiers = append(notifiers, speechNotifier{lang: cfg.Language, voice: cfg.Voices[cfg.Language]})
+		case "matrix":
+			m := cfg.Matrix
+			if m.Homeserver == "" || m.AccessToken == "" || m.RoomID == "" {
+				return nil, fmt.Errorf("the matrix notifier needs matrix.homeserver, matrix.access_token, and matrix.room_id")
+			}
+			notifiers = append(notifiers, matrixNotifier{config: m})
+		case "email":
+			e, err := newEmailNotifier(cfg.Email)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, e)
+		case "terminal":
+			t, err := newTerminalNotifier(cfg.TerminalNotify)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, t)
+		case "ntfy":
+			if cfg.Ntfy.Topic == "" {
+				return nil, fmt.Errorf("the ntfy notifier needs ntfy.topic")
+			}
+			notifiers = append(notifiers, ntfyNotifier{config: cfg.Ntfy})
+		case "mqtt":
+			m, err := newMQTTNotifier(cfg.MQTT)
+			if err != nil {
+				return nil, err
+			}
+			notifiers = append(notifiers, m)
+		case "hook":
+			if len(cfg.Hooks.OnNotify) == 0 {
+				return nil, fmt.Errorf("the hook notifier needs hooks.on_notify")
+			}
+			notifiers = append(notifiers, hookNotifier{commands: cfg.Hooks.OnNotify})
+		default:
+			return nil, fmt.Errorf("unknown notifier %q (expected desktop, speech, matrix, email, terminal, ntfy, mqtt, or hook)", name)
+		}
+	}
+	return notifiers, nil
+}
+
+type desktopNotifier struct{}
+
+func (desktopNotifier) name() string { return "desktop" }
+
+func (desktopNotifier) notify(ctx context.Context, n notification) error {
+	return sendNotification(ctx, n.Title, n.Body)
+}
+
+// sendNotification shows a desktop notification using the platform's native tool.
+func sendNotification(ctx context.Context, title, body string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", body, title)
+		cmd = exec.CommandContext(ctx, "osascript", "-e", script)
+	case "windows":
+		script := fmt.Sprintf(`[reflection.assembly]::loadwithpartialname('System.Windows.Forms') | Out-Null;`+
+			`$n = New-Object System.Windows.Forms.NotifyIcon;`+
+			`$n.Icon = [System.Drawing.SystemIcons]::Information;`+
+			`$n.Visible = $true;`+
+			`$n.ShowBalloonTip(10000, '%s', '%s', 'Info')`,
+			strings.ReplaceAll(title, "'", "''"), strings.ReplaceAll(body, "'", "''"))
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", script)
+	default:
+		cmd = exec.CommandContext(ctx, "notify-send", "--app-name=pray", title, body)
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to send notification: %v", err)
+	}
+	return nil
+}
+
+// Sounds still playing, so short-lived commands can wait for them before exiting
+var playing sync.WaitGroup
+
+// soundNotifier plays the notification's audio file in the background.
+type soundNotifier struct{}
+
+func (soundNotifier) name() string { return "sound" }
+
+func (soundNotifier) notify(ctx context.Context, n notification) error {
+	if n.Sound == "" {
+		return nil
+	}
+	cmd, err := soundCommand(ctx, n.Sound)
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to play %s: %v", n.Sound, err)
+	}
+	playing.Add(1)
+	go func() {
+		defer playing.Done()
+		cmd.Wait()
+	}()
+	return nil
+}
+
+// soundCommand builds the platform's audio player command for a file.
+func soundCommand(ctx context.Context, path string) (*exec.Cmd, error) {
+	return soundCommandAt(ctx, path, 1)
+}
+
+// soundCommandAt is soundCommand at a volume from 0 to 1. Players without a volume
+// option (SoundPlayer, aplay) play at the system volume.
+func soundCommandAt(ctx context.Context, path string, volume float64) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return exec.CommandContext(ctx, "afplay", "-v", fmt.Sprintf("%.2f", volume), path), nil
+	case "windows":
+		script := fmt.Sprintf(`(New-Object Media.SoundPlayer '%s').PlaySync()`, strings.ReplaceAll(path, "'", "''"))
+		return exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", script), nil
+	}
+
+	// Linux players, in order of preference
+	percent := int(volume * 100)
+	players := [][]string{
+		{"paplay", fmt.Sprintf("--volume=%d", int(volume*65536))},
+		{"pw-play", fmt.Sprintf("--volume=%.2f", volume)},
+		{"mpv", "--no-video", "--really-quiet", fmt.Sprintf("--volume=%d", percent)},
+		{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet", "-volume", fmt.Sprint(percent)},
+		{"aplay", "-q"},
+	}
+	for _, player := range players {
+		if bin, err := exec.LookPath(player[0]); err == nil {
+			return exec.CommandContext(ctx, bin, append(player[1:], path)...), nil
+		}
+	}
+	return nil, fmt.Errorf("no audio player found (install paplay, mpv, ffplay, or aplay)")
+}
+
+// speechNotifier reads announcements aloud with say (macOS), SAPI (Windows), or espeak.
+type speechNotifier struct {
+	lang  string
+	voice string
+}
+
+func (speechNotifier) name() string { return "speech" }
+
+func (s speechNotifier) notify(ctx context.Context, n notification) error {
+	if n.Speech == "" {
+		return nil
+	}
+	if err := speak(ctx, n.Speech, s.lang, s.voice); err != nil {
+		return fmt.Errorf("failed to speak announcement: %v", err)
+	}
+	return nil
+}
+
+// speak reads text aloud. An empty voice uses the system default, or the language on espeak.
+func speak(ctx context.Context, text, lang, voice string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		args := []string{text}
+		if voice != "" {
+			args = []string{"-v", voice, text}
+		}
+		cmd = exec.CommandContext(ctx, "say", args...)
+	case "windows":
+		script := `Add-Type -AssemblyName System.Speech;` +
+			`$s = New-Object System.Speech.Synthesis.SpeechSynthesizer;`
+		if voice != "" {
+			script += fmt.Sprintf(`$s.SelectVoice('%s');`, strings.ReplaceAll(voice, "'", "''"))
+		}
+		script += fmt.Sprintf(`$s.Speak('%s')`, strings.ReplaceAll(text, "'", "''"))
+		cmd = exec.CommandContext(ctx, "powershell", "-NoProfile", "-Command", script)
+	default:
+		espeak, err := exec.LookPath("espeak-ng")
+		if err != nil {
+			if espeak, err = exec.LookPath("espeak"); err != nil {
+				return fmt.Errorf("espeak-ng or espeak is required")
+			}
+		}
+		if voice == "" {
+			voice = lang
+		}
+		args := []string{text}
+		if voice != "" {
+			args = []string{"-v", voice, text}
+		}
+		cmd = exec.CommandContext(ctx, espeak, args...)
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Public ntfy server, used when the config doesn't name one
+const defaultNtfyServer = "https://ntfy.sh"
+
+// ntfyNotifier pushes reminders to a phone through an ntfy topic, on ntfy.sh or a
+// self-hosted server, for the ntfy app to show.
+type ntfyNotifier struct {
+	config NtfyConfig
+}
+
+type ntfyMessage struct {
+	Topic   string   `json:"topic"`
+	Title   string   `json:"title"`
+	Message string   `json:"message"`
+	Tags    []string `json:"tags"`
+}
+
+func (ntfyNotifier) name() string { return "ntfy" }
+
+func (n ntfyNotifier) notify(ctx context.Context, msg notification) error {
+	// Published as JSON, since headers can't carry the Arabic of ar notifications
+	payload, err := json.Marshal(ntfyMessage{
+		Topic:   n.config.Topic,
+		Title:   msg.Title,
+		Message: msg.Body,
+		Tags:    []string{"mosque"},
+	})
+	if err != nil {
+		return err
+	}
+
+	server := n.config.Server
+	if server == "" {
+		server = defaultNtfyServer
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(server, "/"), bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to publish to ntfy: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if n.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.config.Token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish to ntfy: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var ntfyErr struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&ntfyErr)
+		return fmt.Errorf("failed to publish to ntfy: %s %s", resp.Status, ntfyErr.Error)
+	}
+	return nil
+}
@@ -0,0 +1,2213 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	BearerTokenScopes = "bearerToken.Scopes"
+	QueryTokenScopes  = "queryToken.Scopes"
+)
+
+// Defines values for CalendarPayloadWeekStart.
+const (
+	Monday   CalendarPayloadWeekStart = "Monday"
+	Saturday CalendarPayloadWeekStart = "Saturday"
+	Sunday   CalendarPayloadWeekStart = "Sunday"
+)
+
+// Defines values for HouseholdMemberToday.
+const (
+	HouseholdMemberTodayLate   HouseholdMemberToday = "late"
+	HouseholdMemberTodayMissed HouseholdMemberToday = "missed"
+	HouseholdMemberTodayPrayed HouseholdMemberToday = "prayed"
+)
+
+// Defines values for PrayerLogPrayer.
+const (
+	PrayerLogPrayerAsr     PrayerLogPrayer = "Asr"
+	PrayerLogPrayerDhuhr   PrayerLogPrayer = "Dhuhr"
+	PrayerLogPrayerFajr    PrayerLogPrayer = "Fajr"
+	PrayerLogPrayerIsha    PrayerLogPrayer = "Isha"
+	PrayerLogPrayerMaghrib PrayerLogPrayer = "Maghrib"
+)
+
+// Defines values for PrayerLogStatus.
+const (
+	PrayerLogStatusLate   PrayerLogStatus = "late"
+	PrayerLogStatusMissed PrayerLogStatus = "missed"
+	PrayerLogStatusPrayed PrayerLogStatus = "prayed"
+)
+
+// Defines values for TrackRequestStatus.
+const (
+	TrackRequestStatusLate   TrackRequestStatus = "late"
+	TrackRequestStatusMissed TrackRequestStatus = "missed"
+	TrackRequestStatusOnTime TrackRequestStatus = "on_time"
+	TrackRequestStatusPrayed TrackRequestStatus = "prayed"
+)
+
+// Defines values for FormatParam.
+const (
+	FormatParamAlexa      FormatParam = "alexa"
+	FormatParamDialogflow FormatParam = "dialogflow"
+)
+
+// Defines values for LogPrayerParamsPrayer.
+const (
+	LogPrayerParamsPrayerAsr     LogPrayerParamsPrayer = "Asr"
+	LogPrayerParamsPrayerDhuhr   LogPrayerParamsPrayer = "Dhuhr"
+	LogPrayerParamsPrayerFajr    LogPrayerParamsPrayer = "Fajr"
+	LogPrayerParamsPrayerIsha    LogPrayerParamsPrayer = "Isha"
+	LogPrayerParamsPrayerMaghrib LogPrayerParamsPrayer = "Maghrib"
+)
+
+// Defines values for LogPrayerParamsStatus.
+const (
+	LogPrayerParamsStatusLate   LogPrayerParamsStatus = "late"
+	LogPrayerParamsStatusMissed LogPrayerParamsStatus = "missed"
+	LogPrayerParamsStatusPrayed LogPrayerParamsStatus = "prayed"
+)
+
+// Defines values for GetVoiceParamsFormat.
+const (
+	GetVoiceParamsFormatAlexa      GetVoiceParamsFormat = "alexa"
+	GetVoiceParamsFormatDialogflow GetVoiceParamsFormat = "dialogflow"
+)
+
+// Defines values for PostVoiceParamsFormat.
+const (
+	Alexa      PostVoiceParamsFormat = "alexa"
+	Dialogflow PostVoiceParamsFormat = "dialogflow"
+)
+
+// CalendarPayload defines model for CalendarPayload.
+type CalendarPayload struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Days    []Day  `json:"days"`
+	Month   int    `json:"month"`
+
+	// WeekStart First day of the week, from the week_start setting
+	WeekStart CalendarPayloadWeekStart `json:"week_start"`
+	Year      int                      `json:"year"`
+}
+
+// CalendarPayloadWeekStart First day of the week, from the week_start setting
+type CalendarPayloadWeekStart string
+
+// Date defines model for Date.
+type Date struct {
+	Gregorian *Gregorian `json:"gregorian,omitempty"`
+	Hijri     *Hijri     `json:"hijri,omitempty"`
+	Readable  *string    `json:"readable,omitempty"`
+}
+
+// Day defines model for Day.
+type Day struct {
+	Date Date `json:"date"`
+	Meta Meta `json:"meta"`
+
+	// Timings Times as HH:MM, sometimes followed by the zone, e.g. 04:31 (+03)
+	Timings Timings `json:"timings"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Error string `json:"error"`
+}
+
+// Gregorian defines model for Gregorian.
+type Gregorian struct {
+	// Date DD-MM-YYYY
+	Date    *string  `json:"date,omitempty"`
+	Day     *string  `json:"day,omitempty"`
+	Month   *Month   `json:"month,omitempty"`
+	Weekday *Weekday `json:"weekday,omitempty"`
+	Year    *string  `json:"year,omitempty"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Status string `json:"status"`
+	Uptime string `json:"uptime"`
+}
+
+// Hijri defines model for Hijri.
+type Hijri struct {
+	// Date DD-MM-YYYY
+	Date    *string  `json:"date,omitempty"`
+	Day     *string  `json:"day,omitempty"`
+	Format  *string  `json:"format,omitempty"`
+	Month   *Month   `json:"month,omitempty"`
+	Weekday *Weekday `json:"weekday,omitempty"`
+	Year    *string  `json:"year,omitempty"`
+}
+
+// HouseholdMember defines model for HouseholdMember.
+type HouseholdMember struct {
+	// Today Status of each prayer logged today
+	Today map[string]HouseholdMemberToday `json:"today"`
+	User  string                          `json:"user"`
+
+	// Week How many prayers with each status this week
+	Week map[string]int `json:"week"`
+}
+
+// HouseholdMemberToday defines model for HouseholdMember.Today.
+type HouseholdMemberToday string
+
+// Location defines model for Location.
+type Location struct {
+	Latitude  *float32 `json:"latitude,omitempty"`
+	Longitude *float32 `json:"longitude,omitempty"`
+}
+
+// Meta defines model for Meta.
+type Meta struct {
+	Latitude  *float32 `json:"latitude,omitempty"`
+	Longitude *float32 `json:"longitude,omitempty"`
+	Method    *Method  `json:"method,omitempty"`
+	Timezone  *string  `json:"timezone,omitempty"`
+}
+
+// Method defines model for Method.
+type Method struct {
+	Id       *int                    `json:"id,omitempty"`
+	Location *Location               `json:"location,omitempty"`
+	Name     *string                 `json:"name,omitempty"`
+	Params   *map[string]interface{} `json:"params,omitempty"`
+}
+
+// Month defines model for Month.
+type Month struct {
+	Ar     *string `json:"ar,omitempty"`
+	En     *string `json:"en,omitempty"`
+	Number *int    `json:"number,omitempty"`
+}
+
+// PrayerLog defines model for PrayerLog.
+type PrayerLog struct {
+	Date   openapi_types.Date `json:"date"`
+	Logged time.Time          `json:"logged"`
+	Prayer PrayerLogPrayer    `json:"prayer"`
+	Status PrayerLogStatus    `json:"status"`
+	User   *string            `json:"user,omitempty"`
+}
+
+// PrayerLogPrayer defines model for PrayerLog.Prayer.
+type PrayerLogPrayer string
+
+// PrayerLogStatus defines model for PrayerLog.Status.
+type PrayerLogStatus string
+
+// Timings Times as HH:MM, sometimes followed by the zone, e.g. 04:31 (+03)
+type Timings struct {
+	Asr       string  `json:"Asr"`
+	Dhuhr     string  `json:"Dhuhr"`
+	Fajr      string  `json:"Fajr"`
+	Imsak     *string `json:"Imsak,omitempty"`
+	Isha      string  `json:"Isha"`
+	Lastthird *string `json:"Lastthird,omitempty"`
+	Maghrib   string  `json:"Maghrib"`
+	Midnight  *string `json:"Midnight,omitempty"`
+	Sunrise   string  `json:"Sunrise"`
+	Sunset    string  `json:"Sunset"`
+}
+
+// TimingsPayload defines model for TimingsPayload.
+type TimingsPayload struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Date    Date   `json:"date"`
+	Meta    Meta   `json:"meta"`
+
+	// Timings Times as HH:MM, sometimes followed by the zone, e.g. 04:31 (+03)
+	Timings Timings `json:"timings"`
+}
+
+// TrackRequest defines model for TrackRequest.
+type TrackRequest struct {
+	// Date Default today
+	Date *openapi_types.Date `json:"date,omitempty"`
+
+	// Prayer Fajr, Dhuhr, Asr, Maghrib, or Isha, in any case
+	Prayer string              `json:"prayer"`
+	Status *TrackRequestStatus `json:"status,omitempty"`
+
+	// User Household member; default the server's own user
+	User *string `json:"user,omitempty"`
+}
+
+// TrackRequestStatus defines model for TrackRequest.Status.
+type TrackRequestStatus string
+
+// VoicePayload defines model for VoicePayload.
+type VoicePayload struct {
+	City string `json:"city"`
+
+	// Minutes Minutes until the prayer
+	Minutes int    `json:"minutes"`
+	Prayer  string `json:"prayer"`
+	Speech  string `json:"speech"`
+
+	// Time HH:MM in the city's time
+	Time string `json:"time"`
+}
+
+// Weekday defines model for Weekday.
+type Weekday struct {
+	Ar *string `json:"ar,omitempty"`
+	En *string `json:"en,omitempty"`
+}
+
+// CityParam defines model for CityParam.
+type CityParam = string
+
+// CountryParam defines model for CountryParam.
+type CountryParam = string
+
+// FormatParam defines model for FormatParam.
+type FormatParam string
+
+// MethodParam defines model for MethodParam.
+type MethodParam = int
+
+// BadGateway defines model for BadGateway.
+type BadGateway = Error
+
+// BadRequest defines model for BadRequest.
+type BadRequest = Error
+
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
+// InternalError defines model for InternalError.
+type InternalError = Error
+
+// Unauthorized defines model for Unauthorized.
+type Unauthorized = Error
+
+// GetCalendarParams defines parameters for GetCalendar.
+type GetCalendarParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+
+	// Year Gregorian year; default this year
+	Year *int `form:"year,omitempty" json:"year,omitempty"`
+
+	// Month Month, 1 to 12; default this month
+	Month *int `form:"month,omitempty" json:"month,omitempty"`
+}
+
+// LogPrayerParams defines parameters for LogPrayer.
+type LogPrayerParams struct {
+	// User Household member; default the server's own user
+	User   *string                `form:"user,omitempty" json:"user,omitempty"`
+	Prayer LogPrayerParamsPrayer  `form:"prayer" json:"prayer"`
+	Status *LogPrayerParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Date YYYY-MM-DD; default today
+	Date *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// LogPrayerParamsPrayer defines parameters for LogPrayer.
+type LogPrayerParamsPrayer string
+
+// LogPrayerParamsStatus defines parameters for LogPrayer.
+type LogPrayerParamsStatus string
+
+// GetTimingsParams defines parameters for GetTimings.
+type GetTimingsParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+}
+
+// GetVoiceParams defines parameters for GetVoice.
+type GetVoiceParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+
+	// Format Answer as an Alexa skill or Dialogflow webhook response
+	Format *GetVoiceParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// GetVoiceParamsFormat defines parameters for GetVoice.
+type GetVoiceParamsFormat string
+
+// PostVoiceParams defines parameters for PostVoice.
+type PostVoiceParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+
+	// Format Answer as an Alexa skill or Dialogflow webhook response
+	Format *PostVoiceParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+}
+
+// PostVoiceParamsFormat defines parameters for PostVoice.
+type PostVoiceParamsFormat string
+
+// GetCalendarFeedParams defines parameters for GetCalendarFeed.
+type GetCalendarFeedParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+
+	// Days How many days, from today
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
+}
+
+// GetGlanceParams defines parameters for GetGlance.
+type GetGlanceParams struct {
+	// City City name; default the server's city
+	City *CityParam `form:"city,omitempty" json:"city,omitempty"`
+
+	// Country Country code; default the server's country
+	Country *CountryParam `form:"country,omitempty" json:"country,omitempty"`
+
+	// Method Calculation method; default the server's method
+	Method *MethodParam `form:"method,omitempty" json:"method,omitempty"`
+}
+
+// TrackPrayerJSONRequestBody defines body for TrackPrayer for application/json ContentType.
+type TrackPrayerJSONRequestBody = TrackRequest
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// GetCalendar request
+	GetCalendar(ctx context.Context, params *GetCalendarParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHousehold request
+	GetHousehold(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// LogPrayer request
+	LogPrayer(ctx context.Context, params *LogPrayerParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTimings request
+	GetTimings(ctx context.Context, params *GetTimingsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetVoice request
+	GetVoice(ctx context.Context, params *GetVoiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostVoice request
+	PostVoice(ctx context.Context, params *PostVoiceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCalendarFeed request
+	GetCalendarFeed(ctx context.Context, params *GetCalendarFeedParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetHealth request
+	GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetGlance request
+	GetGlance(ctx context.Context, params *GetGlanceParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// TrackPrayerWithBody request with any body
+	TrackPrayerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	TrackPrayer(ctx context.Context, body TrackPrayerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) GetCalendar(ctx context.Context, params *GetCalendarParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCalendarRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHousehold(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHouseholdRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LogPrayer(ctx context.Context, params *LogPrayerParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogPrayerRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTimings(ctx context.Context, params *GetTimingsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTimingsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetVoice(ctx context.Context, params *GetVoiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetVoiceRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostVoice(ctx context.Context, params *PostVoiceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostVoiceRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCalendarFeed(ctx context.Context, params *GetCalendarFeedParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCalendarFeedRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetHealth(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetHealthRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetGlance(ctx context.Context, params *GetGlanceParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetGlanceRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TrackPrayerWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTrackPrayerRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) TrackPrayer(ctx context.Context, body TrackPrayerJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewTrackPrayerRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewGetCalendarRequest generates requests for GetCalendar
+func NewGetCalendarRequest(server string, params *GetCalendarParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/calendar")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Year != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "year", runtime.ParamLocationQuery, *params.Year); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Month != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "month", runtime.ParamLocationQuery, *params.Month); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHouseholdRequest generates requests for GetHousehold
+func NewGetHouseholdRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/household")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLogPrayerRequest generates requests for LogPrayer
+func NewLogPrayerRequest(server string, params *LogPrayerParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/log")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.User != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "user", runtime.ParamLocationQuery, *params.User); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "prayer", runtime.ParamLocationQuery, params.Prayer); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Date != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "date", runtime.ParamLocationQuery, *params.Date); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetTimingsRequest generates requests for GetTimings
+func NewGetTimingsRequest(server string, params *GetTimingsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/timings")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetVoiceRequest generates requests for GetVoice
+func NewGetVoiceRequest(server string, params *GetVoiceParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/voice")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostVoiceRequest generates requests for PostVoice
+func NewPostVoiceRequest(server string, params *PostVoiceParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/voice")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Format != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "format", runtime.ParamLocationQuery, *params.Format); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCalendarFeedRequest generates requests for GetCalendarFeed
+func NewGetCalendarFeedRequest(server string, params *GetCalendarFeedParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/calendar.ics")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Days != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "days", runtime.ParamLocationQuery, *params.Days); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetHealthRequest generates requests for GetHealth
+func NewGetHealthRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/healthz")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetGlanceRequest generates requests for GetGlance
+func NewGetGlanceRequest(server string, params *GetGlanceParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/glance")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.City != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "city", runtime.ParamLocationQuery, *params.City); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Country != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "country", runtime.ParamLocationQuery, *params.Country); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Method != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "method", runtime.ParamLocationQuery, *params.Method); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewTrackPrayerRequest calls the generic TrackPrayer builder with application/json body
+func NewTrackPrayerRequest(server string, body TrackPrayerJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewTrackPrayerRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewTrackPrayerRequestWithBody generates requests for TrackPrayer with any type of body
+func NewTrackPrayerRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/track")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// GetCalendarWithResponse request
+	GetCalendarWithResponse(ctx context.Context, params *GetCalendarParams, reqEditors ...RequestEditorFn) (*GetCalendarResponse, error)
+
+	// GetHouseholdWithResponse request
+	GetHouseholdWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHouseholdResponse, error)
+
+	// LogPrayerWithResponse request
+	LogPrayerWithResponse(ctx context.Context, params *LogPrayerParams, reqEditors ...RequestEditorFn) (*LogPrayerResponse, error)
+
+	// GetTimingsWithResponse request
+	GetTimingsWithResponse(ctx context.Context, params *GetTimingsParams, reqEditors ...RequestEditorFn) (*GetTimingsResponse, error)
+
+	// GetVoiceWithResponse request
+	GetVoiceWithResponse(ctx context.Context, params *GetVoiceParams, reqEditors ...RequestEditorFn) (*GetVoiceResponse, error)
+
+	// PostVoiceWithResponse request
+	PostVoiceWithResponse(ctx context.Context, params *PostVoiceParams, reqEditors ...RequestEditorFn) (*PostVoiceResponse, error)
+
+	// GetCalendarFeedWithResponse request
+	GetCalendarFeedWithResponse(ctx context.Context, params *GetCalendarFeedParams, reqEditors ...RequestEditorFn) (*GetCalendarFeedResponse, error)
+
+	// GetHealthWithResponse request
+	GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error)
+
+	// GetGlanceWithResponse request
+	GetGlanceWithResponse(ctx context.Context, params *GetGlanceParams, reqEditors ...RequestEditorFn) (*GetGlanceResponse, error)
+
+	// TrackPrayerWithBodyWithResponse request with any body
+	TrackPrayerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TrackPrayerResponse, error)
+
+	TrackPrayerWithResponse(ctx context.Context, body TrackPrayerJSONRequestBody, reqEditors ...RequestEditorFn) (*TrackPrayerResponse, error)
+}
+
+type GetCalendarResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CalendarPayload
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCalendarResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCalendarResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHouseholdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]HouseholdMember
+	JSON401      *Unauthorized
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHouseholdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHouseholdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LogPrayerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r LogPrayerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogPrayerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTimingsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TimingsPayload
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTimingsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTimingsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetVoiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VoicePayload
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r GetVoiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetVoiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostVoiceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VoicePayload
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r PostVoiceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostVoiceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCalendarFeedResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCalendarFeedResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCalendarFeedResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetHealthResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Health
+}
+
+// Status returns HTTPResponse.Status
+func (r GetHealthResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetHealthResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetGlanceResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *BadRequest
+	JSON502      *BadGateway
+}
+
+// Status returns HTTPResponse.Status
+func (r GetGlanceResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetGlanceResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type TrackPrayerResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *PrayerLog
+	JSON400      *BadRequest
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON500      *InternalError
+}
+
+// Status returns HTTPResponse.Status
+func (r TrackPrayerResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r TrackPrayerResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// GetCalendarWithResponse request returning *GetCalendarResponse
+func (c *ClientWithResponses) GetCalendarWithResponse(ctx context.Context, params *GetCalendarParams, reqEditors ...RequestEditorFn) (*GetCalendarResponse, error) {
+	rsp, err := c.GetCalendar(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCalendarResponse(rsp)
+}
+
+// GetHouseholdWithResponse request returning *GetHouseholdResponse
+func (c *ClientWithResponses) GetHouseholdWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHouseholdResponse, error) {
+	rsp, err := c.GetHousehold(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHouseholdResponse(rsp)
+}
+
+// LogPrayerWithResponse request returning *LogPrayerResponse
+func (c *ClientWithResponses) LogPrayerWithResponse(ctx context.Context, params *LogPrayerParams, reqEditors ...RequestEditorFn) (*LogPrayerResponse, error) {
+	rsp, err := c.LogPrayer(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogPrayerResponse(rsp)
+}
+
+// GetTimingsWithResponse request returning *GetTimingsResponse
+func (c *ClientWithResponses) GetTimingsWithResponse(ctx context.Context, params *GetTimingsParams, reqEditors ...RequestEditorFn) (*GetTimingsResponse, error) {
+	rsp, err := c.GetTimings(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTimingsResponse(rsp)
+}
+
+// GetVoiceWithResponse request returning *GetVoiceResponse
+func (c *ClientWithResponses) GetVoiceWithResponse(ctx context.Context, params *GetVoiceParams, reqEditors ...RequestEditorFn) (*GetVoiceResponse, error) {
+	rsp, err := c.GetVoice(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetVoiceResponse(rsp)
+}
+
+// PostVoiceWithResponse request returning *PostVoiceResponse
+func (c *ClientWithResponses) PostVoiceWithResponse(ctx context.Context, params *PostVoiceParams, reqEditors ...RequestEditorFn) (*PostVoiceResponse, error) {
+	rsp, err := c.PostVoice(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostVoiceResponse(rsp)
+}
+
+// GetCalendarFeedWithResponse request returning *GetCalendarFeedResponse
+func (c *ClientWithResponses) GetCalendarFeedWithResponse(ctx context.Context, params *GetCalendarFeedParams, reqEditors ...RequestEditorFn) (*GetCalendarFeedResponse, error) {
+	rsp, err := c.GetCalendarFeed(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCalendarFeedResponse(rsp)
+}
+
+// GetHealthWithResponse request returning *GetHealthResponse
+func (c *ClientWithResponses) GetHealthWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetHealthResponse, error) {
+	rsp, err := c.GetHealth(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetHealthResponse(rsp)
+}
+
+// GetGlanceWithResponse request returning *GetGlanceResponse
+func (c *ClientWithResponses) GetGlanceWithResponse(ctx context.Context, params *GetGlanceParams, reqEditors ...RequestEditorFn) (*GetGlanceResponse, error) {
+	rsp, err := c.GetGlance(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetGlanceResponse(rsp)
+}
+
+// TrackPrayerWithBodyWithResponse request with arbitrary body returning *TrackPrayerResponse
+func (c *ClientWithResponses) TrackPrayerWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*TrackPrayerResponse, error) {
+	rsp, err := c.TrackPrayerWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrackPrayerResponse(rsp)
+}
+
+func (c *ClientWithResponses) TrackPrayerWithResponse(ctx context.Context, body TrackPrayerJSONRequestBody, reqEditors ...RequestEditorFn) (*TrackPrayerResponse, error) {
+	rsp, err := c.TrackPrayer(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTrackPrayerResponse(rsp)
+}
+
+// ParseGetCalendarResponse parses an HTTP response from a GetCalendarWithResponse call
+func ParseGetCalendarResponse(rsp *http.Response) (*GetCalendarResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCalendarResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CalendarPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHouseholdResponse parses an HTTP response from a GetHouseholdWithResponse call
+func ParseGetHouseholdResponse(rsp *http.Response) (*GetHouseholdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHouseholdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []HouseholdMember
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLogPrayerResponse parses an HTTP response from a LogPrayerWithResponse call
+func ParseLogPrayerResponse(rsp *http.Response) (*LogPrayerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogPrayerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTimingsResponse parses an HTTP response from a GetTimingsWithResponse call
+func ParseGetTimingsResponse(rsp *http.Response) (*GetTimingsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTimingsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TimingsPayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetVoiceResponse parses an HTTP response from a GetVoiceWithResponse call
+func ParseGetVoiceResponse(rsp *http.Response) (*GetVoiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetVoiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VoicePayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostVoiceResponse parses an HTTP response from a PostVoiceWithResponse call
+func ParsePostVoiceResponse(rsp *http.Response) (*PostVoiceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostVoiceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VoicePayload
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCalendarFeedResponse parses an HTTP response from a GetCalendarFeedWithResponse call
+func ParseGetCalendarFeedResponse(rsp *http.Response) (*GetCalendarFeedResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCalendarFeedResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetHealthResponse parses an HTTP response from a GetHealthWithResponse call
+func ParseGetHealthResponse(rsp *http.Response) (*GetHealthResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetHealthResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Health
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetGlanceResponse parses an HTTP response from a GetGlanceWithResponse call
+func ParseGetGlanceResponse(rsp *http.Response) (*GetGlanceResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetGlanceResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 502:
+		var dest BadGateway
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON502 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseTrackPrayerResponse parses an HTTP response from a TrackPrayerWithResponse call
+func ParseTrackPrayerResponse(rsp *http.Response) (*TrackPrayerResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &TrackPrayerResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest PrayerLog
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest BadRequest
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest InternalError
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
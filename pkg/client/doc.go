@@ -0,0 +1,18 @@
+// Package client is a typed Go client for the REST API of pray serve, generated from the
+// OpenAPI specification the server publishes at /openapi.json.
+//
+//	c, err := client.NewClientWithResponses("http://localhost:8080")
+//	if err != nil {
+//		return err
+//	}
+//	city := "Cairo"
+//	resp, err := c.GetTimingsWithResponse(ctx, &client.GetTimingsParams{City: &city})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(resp.JSON200.Timings.Fajr)
+//
+// Regenerate it with go generate after changing web/openapi.json.
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.5.1 -config oapi-codegen.yaml ../../web/openapi.json
@@ -0,0 +1,13 @@
+// Package praypb holds the protobuf messages and gRPC client for the Prayer service of
+// pray serve --grpc-addr, generated from pray.proto.
+//
+//	conn, err := grpc.NewClient("localhost:9090", grpc.WithTransportCredentials(insecure.NewCredentials()))
+//	if err != nil {
+//		return err
+//	}
+//	next, err := praypb.NewPrayerClient(conn).NextPrayer(ctx, &praypb.NextPrayerRequest{
+//		Location: &praypb.Location{City: "Cairo", Country: "EG"},
+//	})
+package praypb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative pray.proto
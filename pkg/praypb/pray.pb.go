@@ -0,0 +1,946 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: pray.proto
+
+package praypb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Location struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Method        int32                  `protobuf:"varint,3,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_pray_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetMethod() int32 {
+	if x != nil {
+		return x.Method
+	}
+	return 0
+}
+
+type TimingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimingsRequest) Reset() {
+	*x = TimingsRequest{}
+	mi := &file_pray_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimingsRequest) ProtoMessage() {}
+
+func (x *TimingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimingsRequest.ProtoReflect.Descriptor instead.
+func (*TimingsRequest) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TimingsRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type Timings struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Fajr          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=fajr,proto3" json:"fajr,omitempty"`
+	Sunrise       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=sunrise,proto3" json:"sunrise,omitempty"`
+	Dhuhr         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=dhuhr,proto3" json:"dhuhr,omitempty"`
+	Asr           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=asr,proto3" json:"asr,omitempty"`
+	Sunset        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sunset,proto3" json:"sunset,omitempty"`
+	Maghrib       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=maghrib,proto3" json:"maghrib,omitempty"`
+	Isha          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=isha,proto3" json:"isha,omitempty"`
+	Imsak         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=imsak,proto3" json:"imsak,omitempty"`
+	Midnight      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=midnight,proto3" json:"midnight,omitempty"`
+	LastThird     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_third,json=lastThird,proto3" json:"last_third,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Timings) Reset() {
+	*x = Timings{}
+	mi := &file_pray_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Timings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Timings) ProtoMessage() {}
+
+func (x *Timings) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Timings.ProtoReflect.Descriptor instead.
+func (*Timings) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Timings) GetFajr() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Fajr
+	}
+	return nil
+}
+
+func (x *Timings) GetSunrise() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunrise
+	}
+	return nil
+}
+
+func (x *Timings) GetDhuhr() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Dhuhr
+	}
+	return nil
+}
+
+func (x *Timings) GetAsr() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Asr
+	}
+	return nil
+}
+
+func (x *Timings) GetSunset() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Sunset
+	}
+	return nil
+}
+
+func (x *Timings) GetMaghrib() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Maghrib
+	}
+	return nil
+}
+
+func (x *Timings) GetIsha() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Isha
+	}
+	return nil
+}
+
+func (x *Timings) GetImsak() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Imsak
+	}
+	return nil
+}
+
+func (x *Timings) GetMidnight() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Midnight
+	}
+	return nil
+}
+
+func (x *Timings) GetLastThird() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastThird
+	}
+	return nil
+}
+
+type HijriDate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Year          int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"`
+	Day           int32                  `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	MonthName     string                 `protobuf:"bytes,4,opt,name=month_name,json=monthName,proto3" json:"month_name,omitempty"`
+	MonthNameAr   string                 `protobuf:"bytes,5,opt,name=month_name_ar,json=monthNameAr,proto3" json:"month_name_ar,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HijriDate) Reset() {
+	*x = HijriDate{}
+	mi := &file_pray_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HijriDate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HijriDate) ProtoMessage() {}
+
+func (x *HijriDate) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HijriDate.ProtoReflect.Descriptor instead.
+func (*HijriDate) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *HijriDate) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *HijriDate) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *HijriDate) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+func (x *HijriDate) GetMonthName() string {
+	if x != nil {
+		return x.MonthName
+	}
+	return ""
+}
+
+func (x *HijriDate) GetMonthNameAr() string {
+	if x != nil {
+		return x.MonthNameAr
+	}
+	return ""
+}
+
+type Day struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Country       string                 `protobuf:"bytes,2,opt,name=country,proto3" json:"country,omitempty"`
+	Date          string                 `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	Hijri         *HijriDate             `protobuf:"bytes,4,opt,name=hijri,proto3" json:"hijri,omitempty"`
+	Timezone      string                 `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	Latitude      float64                `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Method        int32                  `protobuf:"varint,8,opt,name=method,proto3" json:"method,omitempty"`
+	MethodName    string                 `protobuf:"bytes,9,opt,name=method_name,json=methodName,proto3" json:"method_name,omitempty"`
+	Timings       *Timings               `protobuf:"bytes,10,opt,name=timings,proto3" json:"timings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Day) Reset() {
+	*x = Day{}
+	mi := &file_pray_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Day) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Day) ProtoMessage() {}
+
+func (x *Day) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Day.ProtoReflect.Descriptor instead.
+func (*Day) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Day) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Day) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Day) GetDate() string {
+	if x != nil {
+		return x.Date
+	}
+	return ""
+}
+
+func (x *Day) GetHijri() *HijriDate {
+	if x != nil {
+		return x.Hijri
+	}
+	return nil
+}
+
+func (x *Day) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *Day) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Day) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Day) GetMethod() int32 {
+	if x != nil {
+		return x.Method
+	}
+	return 0
+}
+
+func (x *Day) GetMethodName() string {
+	if x != nil {
+		return x.MethodName
+	}
+	return ""
+}
+
+func (x *Day) GetTimings() *Timings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+type NextPrayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextPrayerRequest) Reset() {
+	*x = NextPrayerRequest{}
+	mi := &file_pray_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextPrayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextPrayerRequest) ProtoMessage() {}
+
+func (x *NextPrayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextPrayerRequest.ProtoReflect.Descriptor instead.
+func (*NextPrayerRequest) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *NextPrayerRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+type NextPrayerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	City          string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Prayer        string                 `protobuf:"bytes,2,opt,name=prayer,proto3" json:"prayer,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=at,proto3" json:"at,omitempty"`
+	Until         *durationpb.Duration   `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NextPrayerResponse) Reset() {
+	*x = NextPrayerResponse{}
+	mi := &file_pray_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NextPrayerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextPrayerResponse) ProtoMessage() {}
+
+func (x *NextPrayerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextPrayerResponse.ProtoReflect.Descriptor instead.
+func (*NextPrayerResponse) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *NextPrayerResponse) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *NextPrayerResponse) GetPrayer() string {
+	if x != nil {
+		return x.Prayer
+	}
+	return ""
+}
+
+func (x *NextPrayerResponse) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *NextPrayerResponse) GetUntil() *durationpb.Duration {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type CalendarRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Year          int32                  `protobuf:"varint,2,opt,name=year,proto3" json:"year,omitempty"`
+	Month         int32                  `protobuf:"varint,3,opt,name=month,proto3" json:"month,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarRequest) Reset() {
+	*x = CalendarRequest{}
+	mi := &file_pray_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarRequest) ProtoMessage() {}
+
+func (x *CalendarRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarRequest.ProtoReflect.Descriptor instead.
+func (*CalendarRequest) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CalendarRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *CalendarRequest) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *CalendarRequest) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+type CalendarResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Days          []*Day                 `protobuf:"bytes,1,rep,name=days,proto3" json:"days,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CalendarResponse) Reset() {
+	*x = CalendarResponse{}
+	mi := &file_pray_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CalendarResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CalendarResponse) ProtoMessage() {}
+
+func (x *CalendarResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CalendarResponse.ProtoReflect.Descriptor instead.
+func (*CalendarResponse) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CalendarResponse) GetDays() []*Day {
+	if x != nil {
+		return x.Days
+	}
+	return nil
+}
+
+type QiblaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Location      *Location              `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	Latitude      *float64               `protobuf:"fixed64,2,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude     *float64               `protobuf:"fixed64,3,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QiblaRequest) Reset() {
+	*x = QiblaRequest{}
+	mi := &file_pray_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QiblaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QiblaRequest) ProtoMessage() {}
+
+func (x *QiblaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QiblaRequest.ProtoReflect.Descriptor instead.
+func (*QiblaRequest) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *QiblaRequest) GetLocation() *Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *QiblaRequest) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *QiblaRequest) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+type QiblaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Latitude      float64                `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude     float64                `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Direction     float64                `protobuf:"fixed64,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	DistanceKm    float64                `protobuf:"fixed64,4,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QiblaResponse) Reset() {
+	*x = QiblaResponse{}
+	mi := &file_pray_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QiblaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QiblaResponse) ProtoMessage() {}
+
+func (x *QiblaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pray_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QiblaResponse.ProtoReflect.Descriptor instead.
+func (*QiblaResponse) Descriptor() ([]byte, []int) {
+	return file_pray_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *QiblaResponse) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *QiblaResponse) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *QiblaResponse) GetDirection() float64 {
+	if x != nil {
+		return x.Direction
+	}
+	return 0
+}
+
+func (x *QiblaResponse) GetDistanceKm() float64 {
+	if x != nil {
+		return x.DistanceKm
+	}
+	return 0
+}
+
+var File_pray_proto protoreflect.FileDescriptor
+
+const file_pray_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"pray.proto\x12\apray.v1\x1a\x1egoogle/protobuf/duration.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"P\n" +
+	"\bLocation\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\x05R\x06method\"?\n" +
+	"\x0eTimingsRequest\x12-\n" +
+	"\blocation\x18\x01 \x01(\v2\x11.pray.v1.LocationR\blocation\"\x8e\x04\n" +
+	"\aTimings\x12.\n" +
+	"\x04fajr\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04fajr\x124\n" +
+	"\asunrise\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\asunrise\x120\n" +
+	"\x05dhuhr\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05dhuhr\x12,\n" +
+	"\x03asr\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x03asr\x122\n" +
+	"\x06sunset\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06sunset\x124\n" +
+	"\amaghrib\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\amaghrib\x12.\n" +
+	"\x04isha\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x04isha\x120\n" +
+	"\x05imsak\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x05imsak\x126\n" +
+	"\bmidnight\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bmidnight\x129\n" +
+	"\n" +
+	"last_third\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tlastThird\"\x8a\x01\n" +
+	"\tHijriDate\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\x12\x1d\n" +
+	"\n" +
+	"month_name\x18\x04 \x01(\tR\tmonthName\x12\"\n" +
+	"\rmonth_name_ar\x18\x05 \x01(\tR\vmonthNameAr\"\xac\x02\n" +
+	"\x03Day\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x18\n" +
+	"\acountry\x18\x02 \x01(\tR\acountry\x12\x12\n" +
+	"\x04date\x18\x03 \x01(\tR\x04date\x12(\n" +
+	"\x05hijri\x18\x04 \x01(\v2\x12.pray.v1.HijriDateR\x05hijri\x12\x1a\n" +
+	"\btimezone\x18\x05 \x01(\tR\btimezone\x12\x1a\n" +
+	"\blatitude\x18\x06 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\a \x01(\x01R\tlongitude\x12\x16\n" +
+	"\x06method\x18\b \x01(\x05R\x06method\x12\x1f\n" +
+	"\vmethod_name\x18\t \x01(\tR\n" +
+	"methodName\x12*\n" +
+	"\atimings\x18\n" +
+	" \x01(\v2\x10.pray.v1.TimingsR\atimings\"B\n" +
+	"\x11NextPrayerRequest\x12-\n" +
+	"\blocation\x18\x01 \x01(\v2\x11.pray.v1.LocationR\blocation\"\x9d\x01\n" +
+	"\x12NextPrayerResponse\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x16\n" +
+	"\x06prayer\x18\x02 \x01(\tR\x06prayer\x12*\n" +
+	"\x02at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12/\n" +
+	"\x05until\x18\x04 \x01(\v2\x19.google.protobuf.DurationR\x05until\"j\n" +
+	"\x0fCalendarRequest\x12-\n" +
+	"\blocation\x18\x01 \x01(\v2\x11.pray.v1.LocationR\blocation\x12\x12\n" +
+	"\x04year\x18\x02 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x03 \x01(\x05R\x05month\"4\n" +
+	"\x10CalendarResponse\x12 \n" +
+	"\x04days\x18\x01 \x03(\v2\f.pray.v1.DayR\x04days\"\x9c\x01\n" +
+	"\fQiblaRequest\x12-\n" +
+	"\blocation\x18\x01 \x01(\v2\x11.pray.v1.LocationR\blocation\x12\x1f\n" +
+	"\blatitude\x18\x02 \x01(\x01H\x00R\blatitude\x88\x01\x01\x12!\n" +
+	"\tlongitude\x18\x03 \x01(\x01H\x01R\tlongitude\x88\x01\x01B\v\n" +
+	"\t_latitudeB\f\n" +
+	"\n" +
+	"_longitude\"\x88\x01\n" +
+	"\rQiblaResponse\x12\x1a\n" +
+	"\blatitude\x18\x01 \x01(\x01R\blatitude\x12\x1c\n" +
+	"\tlongitude\x18\x02 \x01(\x01R\tlongitude\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\x01R\tdirection\x12\x1f\n" +
+	"\vdistance_km\x18\x04 \x01(\x01R\n" +
+	"distanceKm2\xfa\x01\n" +
+	"\x06Prayer\x120\n" +
+	"\aTimings\x12\x17.pray.v1.TimingsRequest\x1a\f.pray.v1.Day\x12E\n" +
+	"\n" +
+	"NextPrayer\x12\x1a.pray.v1.NextPrayerRequest\x1a\x1b.pray.v1.NextPrayerResponse\x12?\n" +
+	"\bCalendar\x12\x18.pray.v1.CalendarRequest\x1a\x19.pray.v1.CalendarResponse\x126\n" +
+	"\x05Qibla\x12\x15.pray.v1.QiblaRequest\x1a\x16.pray.v1.QiblaResponseB#Z!github.com/isIbra/pray/pkg/praypbb\x06proto3"
+
+var (
+	file_pray_proto_rawDescOnce sync.Once
+	file_pray_proto_rawDescData []byte
+)
+
+func file_pray_proto_rawDescGZIP() []byte {
+	file_pray_proto_rawDescOnce.Do(func() {
+		file_pray_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pray_proto_rawDesc), len(file_pray_proto_rawDesc)))
+	})
+	return file_pray_proto_rawDescData
+}
+
+var file_pray_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_pray_proto_goTypes = []any{
+	(*Location)(nil),              // 0: pray.v1.Location
+	(*TimingsRequest)(nil),        // 1: pray.v1.TimingsRequest
+	(*Timings)(nil),               // 2: pray.v1.Timings
+	(*HijriDate)(nil),             // 3: pray.v1.HijriDate
+	(*Day)(nil),                   // 4: pray.v1.Day
+	(*NextPrayerRequest)(nil),     // 5: pray.v1.NextPrayerRequest
+	(*NextPrayerResponse)(nil),    // 6: pray.v1.NextPrayerResponse
+	(*CalendarRequest)(nil),       // 7: pray.v1.CalendarRequest
+	(*CalendarResponse)(nil),      // 8: pray.v1.CalendarResponse
+	(*QiblaRequest)(nil),          // 9: pray.v1.QiblaRequest
+	(*QiblaResponse)(nil),         // 10: pray.v1.QiblaResponse
+	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),   // 12: google.protobuf.Duration
+}
+var file_pray_proto_depIdxs = []int32{
+	0,  // 0: pray.v1.TimingsRequest.location:type_name -> pray.v1.Location
+	11, // 1: pray.v1.Timings.fajr:type_name -> google.protobuf.Timestamp
+	11, // 2: pray.v1.Timings.sunrise:type_name -> google.protobuf.Timestamp
+	11, // 3: pray.v1.Timings.dhuhr:type_name -> google.protobuf.Timestamp
+	11, // 4: pray.v1.Timings.asr:type_name -> google.protobuf.Timestamp
+	11, // 5: pray.v1.Timings.sunset:type_name -> google.protobuf.Timestamp
+	11, // 6: pray.v1.Timings.maghrib:type_name -> google.protobuf.Timestamp
+	11, // 7: pray.v1.Timings.isha:type_name -> google.protobuf.Timestamp
+	11, // 8: pray.v1.Timings.imsak:type_name -> google.protobuf.Timestamp
+	11, // 9: pray.v1.Timings.midnight:type_name -> google.protobuf.Timestamp
+	11, // 10: pray.v1.Timings.last_third:type_name -> google.protobuf.Timestamp
+	3,  // 11: pray.v1.Day.hijri:type_name -> pray.v1.HijriDate
+	2,  // 12: pray.v1.Day.timings:type_name -> pray.v1.Timings
+	0,  // 13: pray.v1.NextPrayerRequest.location:type_name -> pray.v1.Location
+	11, // 14: pray.v1.NextPrayerResponse.at:type_name -> google.protobuf.Timestamp
+	12, // 15: pray.v1.NextPrayerResponse.until:type_name -> google.protobuf.Duration
+	0,  // 16: pray.v1.CalendarRequest.location:type_name -> pray.v1.Location
+	4,  // 17: pray.v1.CalendarResponse.days:type_name -> pray.v1.Day
+	0,  // 18: pray.v1.QiblaRequest.location:type_name -> pray.v1.Location
+	1,  // 19: pray.v1.Prayer.Timings:input_type -> pray.v1.TimingsRequest
+	5,  // 20: pray.v1.Prayer.NextPrayer:input_type -> pray.v1.NextPrayerRequest
+	7,  // 21: pray.v1.Prayer.Calendar:input_type -> pray.v1.CalendarRequest
+	9,  // 22: pray.v1.Prayer.Qibla:input_type -> pray.v1.QiblaRequest
+	4,  // 23: pray.v1.Prayer.Timings:output_type -> pray.v1.Day
+	6,  // 24: pray.v1.Prayer.NextPrayer:output_type -> pray.v1.NextPrayerResponse
+	8,  // 25: pray.v1.Prayer.Calendar:output_type -> pray.v1.CalendarResponse
+	10, // 26: pray.v1.Prayer.Qibla:output_type -> pray.v1.QiblaResponse
+	23, // [23:27] is the sub-list for method output_type
+	19, // [19:23] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
+}
+
+func init() { file_pray_proto_init() }
+func file_pray_proto_init() {
+	if File_pray_proto != nil {
+		return
+	}
+	file_pray_proto_msgTypes[9].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pray_proto_rawDesc), len(file_pray_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_pray_proto_goTypes,
+		DependencyIndexes: file_pray_proto_depIdxs,
+		MessageInfos:      file_pray_proto_msgTypes,
+	}.Build()
+	File_pray_proto = out.File
+	file_pray_proto_goTypes = nil
+	file_pray_proto_depIdxs = nil
+}
@@ -0,0 +1,235 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: pray.proto
+
+package praypb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Prayer_Timings_FullMethodName    = "/pray.v1.Prayer/Timings"
+	Prayer_NextPrayer_FullMethodName = "/pray.v1.Prayer/NextPrayer"
+	Prayer_Calendar_FullMethodName   = "/pray.v1.Prayer/Calendar"
+	Prayer_Qibla_FullMethodName      = "/pray.v1.Prayer/Qibla"
+)
+
+// PrayerClient is the client API for Prayer service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PrayerClient interface {
+	Timings(ctx context.Context, in *TimingsRequest, opts ...grpc.CallOption) (*Day, error)
+	NextPrayer(ctx context.Context, in *NextPrayerRequest, opts ...grpc.CallOption) (*NextPrayerResponse, error)
+	Calendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error)
+	Qibla(ctx context.Context, in *QiblaRequest, opts ...grpc.CallOption) (*QiblaResponse, error)
+}
+
+type prayerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPrayerClient(cc grpc.ClientConnInterface) PrayerClient {
+	return &prayerClient{cc}
+}
+
+func (c *prayerClient) Timings(ctx context.Context, in *TimingsRequest, opts ...grpc.CallOption) (*Day, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Day)
+	err := c.cc.Invoke(ctx, Prayer_Timings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prayerClient) NextPrayer(ctx context.Context, in *NextPrayerRequest, opts ...grpc.CallOption) (*NextPrayerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NextPrayerResponse)
+	err := c.cc.Invoke(ctx, Prayer_NextPrayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prayerClient) Calendar(ctx context.Context, in *CalendarRequest, opts ...grpc.CallOption) (*CalendarResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalendarResponse)
+	err := c.cc.Invoke(ctx, Prayer_Calendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *prayerClient) Qibla(ctx context.Context, in *QiblaRequest, opts ...grpc.CallOption) (*QiblaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QiblaResponse)
+	err := c.cc.Invoke(ctx, Prayer_Qibla_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PrayerServer is the server API for Prayer service.
+// All implementations must embed UnimplementedPrayerServer
+// for forward compatibility.
+type PrayerServer interface {
+	Timings(context.Context, *TimingsRequest) (*Day, error)
+	NextPrayer(context.Context, *NextPrayerRequest) (*NextPrayerResponse, error)
+	Calendar(context.Context, *CalendarRequest) (*CalendarResponse, error)
+	Qibla(context.Context, *QiblaRequest) (*QiblaResponse, error)
+	mustEmbedUnimplementedPrayerServer()
+}
+
+// UnimplementedPrayerServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPrayerServer struct{}
+
+func (UnimplementedPrayerServer) Timings(context.Context, *TimingsRequest) (*Day, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Timings not implemented")
+}
+func (UnimplementedPrayerServer) NextPrayer(context.Context, *NextPrayerRequest) (*NextPrayerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NextPrayer not implemented")
+}
+func (UnimplementedPrayerServer) Calendar(context.Context, *CalendarRequest) (*CalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calendar not implemented")
+}
+func (UnimplementedPrayerServer) Qibla(context.Context, *QiblaRequest) (*QiblaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Qibla not implemented")
+}
+func (UnimplementedPrayerServer) mustEmbedUnimplementedPrayerServer() {}
+func (UnimplementedPrayerServer) testEmbeddedByValue()                {}
+
+// UnsafePrayerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PrayerServer will
+// result in compilation errors.
+type UnsafePrayerServer interface {
+	mustEmbedUnimplementedPrayerServer()
+}
+
+func RegisterPrayerServer(s grpc.ServiceRegistrar, srv PrayerServer) {
+	// If the following call pancis, it indicates UnimplementedPrayerServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Prayer_ServiceDesc, srv)
+}
+
+func _Prayer_Timings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrayerServer).Timings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prayer_Timings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrayerServer).Timings(ctx, req.(*TimingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prayer_NextPrayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NextPrayerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrayerServer).NextPrayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prayer_NextPrayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrayerServer).NextPrayer(ctx, req.(*NextPrayerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prayer_Calendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrayerServer).Calendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prayer_Calendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrayerServer).Calendar(ctx, req.(*CalendarRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Prayer_Qibla_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QiblaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PrayerServer).Qibla(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Prayer_Qibla_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PrayerServer).Qibla(ctx, req.(*QiblaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Prayer_ServiceDesc is the grpc.ServiceDesc for Prayer service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Prayer_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pray.v1.Prayer",
+	HandlerType: (*PrayerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Timings",
+			Handler:    _Prayer_Timings_Handler,
+		},
+		{
+			MethodName: "NextPrayer",
+			Handler:    _Prayer_NextPrayer_Handler,
+		},
+		{
+			MethodName: "Calendar",
+			Handler:    _Prayer_Calendar_Handler,
+		},
+		{
+			MethodName: "Qibla",
+			Handler:    _Prayer_Qibla_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pray.proto",
+}
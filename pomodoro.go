@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Shortest stretch of work worth its own cycle; less than this is added to the first one
+const focusMinWork = 5 * time.Minute
+
+// focusPhase is one stretch of work or break in a focus session
+type focusPhase struct {
+	Break bool
+	Start time.Time
+	End   time.Time
+}
+
+// planFocus fits work and break cycles between now and the prayer so that the last break
+// ends exactly at the prayer time. What doesn't make a whole cycle becomes a shorter first
+// cycle, or, when that would leave too little work, is added to the first work stretch.
+func planFocus(now, prayer time.Time, work, rest time.Duration) []focusPhase {
+	total := prayer.Sub(now)
+	if total <= rest {
+		return []focusPhase{{Break: true, Start: now, End: prayer}}
+	}
+
+	cycle := work + rest
+	n, extra := int(total/cycle), total%cycle
+	var works []time.Duration
+	switch {
+	case extra >= rest+focusMinWork:
+		works = append(works, extra-rest)
+	case n == 0:
+		return []focusPhase{{Break: true, Start: now, End: prayer}}
+	}
+	for i := 0; i < n; i++ {
+		works = append(works, work)
+	}
+	if extra < rest+focusMinWork {
+		works[0] += extra
+	}
+
+	var phases []focusPhase
+	at := now
+	for _, w := range works {
+		phases = append(phases, focusPhase{Start: at, End: at.Add(w)})
+		at = at.Add(w)
+		phases = append(phases, focusPhase{Break: true, Start: at, End: at.Add(rest)})
+		at = at.Add(rest)
+	}
+	return phases
+}
+
+// runFocus runs a focus session up to the next prayer, showing a live timer for each
+// stretch of work and break and ringing the terminal bell between them.
+func runFocus(ctx context.Context, work, rest time.Duration, city, country string, method int, cfg Config) error {
+	if work < focusMinWork || rest <= 0 {
+		return fmt.Errorf("--work must be at least %s and --break more than 0", formatDuration(focusMinWork))
+	}
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	prayer, at, err := findNextPrayer(data.Data.Timings)
+	if err != nil {
+		return err
+	}
+
+	phases := planFocus(clock.Now(), at, work, rest)
+	var cycles int
+	for _, p := range phases {
+		if !p.Break {
+			cycles++
+		}
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🍅 Focus until %s at %s", prayerNames[prayer], at.Format("15:04"))))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%d work stretches, the last break ending at the prayer", cycles)))
+	fmt.Println()
+
+	cycle := 0
+	for _, p := range phases {
+		label := "☕ Break"
+		if !p.Break {
+			cycle++
+			label = fmt.Sprintf("💻 Work %d/%d", cycle, cycles)
+		}
+		if !liveFocusPhase(ctx, label, p.End) {
+			fmt.Println(prayerStyle.Render("Focus session stopped"))
+			return nil
+		}
+		// The bell marks each change, and the finished stretch stays on screen
+		fmt.Print("\a")
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("%s  %s – %s", padRight(label, 14), p.Start.Format("15:04"), p.End.Format("15:04"))))
+	}
+
+	fmt.Println()
+	fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("🕌 %s at %s: time to pray", prayerNames[prayer], at.Format("15:04"))))
+	return nil
+}
+
+// liveFocusPhase redraws the time left in a stretch every second until it ends, reporting
+// false if ctx is done first.
+func liveFocusPhase(ctx context.Context, label string, end time.Time) bool {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		left := end.Sub(clock.Now())
+		if left <= 0 {
+			fmt.Print("\r\x1b[K")
+			return true
+		}
+		fmt.Print("\r\x1b[K" + countdownStyle.Render(fmt.Sprintf("%s  %s left", padRight(label, 14), formatDurationTo(left, time.Second))))
+
+		select {
+		case <-ctx.Done():
+			fmt.Print("\r\x1b[K")
+			return false
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// A prompt file older than this was left by a daemon that stopped, and its countdown is wrong
+const promptFileMaxAge = 2 * time.Minute
+
+// showPrompt prints the glance line for a shell prompt. A prompt can't show errors, so
+// anything going wrong prints nothing. With asyncFile it only reads the file the daemon
+// keeps, so it never waits on the network or the cache.
+func showPrompt(ctx context.Context, city, country string, method int, cfg Config, asyncFile string) {
+	if asyncFile != "" {
+		path := expandHome(asyncFile)
+		info, err := os.Stat(path)
+		if err != nil || time.Since(info.ModTime()) > promptFileMaxAge {
+			return
+		}
+		if text, err := os.ReadFile(path); err == nil {
+			fmt.Println(strings.TrimSpace(string(text)))
+		}
+		return
+	}
+
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return
+	}
+	if text, err := glanceText(data.Data.Timings, clock.Now()); err == nil {
+		fmt.Println(text)
+	}
+}
+
+// promptFile is the daemon's side of pray prompt --async-file: the glance line, rewritten
+// at the start of every minute.
+type promptFile struct {
+	path    string
+	timings atomic.Pointer[Timings]
+}
+
+func newPromptFile(path string) *promptFile {
+	return &promptFile{path: expandHome(path)}
+}
+
+// update switches to a new day's timings, or a new location's.
+func (p *promptFile) update(timings Timings) {
+	p.timings.Store(&timings)
+	p.write(clock.Now())
+}
+
+// run rewrites the file every minute until ctx is done, and then removes it.
+func (p *promptFile) run(ctx context.Context) {
+	defer os.Remove(p.path)
+	for {
+		now := clock.Now()
+		if err := clock.Sleep(ctx, now.Truncate(time.Minute).Add(time.Minute).Sub(now)); err != nil {
+			return
+		}
+		p.write(clock.Now())
+	}
+}
+
+// write replaces the file atomically, so a prompt never reads half a line.
+func (p *promptFile) write(now time.Time) {
+	timings := p.timings.Load()
+	if timings == nil {
+		return
+	}
+	text, err := glanceText(*timings, now)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p.path), 0o755); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), ".prompt-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.WriteString(text + "\n")
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), p.path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Cities kept in the history
+const maxRecentCities = 10
+
+// RecentCity is a city given with --city, for --city - and pray recent
+type RecentCity struct {
+	City    string    `json:"city"`
+	Country string    `json:"country"`
+	Used    time.Time `json:"used"`
+}
+
+// recentPath is kept apart from the store, which may be encrypted and would then ask for
+// its passphrase on every --city.
+func recentPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recent.json"), nil
+}
+
+// loadRecent reads the history, most recent first.
+func loadRecent() ([]RecentCity, error) {
+	path, err := recentPath()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read city history: %v", err)
+	}
+	var recent []RecentCity
+	if err := json.Unmarshal(raw, &recent); err != nil {
+		return nil, fmt.Errorf("failed to parse city history %s: %v", path, err)
+	}
+	return recent, nil
+}
+
+func saveRecent(recent []RecentCity) error {
+	path, err := recentPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %v", err)
+	}
+	raw, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write city history: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write city history: %v", err)
+	}
+	return nil
+}
+
+// recordCity moves a city to the top of the history.
+func recordCity(city, country string, now time.Time) error {
+	recent, err := loadRecent()
+	if err != nil {
+		return err
+	}
+	updated := []RecentCity{{City: city, Country: country, Used: now}}
+	for _, r := range recent {
+		if strings.EqualFold(r.City, city) && strings.EqualFold(r.Country, country) {
+			continue
+		}
+		updated = append(updated, r)
+	}
+	if len(updated) > maxRecentCities {
+		updated = updated[:maxRecentCities]
+	}
+	return saveRecent(updated)
+}
+
+// previousCity resolves --city - like cd -: "-" is the city before the last one, so
+// running it again flips back, and "-2" goes a step further. With a single city in the
+// history, the configured city came before it.
+func previousCity(arg string, cfg Config) (RecentCity, error) {
+	steps := 1
+	if arg != "-" {
+		n, err := strconv.Atoi(strings.TrimPrefix(arg, "-"))
+		if err != nil || n < 1 {
+			return RecentCity{}, fmt.Errorf("invalid city %q (expected - or -N for the Nth previous city)", arg)
+		}
+		steps = n
+	}
+
+	recent, err := loadRecent()
+	if err != nil {
+		return RecentCity{}, err
+	}
+	if len(recent) == 1 && !strings.EqualFold(recent[0].City, cfg.City) {
+		recent = append(recent, RecentCity{City: cfg.City, Country: cfg.Country})
+	}
+	if steps >= len(recent) {
+		if len(recent) < 2 {
+			return RecentCity{}, fmt.Errorf("no previous city yet; pass one with --city first")
+		}
+		return RecentCity{}, fmt.Errorf("only %d previous cities (see pray recent)", len(recent)-1)
+	}
+	return recent[steps], nil
+}
+
+// useCityFlag resolves --city - and -N, and remembers the city given. A history that
+// can't be written doesn't stop the command. An airport code brings its country along. Without --city, a --country of its own
+// brings a city in that country.
+func useCityFlag(cmd *cobra.Command, city, country *string, cfg Config) error {
+	if !cmd.Flags().Changed("city") {
+		useCountryCity(cmd, city, *country, cfg)
+		return nil
+	}
+	if strings.HasPrefix(*city, "-") {
+		previous, err := previousCity(*city, cfg)
+		if err != nil {
+			return err
+		}
+		*city = previous.City
+		if !cmd.Flags().Changed("country") {
+			*country = previous.Country
+		}
+	}
+	if a, ok := lookupAirport(*city); ok {
+		if !cmd.Flags().Changed("country") {
+			*country = a.Country
+		}
+		fmt.Fprintf(os.Stderr, "✈️ %s is %s, %s\n", a.Code, a.Name, a.City)
+	}
+	recordCity(*city, *country, time.Now())
+	return nil
+}
+
+// useCountryCity stands in the country's capital for the configured city when --country
+// names another country, saying so on stderr so piped output stays clean. A country the
+// database doesn't know keeps the configured city, for the API to judge.
+func useCountryCity(cmd *cobra.Command, city *string, country string, cfg Config) {
+	if !cmd.Flags().Changed("country") || strings.EqualFold(country, cfg.Country) {
+		return
+	}
+	c, ok := countryCity(country)
+	if !ok {
+		return
+	}
+	*city = c.Name
+	if c.Capital {
+		fmt.Fprintf(os.Stderr, "📍 No --city given; using %s, the capital of %s\n", c.Name, strings.ToUpper(country))
+	} else {
+		fmt.Fprintf(os.Stderr, "📍 No --city given; using %s, the main city of %s\n", c.Name, strings.ToUpper(country))
+	}
+}
+
+func newRecentCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "recent",
+		Short: "List recently used cities",
+		Long:  "List the cities last given with --city, most recent first. Use one again with --city -N, or flip back to the previous one with --city -, like cd -.",
+		Run: func(cmd *cobra.Command, args []string) {
+			recent, err := loadRecent()
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Println(titleStyle.Render("🕌 Recent cities"))
+			fmt.Println(strings.Repeat("━", 50))
+			if len(recent) == 0 {
+				fmt.Println(prayerStyle.Render("None yet; cities given with --city show up here"))
+				return
+			}
+			for i, r := range recent {
+				flag := "--city -" + strconv.Itoa(i)
+				switch i {
+				case 0:
+					flag = "last used"
+				case 1:
+					flag = "--city -"
+				}
+				place := padRight(fmt.Sprintf("%s, %s", r.City, r.Country), 28)
+				fmt.Printf("%s %s %s\n", prayerStyle.Render(place), timeStyle.Render(padRight(r.Used.Local().Format("Mon 02 Jan 15:04"), 17)), cityStyle.Render(flag))
+			}
+		},
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Deadline for a single Redis command; past it the cache counts as a miss
+const redisTimeout = 2 * time.Second
+
+// After failing to connect, Redis is left alone this long rather than slowing every request
+const redisRetryAfter = 30 * time.Second
+
+// Prefix of the keys pray keeps in Redis
+const redisKeyPrefix = "pray:http:"
+
+// redisCache keeps responses in Redis, so several instances of pray serve behind a load
+// balancer share them and fetch each day's timings once between them. It speaks just
+// enough RESP for GET, SET, and EVAL over one connection, reconnecting after an error.
+type redisCache struct {
+	addr     string
+	tls      bool
+	username string
+	password string
+	db       int
+
+	mu        sync.Mutex
+	conn      net.Conn
+	reader    *bufio.Reader
+	downUntil time.Time
+}
+
+// newRedisCache reads a URL like redis://:password@host:6379/0; rediss:// uses TLS.
+func newRedisCache(rawURL string) (*redisCache, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid redis URL %q", rawURL)
+	}
+	c := &redisCache{addr: u.Host, tls: u.Scheme == "rediss"}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.username = u.User.Username()
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		if c.db, err = strconv.Atoi(db); err != nil || c.db < 0 {
+			return nil, fmt.Errorf("invalid redis database %q in %q", db, rawURL)
+		}
+	}
+	return c, nil
+}
+
+func (c *redisCache) load(url string) *cachedResponse {
+	reply, err := c.do("GET", redisKeyPrefix+cacheKey(url))
+	raw, ok := reply.([]byte)
+	if err != nil || !ok {
+		return nil
+	}
+	var r cachedResponse
+	if err := json.Unmarshal(raw, &r); err != nil || r.URL != url {
+		return nil
+	}
+	return &r
+}
+
+// store keeps a response as long as the disk cache would, for revalidating it later.
+func (c *redisCache) store(r *cachedResponse) {
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	ttl := strconv.FormatInt(httpCacheMaxAge.Milliseconds(), 10)
+	c.do("SET", redisKeyPrefix+cacheKey(r.URL), string(raw), "PX", ttl)
+}
+
+// redisUnlockScript deletes a lock only while it still holds its owner's token, so a lock
+// that expired and was taken by someone else is left to them
+const redisUnlockScript = `if redis.call("GET", KEYS[1]) == ARGV[1] then return redis.call("DEL", KEYS[1]) end return 0`
+
+// lock is a key set only if it's missing, expiring on its own if its holder dies. When
+// Redis can't be reached, lock doesn't wait.
+func (c *redisCache) lock(ctx context.Context, url string) (func(), error) {
+	key := redisKeyPrefix + lockKey(url)
+	ttl := strconv.FormatInt(staleFetchLock.Milliseconds(), 10)
+	token, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	usable := true
+	err = waitForLock(ctx, func() bool {
+		reply, err := c.do("SET", key, token, "NX", "PX", ttl)
+		if err != nil {
+			usable = false
+			return true
+		}
+		// A nil reply is someone else holding it
+		return reply != nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !usable {
+		return func() {}, nil
+	}
+	return func() { c.do("EVAL", redisUnlockScript, "1", key, token) }, nil
+}
+
+// do sends a command and reads its reply: a string, an int64, []byte for a bulk string,
+// or nil.
+func (c *redisCache) do(args ...string) (any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.conn == nil {
+		if time.Now().Before(c.downUntil) {
+			return nil, fmt.Errorf("redis is unavailable")
+		}
+		if err := c.connect(); err != nil {
+			c.downUntil = time.Now().Add(redisRetryAfter)
+			return nil, err
+		}
+	}
+	reply, err := c.roundTrip(args)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		// The connection is in an unknown state; start over next time
+		c.conn.Close()
+		c.conn = nil
+	}
+	return reply, err
+}
+
+func (c *redisCache) connect() error {
+	dialer := &net.Dialer{Timeout: redisTimeout}
+	var conn net.Conn
+	var err error
+	if c.tls {
+		host, _, _ := net.SplitHostPort(c.addr)
+		conn, err = tls.DialWithDialer(dialer, "tcp", c.addr, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", c.addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to redis: %v", err)
+	}
+	c.conn, c.reader = conn, bufio.NewReader(conn)
+
+	var setup [][]string
+	if c.password != "" {
+		if c.username != "" {
+			setup = append(setup, []string{"AUTH", c.username, c.password})
+		} else {
+			setup = append(setup, []string{"AUTH", c.password})
+		}
+	}
+	if c.db != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(c.db)})
+	}
+	for _, args := range setup {
+		if _, err := c.roundTrip(args); err != nil {
+			conn.Close()
+			c.conn = nil
+			return fmt.Errorf("failed to set up redis connection: %v", err)
+		}
+	}
+	return nil
+}
+
+func (c *redisCache) roundTrip(args []string) (any, error) {
+	c.conn.SetDeadline(time.Now().Add(redisTimeout))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, b.String()); err != nil {
+		return nil, err
+	}
+	return readRedisReply(c.reader)
+}
+
+// redisError is an error reply, after which the connection is still good
+type redisError string
+
+func (e redisError) Error() string { return "redis: " + string(e) }
+
+func readRedisReply(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("empty redis reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis reply %q", line)
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	}
+	return nil, fmt.Errorf("unexpected redis reply %q", line)
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// prayerTally counts one prayer's logged statuses over a report period
+type prayerTally struct {
+	Prayer   string
+	Prayed   int
+	Late     int
+	Missed   int
+	Unlogged int
+	// Prayed on time in the period before, for the trend
+	Before int
+}
+
+// score ranks prayers for best and worst: on time counts double, late once.
+func (t prayerTally) score() int {
+	return 2*t.Prayed + t.Late
+}
+
+// prayerReport summarizes a user's prayer log over the last week or month
+type prayerReport struct {
+	User    string
+	Title   string
+	From    time.Time
+	To      time.Time
+	Prayers []prayerTally
+	Total   prayerTally
+}
+
+func newReportCmd(cfg Config) *cobra.Command {
+	var user string
+	var week, month, email, notify bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Summarize prayed, late, and missed prayers over the last week or month",
+		Long:  "Summarize the prayer log over the last 7 days (--week, the default) or 30 days (--month), with each prayer's trend against the period before. --email sends it with the email settings; --notify sends it through the configured notifiers.",
+		Run: func(cmd *cobra.Command, args []string) {
+			if week && month {
+				fmt.Println("Error: --week and --month can't be combined")
+				os.Exit(1)
+			}
+			days, title := 7, "Weekly"
+			if month {
+				days, title = 30, "Monthly"
+			}
+
+			if err := runReport(cmd.Context(), normalizeUser(user), title, days, email, notify, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&week, "week", false, "Report on the last 7 days (default)")
+	cmd.Flags().BoolVar(&month, "month", false, "Report on the last 30 days")
+	cmd.Flags().BoolVar(&email, "email", false, "Email the report with the email settings in the config file")
+	cmd.Flags().BoolVar(&notify, "notify", false, "Send the report through the configured notifiers")
+	cmd.Flags().StringVar(&user, "user", "", "Household member to report on (default: you)")
+	return cmd
+}
+
+// buildReport tallies a user's log over the days days up to and including now, and the
+// same number of days before that for the trend.
+func buildReport(store *Store, user, title string, days int, now time.Time) prayerReport {
+	to := dayStart(now)
+	from := addDays(to, -(days - 1))
+	before := addDays(from, -days)
+
+	r := prayerReport{User: user, Title: title, From: from, To: to, Total: prayerTally{Prayer: "Total"}}
+	tallies := map[string]*prayerTally{}
+	for _, prayer := range trackedPrayers {
+		tallies[prayer] = &prayerTally{Prayer: prayer, Unlogged: days}
+	}
+
+	fromDate, toDate, beforeDate := from.Format("2006-01-02"), to.Format("2006-01-02"), before.Format("2006-01-02")
+	for _, p := range store.Prayers {
+		t, ok := tallies[p.Prayer]
+		if p.User != user || !ok {
+			continue
+		}
+		switch {
+		case p.Date >= fromDate && p.Date <= toDate:
+			t.Unlogged--
+			switch p.Status {
+			case statusPrayed:
+				t.Prayed++
+			case statusLate:
+				t.Late++
+			case statusMissed:
+				t.Missed++
+			}
+		case p.Date >= beforeDate && p.Date < fromDate && p.Status == statusPrayed:
+			t.Before++
+		}
+	}
+
+	for _, prayer := range trackedPrayers {
+		t := *tallies[prayer]
+		r.Prayers = append(r.Prayers, t)
+		r.Total.Prayed += t.Prayed
+		r.Total.Late += t.Late
+		r.Total.Missed += t.Missed
+		r.Total.Unlogged += t.Unlogged
+		r.Total.Before += t.Before
+	}
+	return r
+}
+
+// trend is an arrow comparing prayers on time with the period before.
+func (t prayerTally) trend() string {
+	switch {
+	case t.Prayed > t.Before:
+		return "↑"
+	case t.Prayed < t.Before:
+		return "↓"
+	default:
+		return "→"
+	}
+}
+
+// bestAndWorst are the prayers with the highest and lowest scores, or "" when nothing
+// was logged or every prayer scored the same.
+func (r prayerReport) bestAndWorst() (best, worst string) {
+	hi, lo := r.Prayers[0], r.Prayers[0]
+	for _, t := range r.Prayers[1:] {
+		if t.score() > hi.score() {
+			hi = t
+		}
+		if t.score() < lo.score() {
+			lo = t
+		}
+	}
+	if hi.score() == lo.score() {
+		return "", ""
+	}
+	return hi.Prayer, lo.Prayer
+}
+
+func (r prayerReport) heading() string {
+	heading := fmt.Sprintf("%s prayer report, %s – %s", r.Title, r.From.Format("02 Jan"), r.To.Format("02 Jan 2006"))
+	if r.User != "" {
+		heading += " for " + r.User
+	}
+	return heading
+}
+
+// text is the report as plain text for email and notifications.
+func (r prayerReport) text() string {
+	var b strings.Builder
+	for _, t := range append(r.Prayers, r.Total) {
+		fmt.Fprintf(&b, "%s %s: %d prayed, %d late, %d missed", t.trend(), t.Prayer, t.Prayed, t.Late, t.Missed)
+		if t.Unlogged > 0 {
+			fmt.Fprintf(&b, ", %d not logged", t.Unlogged)
+		}
+		b.WriteString("\n")
+	}
+	if best, worst := r.bestAndWorst(); best != "" {
+		fmt.Fprintf(&b, "\nBest: %s. Needs attention: %s.\n", best, worst)
+	}
+	b.WriteString("\n↑ ↓ → compare prayers on time with the period before.\n")
+	return b.String()
+}
+
+func runReport(ctx context.Context, user, title string, days int, email, notify bool, cfg Config) error {
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+	r := buildReport(store, user, title, days, clock.Now())
+
+	fmt.Println(titleStyle.Render("📈 " + r.heading()))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %-7s %-5s %-7s %s", padRight("", 15), statusPrayed, statusLate, statusMissed, "not logged")))
+	for _, t := range append(r.Prayers, r.Total) {
+		name := prayerNames[t.Prayer]
+		if t.Prayer == r.Total.Prayer {
+			name = t.Prayer
+		}
+		row := fmt.Sprintf("%-7d %-5d %-7d %-10d", t.Prayed, t.Late, t.Missed, t.Unlogged)
+		fmt.Printf("%s %s %s\n", prayerStyle.Render(padRight(name, 15)), timeStyle.Render(row), countdownStyle.Render(t.trend()))
+	}
+	if best, worst := r.bestAndWorst(); best != "" {
+		fmt.Println()
+		fmt.Println(nextPrayerStyle.Render(fmt.Sprintf("Best: %s", prayerNames[best])))
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("Needs attention: %s", prayerNames[worst])))
+	}
+
+	var notifiers []notifier
+	if email {
+		e, err := newEmailNotifier(cfg.Email)
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, e)
+	}
+	if notify {
+		configured, err := newNotifiers(cfg)
+		if err != nil {
+			return err
+		}
+		notifiers = append(notifiers, configured...)
+	}
+
+	n := notification{Title: r.heading(), Body: r.text()}
+	for _, backend := range notifiers {
+		if err := backend.notify(ctx, n); err != nil {
+			return err
+		}
+	}
+	if len(notifiers) > 0 {
+		fmt.Println()
+		fmt.Println(cityStyle.PaddingLeft(2).Render("Report sent"))
+	}
+	return nil
+}
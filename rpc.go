@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JSON-RPC 2.0 error codes
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+// Longest request line read, well past any request pray answers
+const rpcMaxLine = 1 << 20
+
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcNotification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// rpcLocation is the params of every method; fields left out are the flags' location
+type rpcLocation struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Method  int    `json:"method"`
+}
+
+// rpcNext is the result of get_next and the params of a next notification
+type rpcNext struct {
+	City      string `json:"city"`
+	Prayer    string `json:"prayer"`
+	Time      string `json:"time"`
+	Timestamp string `json:"timestamp"`
+	Minutes   int    `json:"minutes"`
+	Countdown string `json:"countdown"`
+}
+
+type rpcTiming struct {
+	Prayer string `json:"prayer"`
+	Time   string `json:"time"`
+}
+
+// rpcToday is the result of get_today, with the timings in order
+type rpcToday struct {
+	City    string      `json:"city"`
+	Country string      `json:"country"`
+	Date    string      `json:"date"`
+	Hijri   string      `json:"hijri"`
+	Timings []rpcTiming `json:"timings"`
+	Next    string      `json:"next"`
+}
+
+// rpcServer answers JSON-RPC 2.0 requests, one JSON object per line, so an editor plugin
+// can keep one pray process running instead of starting one for every redraw:
+//
+//	get_next   the next prayer and its countdown
+//	get_today  today's timings
+//	subscribe  a "next" notification now and at the start of every minute after
+//
+// Each method takes an optional {"city", "country", "method"}.
+type rpcServer struct {
+	city    string
+	country string
+	method  int
+	cfg     Config
+
+	mu          sync.Mutex
+	out         *json.Encoder
+	unsubscribe context.CancelFunc
+}
+
+func runRPC(ctx context.Context, city, country string, method int, cfg Config, in io.Reader, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	s := &rpcServer{city: city, country: country, method: method, cfg: cfg, out: enc}
+	defer func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.unsubscribe != nil {
+			s.unsubscribe()
+		}
+	}()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 0, 64*1024), rpcMaxLine)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		s.handle(ctx, line)
+	}
+	// The client closing standard input is the end of the session
+	return scanner.Err()
+}
+
+func (s *rpcServer) handle(ctx context.Context, line string) {
+	if strings.HasPrefix(line, "[") {
+		s.send(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcInvalidRequest, "batches are not supported"}})
+		return
+	}
+	var req rpcRequest
+	if err := json.Unmarshal([]byte(line), &req); err != nil {
+		s.send(rpcResponse{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &rpcError{rpcParseError, err.Error()}})
+		return
+	}
+
+	result, rpcErr := s.call(ctx, req)
+	// Requests without an id are notifications, which get no response
+	if len(req.ID) > 0 {
+		s.send(rpcResponse{JSONRPC: "2.0", ID: req.ID, Result: result, Error: rpcErr})
+	}
+	// Started after the response, so the first notification follows it
+	if req.Method == "subscribe" && rpcErr == nil {
+		loc, _ := s.location(req.Params)
+		s.subscribe(ctx, loc)
+	}
+}
+
+func (s *rpcServer) call(ctx context.Context, req rpcRequest) (any, *rpcError) {
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return nil, &rpcError{rpcInvalidRequest, `expected "jsonrpc": "2.0" and a method`}
+	}
+	loc, err := s.location(req.Params)
+	if err != nil {
+		return nil, &rpcError{rpcInvalidParams, err.Error()}
+	}
+
+	var result any
+	switch req.Method {
+	case "get_next":
+		result, err = s.next(ctx, loc)
+	case "get_today":
+		result, err = s.today(ctx, loc)
+	case "subscribe":
+		result = map[string]bool{"subscribed": true}
+	default:
+		return nil, &rpcError{rpcMethodNotFound, fmt.Sprintf("unknown method %q (expected get_next, get_today, or subscribe)", req.Method)}
+	}
+	if err != nil {
+		return nil, &rpcError{rpcServerError, err.Error()}
+	}
+	return result, nil
+}
+
+// location reads the params, falling back to the location pray rpc was started with.
+func (s *rpcServer) location(params json.RawMessage) (rpcLocation, error) {
+	loc := rpcLocation{City: s.city, Country: s.country, Method: s.method}
+	if len(params) == 0 || string(params) == "null" {
+		return loc, nil
+	}
+	var given rpcLocation
+	if err := json.Unmarshal(params, &given); err != nil {
+		return loc, fmt.Errorf("invalid params: %v", err)
+	}
+	if given.City != "" {
+		loc.City = given.City
+	}
+	if given.Country != "" {
+		loc.Country = given.Country
+	}
+	if given.Method != 0 {
+		loc.Method = given.Method
+	}
+	return loc, nil
+}
+
+func (s *rpcServer) next(ctx context.Context, loc rpcLocation) (rpcNext, error) {
+	data, err := fetchPrayerTimes(ctx, loc.City, loc.Country, loc.Method, s.cfg)
+	if err != nil {
+		return rpcNext{}, err
+	}
+	now := clock.Now()
+	prayer, at, err := findNextPrayerAt(data.Data.Timings, now)
+	if err != nil {
+		return rpcNext{}, err
+	}
+	until := at.Sub(now)
+	return rpcNext{
+		City:      loc.City,
+		Prayer:    prayer,
+		Time:      at.Format("15:04"),
+		Timestamp: at.Format(time.RFC3339),
+		Minutes:   int(until.Minutes()),
+		Countdown: formatDuration(until),
+	}, nil
+}
+
+func (s *rpcServer) today(ctx context.Context, loc rpcLocation) (rpcToday, error) {
+	data, err := fetchPrayerTimes(ctx, loc.City, loc.Country, loc.Method, s.cfg)
+	if err != nil {
+		return rpcToday{}, err
+	}
+	next, _, err := findNextPrayerAt(data.Data.Timings, clock.Now())
+	if err != nil {
+		return rpcToday{}, err
+	}
+	t := data.Data.Timings
+	timings := map[string]string{
+		"Fajr":    t.Fajr,
+		"Sunrise": t.Sunrise,
+		"Dhuhr":   t.Dhuhr,
+		"Asr":     t.Asr,
+		"Maghrib": t.Maghrib,
+		"Isha":    t.Isha,
+	}
+	today := rpcToday{
+		City:    loc.City,
+		Country: loc.Country,
+		Date:    isoDate(data.Data.Date.Gregorian.Date),
+		Hijri:   fmt.Sprintf("%s %s %s", data.Data.Date.Hijri.Day, data.Data.Date.Hijri.Month.En, data.Data.Date.Hijri.Year),
+		Next:    next,
+	}
+	for _, prayer := range prayerOrder {
+		today.Timings = append(today.Timings, rpcTiming{Prayer: prayer, Time: strings.Split(timings[prayer], " ")[0]})
+	}
+	return today, nil
+}
+
+// subscribe sends a next notification now and at the start of every minute, replacing an
+// earlier subscription. A failed update is skipped rather than ending the subscription.
+func (s *rpcServer) subscribe(ctx context.Context, loc rpcLocation) {
+	s.mu.Lock()
+	if s.unsubscribe != nil {
+		s.unsubscribe()
+	}
+	ctx, s.unsubscribe = context.WithCancel(ctx)
+	s.mu.Unlock()
+
+	go func() {
+		for {
+			if next, err := s.next(ctx, loc); err == nil {
+				s.send(rpcNotification{JSONRPC: "2.0", Method: "next", Params: next})
+			}
+			now := clock.Now()
+			if err := clock.Sleep(ctx, now.Truncate(time.Minute).Add(time.Minute).Sub(now)); err != nil {
+				return
+			}
+		}
+	}()
+}
+
+// send writes one message per line; the subscription writes from its own goroutine.
+func (s *rpcServer) send(msg any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.out.Encode(msg)
+}
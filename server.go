@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+//go:embed web
+var webFiles embed.FS
+
+// How long a client gets to send its request headers, so slow ones can't hold connections open
+const serverReadHeaderTimeout = 10 * time.Second
+
+// serveOptions are the serve command's flags
+type serveOptions struct {
+	Addr        string
+	HealthPath  string
+	SlackSecret string
+	GRPCAddr    string
+}
+
+// server answers HTTP requests, defaulting to the location given on the command line
+type server struct {
+	city    string
+	country string
+	method  int
+	cfg     Config
+	opts    serveOptions
+	started time.Time
+}
+
+type timingsPayload struct {
+	City    string `json:"city"`
+	Country string `json:"country"`
+	Data
+}
+
+type calendarPayload struct {
+	City      string `json:"city"`
+	Country   string `json:"country"`
+	Year      int    `json:"year"`
+	Month     int    `json:"month"`
+	WeekStart string `json:"week_start"`
+	Days      []Data `json:"days"`
+}
+
+func runServer(ctx context.Context, opts serveOptions, city, country string, method int, cfg Config) error {
+	if opts.SlackSecret == "" {
+		opts.SlackSecret = os.Getenv("PRAY_SLACK_SIGNING_SECRET")
+	}
+	if err := validateTokens(cfg.APITokens); err != nil {
+		return err
+	}
+	s := &server{city: city, country: country, method: method, cfg: cfg, opts: opts, started: time.Now()}
+
+	static, err := fs.Sub(webFiles, "web")
+	if err != nil {
+		return err
+	}
+
+	schema, err := s.graphqlSchema()
+	if err != nil {
+		return fmt.Errorf("failed to build GraphQL schema: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServer(http.FS(static)))
+	mux.HandleFunc("GET /api/timings", s.handleTimings)
+	mux.HandleFunc("GET /api/calendar", s.handleCalendar)
+	mux.HandleFunc("GET /calendar.ics", s.handleICS)
+	mux.HandleFunc("GET /api/voice", s.handleVoice)
+	mux.HandleFunc("GET /v1/glance", s.handleGlance)
+	mux.HandleFunc("POST /api/voice", s.handleVoice)
+	mux.HandleFunc("POST /api/log", s.requireScope(scopeWrite, s.handleLog))
+	mux.HandleFunc("POST /v1/track", s.requireScope(scopeWrite, s.handleTrack))
+	mux.HandleFunc("GET /api/household", s.requireScope(scopeRead, s.handleHousehold))
+	graphqlHandler := s.handleGraphQL(schema)
+	mux.HandleFunc("GET /graphql", graphqlHandler)
+	mux.HandleFunc("POST /graphql", graphqlHandler)
+	if opts.HealthPath != "" {
+		mux.HandleFunc("GET "+opts.HealthPath, s.handleHealth)
+	}
+	if opts.SlackSecret != "" {
+		mux.HandleFunc("POST /slack/command", s.handleSlackCommand)
+	}
+
+	srv := &http.Server{Addr: opts.Addr, Handler: logRequests(mux), ReadHeaderTimeout: serverReadHeaderTimeout}
+
+	if opts.GRPCAddr != "" {
+		if err := s.serveGRPC(ctx, opts.GRPCAddr); err != nil {
+			return err
+		}
+	}
+
+	// Shut down gracefully when the command is cancelled
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 pray serving %s on %s", cityStyle.Render(city), opts.Addr)))
+	if opts.GRPCAddr != "" {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("gRPC on %s", opts.GRPCAddr)))
+	}
+	if len(cfg.APITokens) == 0 {
+		fmt.Println(prayerStyle.Render("The prayer log at /api/log, /v1/track, and /api/household is open to anyone who can reach this address; set api_tokens to restrict it"))
+	}
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// location reads city, country, and method from the query, falling back to the server defaults.
+func (s *server) location(r *http.Request) (string, string, int, error) {
+	query := r.URL.Query()
+
+	city := s.city
+	if v := query.Get("city"); v != "" {
+		city = v
+	}
+
+	country := s.country
+	if v := query.Get("country"); v != "" {
+		country = v
+	}
+
+	method := s.method
+	if v := query.Get("method"); v != "" {
+		m, err := strconv.Atoi(v)
+		if err != nil {
+			return "", "", 0, fmt.Errorf("invalid method %q", v)
+		}
+		method = m
+	}
+
+	return city, country, method, nil
+}
+
+func (s *server) handleTimings(w http.ResponseWriter, r *http.Request) {
+	city, country, method, err := s.location(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	data, err := fetchPrayerTimes(r.Context(), city, country, method, s.cfg)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, timingsPayload{City: city, Country: country, Data: data.Data})
+}
+
+func (s *server) handleCalendar(w http.ResponseWriter, r *http.Request) {
+	city, country, method, err := s.location(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	now := clock.Now()
+	year, month := now.Year(), int(now.Month())
+	if v := r.URL.Query().Get("year"); v != "" {
+		if year, err = strconv.Atoi(v); err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid year %q", v))
+			return
+		}
+	}
+	if v := r.URL.Query().Get("month"); v != "" {
+		if month, err = strconv.Atoi(v); err != nil || month < 1 || month > 12 {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid month %q", v))
+			return
+		}
+	}
+
+	calendar, err := fetchCalendar(r.Context(), city, country, method, s.cfg, year, month)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, calendarPayload{
+		City:      city,
+		Country:   country,
+		Year:      year,
+		Month:     month,
+		WeekStart: s.cfg.firstWeekday().String(),
+		Days:      calendar.Data,
+	})
+}
+
+// handleHealth answers container health probes. It does not call the API, so an
+// upstream outage doesn't get the container restarted.
+func (s *server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"status": "ok",
+		"uptime": time.Since(s.started).Round(time.Second).String(),
+	})
+}
+
+// cityNow is the current time in the timezone of the fetched location, so next-prayer
+// answers are right when the server runs in a different timezone than the city.
+func cityNow(data Data) time.Time {
+	if loc, err := time.LoadLocation(data.Meta.Timezone); err == nil {
+		return clock.Now().In(loc)
+	}
+	return clock.Now()
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("failed to encode response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+
+		// Keep API tokens passed in the query out of the log
+		uri := *r.URL
+		if query := uri.Query(); query.Has("token") {
+			query.Set("token", "redacted")
+			uri.RawQuery = query.Encode()
+		}
+		log.Printf("%s %s (%s)", r.Method, uri.RequestURI(), time.Since(start).Round(time.Millisecond))
+	})
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// shortcutsNext is a flat dictionary so iOS Shortcuts can read it with "Get Dictionary from Input"
+type shortcutsNext struct {
+	City      string `json:"city"`
+	Prayer    string `json:"prayer"`
+	Time      string `json:"time"`
+	Timestamp string `json:"timestamp"`
+	Minutes   int    `json:"minutes"`
+	Countdown string `json:"countdown"`
+	Hijri     string `json:"hijri"`
+}
+
+func printShortcutsNext(city, prayer string, at time.Time, until time.Duration, data Data) {
+	if until < 0 {
+		until = 0
+	}
+	shortcutsEncoder().Encode(shortcutsNext{
+		City:      city,
+		Prayer:    prayer,
+		Time:      at.Format("15:04"),
+		Timestamp: at.Format(time.RFC3339),
+		Minutes:   int(until.Minutes()),
+		Countdown: formatDuration(until),
+		Hijri:     fmt.Sprintf("%s %s %s", data.Date.Hijri.Day, data.Date.Hijri.Month.En, data.Date.Hijri.Year),
+	})
+}
+
+// exitNext reports an error from `pray next`, as JSON when a script is reading the output.
+func exitNext(format string, err error) {
+	if format == "shortcuts" {
+		shortcutsEncoder().Encode(map[string]string{"error": err.Error()})
+	} else {
+		fmt.Printf("Error: %v\n", err)
+	}
+	os.Exit(1)
+}
+
+func shortcutsEncoder() *json.Encoder {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetEscapeHTML(false)
+	return enc
+}
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// How far ahead a simulation runs: a full day from the start, so every event comes round once
+const simulateSpan = 24 * time.Hour
+
+// parseSimulateTime reads --simulate, like 2025-03-01T17:55, or 17:55 for today.
+func parseSimulateTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02T15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("15:04", s); err == nil {
+		return wallClock(now, t.Hour(), t.Minute(), ""), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --simulate time %q (expected 2006-01-02T15:04, or 15:04 for today)", s)
+}
+
+// simulateDaemon runs the daemon's scheduler on a fake clock starting at start, which jumps
+// ahead whenever the scheduler sleeps, and prints what each event would send and run.
+// Nothing is sent and no hook runs, so a reminder config can be checked without waiting
+// for the day to pass.
+func simulateDaemon(ctx context.Context, city, country string, method int, cfg Config, start time.Time) error {
+	out, err := newDaemonOutputs(cfg)
+	if err != nil {
+		return err
+	}
+	if err := checkDaemonConfig(cfg); err != nil {
+		return err
+	}
+	var targets []string
+	for _, backend := range out.notifiers {
+		targets = append(targets, backend.name())
+	}
+
+	// The daemon fetches each day's timings after midnight, so the span covers two days
+	end := start.Add(simulateSpan)
+	calendars := map[[2]int]*CalendarResponse{}
+	var events []daemonEvent
+	for day := dayStart(start); day.Before(end); day = addDays(day, 1) {
+		key := [2]int{day.Year(), int(day.Month())}
+		calendar, ok := calendars[key]
+		if !ok {
+			calendar, err = fetchCalendar(ctx, city, country, method, cfg, day.Year(), int(day.Month()))
+			if err != nil {
+				return err
+			}
+			calendars[key] = calendar
+		}
+		if day.Day() > len(calendar.Data) {
+			return fmt.Errorf("no timings for %s", day.Format("2006-01-02"))
+		}
+		for _, event := range daemonEvents(calendar.Data[day.Day()-1].Timings, day, cfg) {
+			if !event.At.Before(start) && event.At.Before(end) {
+				events = append(events, event)
+			}
+		}
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 pray daemon simulation for %s", cityStyle.Render(city))))
+	fmt.Println(strings.Repeat("━", 60))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("From %s to %s; nothing is sent", start.Format("Mon 02 Jan 15:04"), end.Format("Mon 02 Jan 15:04"))))
+	if len(events) == 0 {
+		fmt.Println(prayerStyle.Render("No events in that time"))
+		return nil
+	}
+
+	saved := clock
+	clock = newFakeClock(start)
+	defer func() { clock = saved }()
+
+	for _, event := range events {
+		if _, err := sleepUntil(ctx, event.At, nil); err != nil {
+			return err
+		}
+		fmt.Println()
+		heading := fmt.Sprintf("%s %s", clock.Now().Format("Mon 15:04"), event.Kind)
+		if event.Prayer != "" {
+			heading += " " + event.Prayer
+		}
+		fmt.Println(nextPrayerStyle.Render(heading))
+
+		sim := &simulatedOutputs{cfg: cfg, targets: targets, at: event.At}
+		fireDaemonEvent(ctx, event, city, cfg, sim)
+		for _, line := range sim.lines {
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %s", padRight(line[0], 8), line[1])))
+		}
+	}
+	return nil
+}
+
+// simulatedOutputs stand in for the daemon's outputs in a simulation, listing what each
+// effect of an event would do, as pairs of what and how, instead of doing it.
+type simulatedOutputs struct {
+	cfg     Config
+	targets []string
+	at      time.Time
+	lines   [][2]string
+}
+
+func (o *simulatedOutputs) add(what, how string) {
+	o.lines = append(o.lines, [2]string{what, how})
+}
+
+// show prints nothing; the simulation heads each event itself.
+func (o *simulatedOutputs) show(event daemonEvent, n notification, cfg Config) {}
+
+func (o *simulatedOutputs) runHooks(ctx context.Context, commands []string, event daemonEvent, city string) {
+	for _, command := range commands {
+		o.add("hook", command)
+	}
+}
+
+func (o *simulatedOutputs) pulseGPIO(ctx context.Context, pin GPIOPin, pulses *sync.WaitGroup) {
+	o.add("gpio", fmt.Sprintf("pin %d for %s", pin.Pin, pin.Pulse))
+}
+
+func (o *simulatedOutputs) notify(ctx context.Context, n notification) {
+	o.add("notify", fmt.Sprintf("%s: %s", n.Title, n.Body))
+	if len(o.targets) > 0 {
+		o.add("", "via "+strings.Join(o.targets, ", "))
+	}
+	if n.Sound != "" {
+		o.add("sound", n.Sound)
+	}
+	if quiet, _ := o.cfg.QuietHours.contains(o.at); quiet {
+		o.add("", "quiet hours: sound and speech muted")
+	}
+}
+
+func (o *simulatedOutputs) setStatus(ctx context.Context, cfg StatusConfig) {
+	text, duration := cfg.Text, cfg.Duration
+	if text == "" {
+		text = defaultStatusText
+	}
+	if duration <= 0 {
+		duration = defaultStatusDuration
+	}
+	var services []string
+	if cfg.SlackToken != "" {
+		services = append(services, "Slack")
+	}
+	if cfg.Teams.RefreshToken != "" {
+		services = append(services, "Teams")
+	}
+	o.add("status", fmt.Sprintf("%q on %s until %s", text, strings.Join(services, " and "), o.at.Add(duration).Format("15:04")))
+}
+
+func (o *simulatedOutputs) focusBreak(ctx context.Context, mode string, n notification) {
+	o.add("focus", mode+" break")
+}
+
+func (o *simulatedOutputs) fail(err error) {
+	o.add("error", err.Error())
+}
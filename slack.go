@@ -0,0 +1,168 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Slack rejects replays older than this, and so do we
+const slackMaxSkew = 5 * time.Minute
+
+const slackUsage = "Usage: `/pray next [city, country]` or `/pray times [city, country]`"
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackResponse struct {
+	ResponseType string       `json:"response_type"`
+	Text         string       `json:"text"`
+	Blocks       []slackBlock `json:"blocks,omitempty"`
+}
+
+// handleSlackCommand answers the /pray slash command with Block Kit formatted timings.
+func (s *server) handleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := verifySlackSignature(s.opts.SlackSecret, r.Header, body, time.Now()); err != nil {
+		writeError(w, http.StatusUnauthorized, err)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	subcommand, location, _ := strings.Cut(strings.TrimSpace(form.Get("text")), " ")
+	city, country := s.city, s.country
+	if location = strings.TrimSpace(location); location != "" {
+		city = location
+		if c, cc, ok := strings.Cut(location, ","); ok {
+			city, country = strings.TrimSpace(c), strings.TrimSpace(cc)
+		}
+	}
+
+	subcommand = strings.ToLower(subcommand)
+	if subcommand != "" && subcommand != "next" && subcommand != "times" {
+		// Slack shows non-200 replies as a generic failure, so errors are ephemeral messages
+		writeJSON(w, http.StatusOK, slackResponse{ResponseType: "ephemeral", Text: slackUsage})
+		return
+	}
+
+	data, err := fetchPrayerTimes(r.Context(), city, country, s.method, s.cfg)
+	if err != nil {
+		writeJSON(w, http.StatusOK, slackResponse{
+			ResponseType: "ephemeral",
+			Text:         fmt.Sprintf("Couldn't get prayer times for %s: %v", city, err),
+		})
+		return
+	}
+
+	if subcommand == "times" {
+		writeJSON(w, http.StatusOK, slackTimings(city, data.Data))
+	} else {
+		writeJSON(w, http.StatusOK, slackNext(city, data.Data))
+	}
+}
+
+// verifySlackSignature checks the v0 request signature Slack sends with every command.
+func verifySlackSignature(secret string, header http.Header, body []byte, now time.Time) error {
+	ts := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing or invalid request timestamp")
+	}
+	if skew := now.Sub(time.Unix(seconds, 0)); skew > slackMaxSkew || skew < -slackMaxSkew {
+		return fmt.Errorf("request timestamp too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "v0:%s:%s", ts, body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
+
+func slackNext(city string, data Data) slackResponse {
+	now := cityNow(data)
+	prayer, at, err := findNextPrayerAt(data.Timings, now)
+	if err != nil {
+		return slackResponse{ResponseType: "ephemeral", Text: fmt.Sprintf("Couldn't work out the next prayer: %v", err)}
+	}
+
+	text := fmt.Sprintf("Next prayer in %s: %s at %s, in %s", city, prayer, at.Format("15:04"), formatDuration(at.Sub(now)))
+	return slackResponse{
+		ResponseType: "ephemeral",
+		Text:         text,
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: fmt.Sprintf("🕌 Next prayer in %s", city)}},
+			{Type: "section", Text: &slackText{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("%s at *%s*, in %s", prayerNames[prayer], at.Format("15:04"), formatDuration(at.Sub(now))),
+			}},
+			slackDateContext(data),
+		},
+	}
+}
+
+func slackTimings(city string, data Data) slackResponse {
+	timings := map[string]string{
+		"Fajr":    data.Timings.Fajr,
+		"Sunrise": data.Timings.Sunrise,
+		"Dhuhr":   data.Timings.Dhuhr,
+		"Asr":     data.Timings.Asr,
+		"Maghrib": data.Timings.Maghrib,
+		"Isha":    data.Timings.Isha,
+	}
+
+	var fields []slackText
+	for _, prayer := range prayerOrder {
+		timeStr := strings.Split(timings[prayer], " ")[0]
+		fields = append(fields, slackText{Type: "mrkdwn", Text: fmt.Sprintf("%s\n*%s*", prayerNames[prayer], timeStr)})
+	}
+
+	return slackResponse{
+		ResponseType: "ephemeral",
+		Text:         fmt.Sprintf("Prayer times for %s", city),
+		Blocks: []slackBlock{
+			{Type: "header", Text: &slackText{Type: "plain_text", Text: fmt.Sprintf("🕌 Prayer Times for %s", city)}},
+			{Type: "section", Fields: fields},
+			slackDateContext(data),
+		},
+	}
+}
+
+func slackDateContext(data Data) slackBlock {
+	return slackBlock{
+		Type: "context",
+		Elements: []slackText{{
+			Type: "mrkdwn",
+			Text: fmt.Sprintf("📅 %s | %s %s, %s AH | %s",
+				Config{}.gregorianDate(data.Date.Gregorian), data.Date.Hijri.Day, data.Date.Hijri.Month.En, data.Date.Hijri.Year, data.Meta.Method.Name),
+		}},
+	}
+}
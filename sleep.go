@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	sleepCycle = 90 * time.Minute
+
+	// Time it takes to fall asleep, added before the first cycle
+	fallAsleep = 15 * time.Minute
+
+	// Earliest bedtime after the Isha adhan, leaving time to pray it
+	ishaGrace = 30 * time.Minute
+)
+
+// sleepPlan is a recommended bedtime and wake-up time
+type sleepPlan struct {
+	Bed    time.Time
+	Wake   time.Time
+	Cycles int
+	Note   string
+}
+
+// sleepPlans suggests bedtimes that end a whole number of sleep cycles at Fajr, plus one
+// that wakes at the start of the last third of the night (Maghrib to Fajr) for tahajjud.
+func sleepPlans(maghrib, isha, fajr time.Time) ([]sleepPlan, time.Time) {
+	lastThird := fajr.Add(-fajr.Sub(maghrib) / 3)
+	earliest := isha.Add(ishaGrace)
+
+	var plans []sleepPlan
+	for cycles := 6; cycles >= 2 && len(plans) < 2; cycles-- {
+		bed := fajr.Add(-time.Duration(cycles)*sleepCycle - fallAsleep)
+		if !bed.Before(earliest) {
+			plans = append(plans, sleepPlan{Bed: bed, Wake: fajr, Cycles: cycles, Note: "wake for Fajr"})
+		}
+	}
+	for cycles := 5; cycles >= 2; cycles-- {
+		bed := lastThird.Add(-time.Duration(cycles)*sleepCycle - fallAsleep)
+		if !bed.Before(earliest) {
+			plans = append(plans, sleepPlan{Bed: bed, Wake: lastThird, Cycles: cycles, Note: "wake in the last third for qiyam, then Fajr"})
+			break
+		}
+	}
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].Bed.Before(plans[j].Bed) })
+	return plans, lastThird
+}
+
+// showSleep prints tonight's sleep plans. After midnight and before Fajr, tonight is the
+// night that is already under way.
+func showSleep(ctx context.Context, city, country string, method int, cfg Config) error {
+	now := clock.Now()
+	today, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	todayFajr, err := parseTimeOn(today.Data.Timings.Fajr, now)
+	if err != nil {
+		return err
+	}
+
+	evening, morning := today, today
+	eveningDay, morningDay := now, now
+	if now.Before(todayFajr) {
+		eveningDay = addDays(now, -1)
+		evening, err = fetchPrayerTimesOn(ctx, eveningDay, city, country, method, cfg)
+	} else {
+		morningDay = addDays(now, 1)
+		morning, err = fetchPrayerTimesOn(ctx, morningDay, city, country, method, cfg)
+	}
+	if err != nil {
+		return err
+	}
+
+	maghrib, err := parseTimeOn(evening.Data.Timings.Maghrib, eveningDay)
+	if err != nil {
+		return err
+	}
+	isha, err := parseTimeOn(evening.Data.Timings.Isha, eveningDay)
+	if err != nil {
+		return err
+	}
+	fajr, err := parseTimeOn(morning.Data.Timings.Fajr, morningDay)
+	if err != nil {
+		return err
+	}
+
+	plans, lastThird := sleepPlans(maghrib, isha, fajr)
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🌙 Sleep tonight in %s", cityStyle.Render(city))))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("Isha %s · Fajr %s · last third from %s",
+		isha.Format("15:04"), fajr.Format("15:04"), lastThird.Format("15:04"))))
+	fmt.Println()
+
+	if len(plans) == 0 {
+		fmt.Println(countdownStyle.Render("The night is too short for full sleep cycles; consider a nap after Dhuhr."))
+		return nil
+	}
+	for _, plan := range plans {
+		fmt.Printf("  %s %s  %s %s  %s  %s\n",
+			emojiStyle.Render("💤"), timeStyle.Render(plan.Bed.Format("15:04")),
+			emojiStyle.Render("⏰"), timeStyle.Render(plan.Wake.Format("15:04")),
+			nextPrayerStyle.Render(fmt.Sprintf("%d cycles, %s", plan.Cycles, formatDuration(time.Duration(plan.Cycles)*sleepCycle))),
+			prayerStyle.Render(plan.Note))
+	}
+
+	fmt.Println()
+	fmt.Println(cityStyle.Render("Bedtimes allow 15 minutes to fall asleep; a sleep cycle is about 90 minutes."))
+	return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/ssh"
+	"github.com/charmbracelet/wish"
+	"github.com/muesli/termenv"
+)
+
+type sshKioskOptions struct {
+	Addr           string
+	HostKey        string
+	AuthorizedKeys string
+	Interval       time.Duration
+}
+
+// Keys that end an SSH kiosk session: q, Ctrl+C, and Ctrl+D
+var sshKioskQuitKeys = map[byte]bool{'q': true, 'Q': true, 0x03: true, 0x04: true}
+
+// runSSHKiosk serves the kiosk over SSH, so anyone on the network can ssh in and watch it
+// without installing pray. Without authorized keys any user name and key gets in.
+func runSSHKiosk(ctx context.Context, opts sshKioskOptions, city, country string, method int, cfg Config) error {
+	if opts.HostKey == "" {
+		dir, err := dataDir()
+		if err != nil {
+			return err
+		}
+		opts.HostKey = filepath.Join(dir, "ssh_host_ed25519_key")
+	}
+
+	// The profile is detected from the server's own output, which may not be a terminal at
+	// all; every SSH client pray is likely to meet shows 256 colors.
+	lipgloss.SetColorProfile(termenv.ANSI256)
+
+	options := []ssh.Option{
+		wish.WithAddress(opts.Addr),
+		// Generated on first start if it doesn't exist
+		wish.WithHostKeyPath(opts.HostKey),
+		// Turns \n into \r\n, since the client's terminal is in raw mode
+		ssh.EmulatePty(),
+		// The last middleware runs first
+		wish.WithMiddleware(sshKioskHandler(city, country, method, cfg, opts.Interval), logSessions),
+	}
+	if opts.AuthorizedKeys != "" {
+		options = append(options, wish.WithAuthorizedKeys(expandHome(opts.AuthorizedKeys)))
+	}
+	srv, err := wish.NewServer(options...)
+	if err != nil {
+		return fmt.Errorf("failed to start SSH server: %v", err)
+	}
+
+	// Shut down gracefully when the command is cancelled
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🕌 pray serving %s over SSH on %s", cityStyle.Render(city), opts.Addr)))
+	if opts.AuthorizedKeys == "" {
+		fmt.Println(prayerStyle.Render("Anyone who can reach this address can connect; set --authorized-keys to restrict it"))
+	}
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, ssh.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// sshKioskHandler shows the kiosk in each session at the size of the client's terminal,
+// until the client presses q or disconnects.
+func sshKioskHandler(city, country string, method int, cfg Config, interval time.Duration) wish.Middleware {
+	return func(ssh.Handler) ssh.Handler {
+		return func(s ssh.Session) {
+			pty, resize, ok := s.Pty()
+			if !ok {
+				wish.Fatalln(s, "pray needs a terminal; connect with ssh -t")
+				return
+			}
+
+			ctx, cancel := context.WithCancel(s.Context())
+			defer cancel()
+
+			var mu sync.Mutex
+			width, height := pty.Window.Width, pty.Window.Height
+			go func() {
+				for w := range resize {
+					mu.Lock()
+					width, height = w.Width, w.Height
+					mu.Unlock()
+				}
+			}()
+			go func() {
+				defer cancel()
+				buf := make([]byte, 64)
+				for {
+					n, err := s.Read(buf)
+					if err != nil {
+						return
+					}
+					for _, b := range buf[:n] {
+						if sshKioskQuitKeys[b] {
+							return
+						}
+					}
+				}
+			}()
+
+			showKiosk(ctx, city, country, method, cfg, interval, s, func() (int, int) {
+				mu.Lock()
+				defer mu.Unlock()
+				return width, height
+			})
+		}
+	}
+}
+
+// logSessions logs each SSH session when it ends, like logRequests does for HTTP.
+func logSessions(next ssh.Handler) ssh.Handler {
+	return func(s ssh.Session) {
+		start := time.Now()
+		next(s)
+		log.Printf("ssh %s@%s (%s)", s.User(), s.RemoteAddr(), time.Since(start).Round(time.Second))
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Status defaults, used when the config leaves them out
+const (
+	defaultStatusText     = "Praying — back soon"
+	defaultStatusEmoji    = ":mosque:"
+	defaultStatusDuration = 20 * time.Minute
+)
+
+const (
+	slackProfileURL   = "https://slack.com/api/users.profile.set"
+	teamsStatusURL    = "https://graph.microsoft.com/v1.0/me/presence/setStatusMessage"
+	teamsTokenURLBase = "https://login.microsoftonline.com/"
+)
+
+// statusUpdater sets a chat status that expires on its own
+type statusUpdater interface {
+	setStatus(ctx context.Context, text, emoji string, until time.Time) error
+}
+
+func newStatusUpdaters(cfg StatusConfig) []statusUpdater {
+	var updaters []statusUpdater
+	if cfg.SlackToken != "" {
+		updaters = append(updaters, slackStatus{token: cfg.SlackToken})
+	}
+	if cfg.Teams.RefreshToken != "" {
+		updaters = append(updaters, &teamsStatus{config: cfg.Teams, refreshToken: cfg.Teams.RefreshToken})
+	}
+	return updaters
+}
+
+// slackStatus sets the Slack profile status with a user token (users.profile:write).
+type slackStatus struct {
+	token string
+}
+
+func (s slackStatus) setStatus(ctx context.Context, text, emoji string, until time.Time) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"profile": map[string]interface{}{
+			"status_text":       text,
+			"status_emoji":      emoji,
+			"status_expiration": until.Unix(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := postJSON(ctx, slackProfileURL, s.token, payload, &result); err != nil {
+		return fmt.Errorf("failed to set Slack status: %v", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("failed to set Slack status: %s", result.Error)
+	}
+	return nil
+}
+
+// teamsStatus sets the Teams status message through Microsoft Graph (Presence.ReadWrite).
+// Access tokens are short-lived, so it keeps exchanging the refresh token for new ones.
+type teamsStatus struct {
+	config TeamsConfig
+
+	mu           sync.Mutex
+	refreshToken string
+	accessToken  string
+	expires      time.Time
+}
+
+func (t *teamsStatus) setStatus(ctx context.Context, text, emoji string, until time.Time) error {
+	token, err := t.token(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to set Teams status: %v", err)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"statusMessage": map[string]interface{}{
+			"message": map[string]string{
+				"content":     "🕌 " + text,
+				"contentType": "text",
+			},
+			"expiryDateTime": map[string]string{
+				"dateTime": until.UTC().Format("2006-01-02T15:04:05"),
+				"timeZone": "UTC",
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := postJSON(ctx, teamsStatusURL, token, payload, nil); err != nil {
+		return fmt.Errorf("failed to set Teams status: %v", err)
+	}
+	return nil
+}
+
+// token returns a valid access token, refreshing it when it is about to expire.
+func (t *teamsStatus) token(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.accessToken != "" && time.Until(t.expires) > time.Minute {
+		return t.accessToken, nil
+	}
+
+	tenant := t.config.Tenant
+	if tenant == "" {
+		tenant = "common"
+	}
+	form := url.Values{
+		"client_id":     {t.config.ClientID},
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {t.refreshToken},
+		"scope":         {"Presence.ReadWrite offline_access"},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, teamsTokenURLBase+tenant+"/oauth2/v2.0/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh token: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		RefreshToken     string `json:"refresh_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("failed to refresh token: %s %s", resp.Status, result.ErrorDescription)
+	}
+
+	t.accessToken = result.AccessToken
+	t.expires = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	if result.RefreshToken != "" {
+		t.refreshToken = result.RefreshToken
+	}
+	return t.accessToken, nil
+}
+
+// postJSON sends an authorized JSON POST and decodes the response into result, if given.
+func postJSON(ctx context.Context, endpoint, token string, payload []byte, result interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s returned %s", endpoint, resp.Status)
+	}
+	if result != nil {
+		return json.NewDecoder(resp.Body).Decode(result)
+	}
+	return nil
+}
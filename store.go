@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// Store is the local database of user activity, kept as JSON in the data directory
+type Store struct {
+	TasbihSessions []TasbihSession `json:"tasbih_sessions"`
+	TimerSessions  []TimerSession  `json:"timer_sessions"`
+	Prayers        []PrayerLog     `json:"prayers"`
+	Badges         []EarnedBadge   `json:"badges"`
+	Tasks          []SyncedTask    `json:"tasks"`
+}
+
+// TasbihSession is one run of the tasbih counter
+type TasbihSession struct {
+	Started time.Time `json:"started"`
+	Ended   time.Time `json:"ended"`
+	Count   int       `json:"count"`
+	Target  int       `json:"target"`
+}
+
+// TimerSession is one timed prayer or athkar session
+type TimerSession struct {
+	User     string    `json:"user,omitempty"`
+	Activity string    `json:"activity"`
+	Started  time.Time `json:"started"`
+	Ended    time.Time `json:"ended"`
+	Target   int       `json:"target"` // minutes
+}
+
+// PrayerLog is one logged prayer. User is empty for the machine's own user and names
+// other household members, such as children logged by a parent.
+type PrayerLog struct {
+	User   string    `json:"user,omitempty"`
+	Date   string    `json:"date"` // YYYY-MM-DD
+	Prayer string    `json:"prayer"`
+	Status string    `json:"status"`
+	Logged time.Time `json:"logged"`
+}
+
+// EarnedBadge records when a user unlocked an achievement
+type EarnedBadge struct {
+	User   string    `json:"user,omitempty"`
+	ID     string    `json:"id"`
+	Earned time.Time `json:"earned"`
+}
+
+// dataDir follows XDG on Linux and falls back to the config directory elsewhere.
+func dataDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "pray"), nil
+	}
+
+	if runtime.GOOS == "darwin" || runtime.GOOS == "windows" {
+		return configDir()
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate data directory: %v", err)
+	}
+	return filepath.Join(home, ".local", "share", "pray"), nil
+}
+
+func storePath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "store.json"), nil
+}
+
+// loadStore reads the store, returning an empty one if it does not exist yet.
+func loadStore() (*Store, error) {
+	store := &Store{}
+
+	path, err := storePath()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store: %v", err)
+	}
+
+	if isEncryptedStore(raw) {
+		if raw, err = openStore(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := json.Unmarshal(raw, store); err != nil {
+		return nil, fmt.Errorf("failed to parse store %s: %v", path, err)
+	}
+	return store, nil
+}
+
+// storeMu serializes store writes within the process, from concurrent server requests and
+// the daemon; the store's lock file serializes them with other processes
+var storeMu sync.Mutex
+
+// updateStore loads the store, applies update, and saves it, holding the store locked
+// against other writers in this process and in others so that none of their changes are
+// lost. An error from update leaves the store as it was.
+func updateStore(update func(*Store) error) error {
+	path, err := storePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %v", err)
+	}
+
+	storeMu.Lock()
+	defer storeMu.Unlock()
+	unlock, err := lockStoreFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+	if err := update(store); err != nil {
+		return err
+	}
+	return store.save()
+}
+
+// save writes the store atomically so an interrupted write never corrupts it.
+func (s *Store) save() error {
+	path, err := storePath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %v", err)
+	}
+
+	raw, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode store: %v", err)
+	}
+	if storeEncryption.Enabled || storeEncryption.key != nil {
+		if raw, err = sealStore(raw); err != nil {
+			return fmt.Errorf("failed to encrypt store: %v", err)
+		}
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("failed to write store: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write store: %v", err)
+	}
+	return nil
+}
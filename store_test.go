@@ -0,0 +1,102 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+	"testing"
+)
+
+// useDataDir keeps the store in a temporary directory for the rest of a test.
+func useDataDir(t *testing.T) {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+}
+
+func TestUpdateStoreConcurrent(t *testing.T) {
+	useDataDir(t)
+	const writers = 20
+	var wg sync.WaitGroup
+	for range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := updateStore(func(store *Store) error {
+				store.TasbihSessions = append(store.TasbihSessions, TasbihSession{Count: 33})
+				return nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	store, err := loadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(store.TasbihSessions); got != writers {
+		t.Errorf("store has %d sessions, want %d", got, writers)
+	}
+}
+
+// TestStoreWriterProcess is run by TestUpdateStoreAcrossProcesses in processes of its own.
+func TestStoreWriterProcess(t *testing.T) {
+	if os.Getenv("PRAY_TEST_STORE_WRITER") == "" {
+		t.Skip("only run as a separate process")
+	}
+	for range 25 {
+		err := updateStore(func(store *Store) error {
+			store.TasbihSessions = append(store.TasbihSessions, TasbihSession{Count: 33})
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestUpdateStoreAcrossProcesses(t *testing.T) {
+	useDataDir(t)
+	const processes = 4
+	var cmds []*exec.Cmd
+	for range processes {
+		cmd := exec.Command(os.Args[0], "-test.run=^TestStoreWriterProcess$")
+		cmd.Env = append(os.Environ(), "PRAY_TEST_STORE_WRITER=1")
+		if err := cmd.Start(); err != nil {
+			t.Fatal(err)
+		}
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range cmds {
+		if err := cmd.Wait(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, err := loadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(store.TasbihSessions), processes*25; got != want {
+		t.Errorf("store has %d sessions, want %d", got, want)
+	}
+}
+
+func TestUpdateStoreError(t *testing.T) {
+	useDataDir(t)
+	if err := updateStore(func(store *Store) error {
+		store.TasbihSessions = append(store.TasbihSessions, TasbihSession{Count: 33})
+		return os.ErrInvalid
+	}); err != os.ErrInvalid {
+		t.Fatalf("updateStore = %v, want the update's error", err)
+	}
+	store, err := loadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(store.TasbihSessions) != 0 {
+		t.Errorf("store has %d sessions after a failed update, want none", len(store.TasbihSessions))
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/nacl/secretbox"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Encrypted stores start with this header, then the scrypt salt, the nonce, and the sealed JSON
+var storeMagic = []byte("pray-store-secretbox-v1\n")
+
+const (
+	storeSaltSize  = 16
+	storeNonceSize = 24
+)
+
+// storeEncryption is how the store is encrypted at rest, set from the config at startup.
+// The key comes from store_key_file, or else PRAY_STORE_PASSPHRASE, or else a prompt. A
+// store that was read encrypted is saved encrypted even when encrypt_store is off.
+var storeEncryption struct {
+	Enabled bool
+	KeyFile string
+
+	// Derived on first use, so commands that don't touch the store never ask
+	salt []byte
+	key  *[32]byte
+}
+
+// storeSecret reads the key file or passphrase the store key is derived from. A new
+// passphrase is asked for twice.
+func storeSecret(fresh bool) ([]byte, error) {
+	if path := storeEncryption.KeyFile; path != "" {
+		secret, err := os.ReadFile(expandHome(path))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read store key file: %v", err)
+		}
+		return bytes.TrimSpace(secret), nil
+	}
+	if passphrase := os.Getenv("PRAY_STORE_PASSPHRASE"); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return nil, fmt.Errorf("the store is encrypted: set PRAY_STORE_PASSPHRASE or store_key_file")
+	}
+	fmt.Fprint(os.Stderr, "🔒 Store passphrase: ")
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read passphrase: %v", err)
+	}
+	if len(passphrase) == 0 {
+		return nil, fmt.Errorf("empty passphrase")
+	}
+	if fresh {
+		fmt.Fprint(os.Stderr, "🔒 Repeat passphrase: ")
+		repeated, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %v", err)
+		}
+		if !bytes.Equal(passphrase, repeated) {
+			return nil, fmt.Errorf("passphrases don't match")
+		}
+	}
+	return passphrase, nil
+}
+
+// storeKey derives the secretbox key for a salt with scrypt, reusing the last key
+// when the salt is the same. fresh is set when encrypting the store for the first time.
+func storeKey(salt []byte, fresh bool) (*[32]byte, error) {
+	if storeEncryption.key != nil && bytes.Equal(storeEncryption.salt, salt) {
+		return storeEncryption.key, nil
+	}
+
+	secret, err := storeSecret(fresh)
+	if err != nil {
+		return nil, err
+	}
+	derived, err := scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive store key: %v", err)
+	}
+
+	key := new([32]byte)
+	copy(key[:], derived)
+	storeEncryption.salt, storeEncryption.key = salt, key
+	return key, nil
+}
+
+// isEncryptedStore reports whether raw store bytes are encrypted.
+func isEncryptedStore(raw []byte) bool {
+	return bytes.HasPrefix(raw, storeMagic)
+}
+
+// sealStore encrypts the store JSON, keeping the salt of the last key so it isn't
+// derived again.
+func sealStore(plain []byte) ([]byte, error) {
+	salt, fresh := storeEncryption.salt, storeEncryption.salt == nil
+	if fresh {
+		salt = make([]byte, storeSaltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+	}
+	key, err := storeKey(salt, fresh)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonce [storeNonceSize]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	out := append(append([]byte{}, storeMagic...), salt...)
+	out = append(out, nonce[:]...)
+	return secretbox.Seal(out, plain, &nonce, key), nil
+}
+
+// openStore decrypts an encrypted store.
+func openStore(raw []byte) ([]byte, error) {
+	raw = raw[len(storeMagic):]
+	if len(raw) < storeSaltSize+storeNonceSize+secretbox.Overhead {
+		return nil, fmt.Errorf("the encrypted store is truncated")
+	}
+	salt := append([]byte{}, raw[:storeSaltSize]...)
+	var nonce [storeNonceSize]byte
+	copy(nonce[:], raw[storeSaltSize:storeSaltSize+storeNonceSize])
+
+	key, err := storeKey(salt, false)
+	if err != nil {
+		return nil, err
+	}
+	plain, ok := secretbox.Open(nil, raw[storeSaltSize+storeNonceSize:], &nonce, key)
+	if !ok {
+		// Forget the key so the next attempt asks again
+		storeEncryption.salt, storeEncryption.key = nil, nil
+		return nil, fmt.Errorf("failed to decrypt the store: wrong passphrase or key file")
+	}
+	return plain, nil
+}
+
+func newStoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "Manage the local tracking store",
+	}
+
+	// Rewrite the store in the requested form straight away, rather than on the next save
+	rewrite := func(encrypt bool) error {
+		err := updateStore(func(store *Store) error {
+			storeEncryption.Enabled = encrypt
+			if !encrypt {
+				storeEncryption.salt, storeEncryption.key = nil, nil
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		path, err := storePath()
+		if err != nil {
+			return err
+		}
+		state := "decrypted"
+		if encrypt {
+			state = "encrypted"
+		}
+		fmt.Println(cityStyle.Render(fmt.Sprintf("🔒 %s is now %s", path, state)))
+		return nil
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "encrypt",
+		Short: "Encrypt the store now; it stays encrypted from then on",
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := rewrite(true); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	})
+	cmd.AddCommand(&cobra.Command{
+		Use:   "decrypt",
+		Short: "Write the store back as plain JSON",
+		Run: func(cmd *cobra.Command, args []string) {
+			if storeEncryption.Enabled {
+				fmt.Println("Error: encrypt_store is set, so the store would be encrypted again on the next save; unset it first")
+				os.Exit(1)
+			}
+			if err := rewrite(false); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	})
+	return cmd
+}
@@ -0,0 +1,41 @@
+//go:build !unix
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// A store lock older than this was left by a process that died, and is taken over. A write
+// holds it for a moment.
+const staleStoreLock = 30 * time.Second
+
+// lockStoreFile creates a lock file next to the store, waiting while another process
+// holds it.
+func lockStoreFile(path string) (func(), error) {
+	lock := path + ".lock"
+	var failed error
+	waitForLock(context.Background(), func() bool {
+		f, err := os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return true
+		}
+		if !os.IsExist(err) {
+			failed = err
+			return true
+		}
+		// Left by a process that died
+		if info, err := os.Stat(lock); err == nil && time.Since(info.ModTime()) > staleStoreLock {
+			os.Remove(lock)
+		}
+		return false
+	})
+	if failed != nil {
+		return nil, fmt.Errorf("failed to lock store: %v", failed)
+	}
+	return func() { os.Remove(lock) }, nil
+}
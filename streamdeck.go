@@ -0,0 +1,265 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// States of a pray streamdeck key, besides the logged statuses, for a plugin to pick an icon by
+const (
+	deckUpcoming = "upcoming" // not started yet
+	deckDue      = "due"      // started and not logged
+	deckSnoozed  = "snoozed"  // due, but snoozed
+	deckUnlogged = "unlogged" // its time has passed and it wasn't logged
+	deckNext     = "next"     // the countdown key
+)
+
+// How long a snooze lasts when the action doesn't say
+const defaultDeckSnooze = 10 * time.Minute
+
+// deckKey is one button: keys 0 to 4 are the five prayers, key 5 the countdown to the next
+type deckKey struct {
+	Key    int    `json:"key"`
+	Prayer string `json:"prayer"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+}
+
+// deckMessage is one line of output: the keys, or what went wrong with an action
+type deckMessage struct {
+	Keys   []deckKey `json:"keys,omitempty"`
+	Error  string    `json:"error,omitempty"`
+	Badges []string  `json:"badges,omitempty"`
+}
+
+// deckAction is one line of input, a button press. The prayer is given by its key or name.
+type deckAction struct {
+	Action  string `json:"action"`
+	Key     *int   `json:"key"`
+	Prayer  string `json:"prayer"`
+	Status  string `json:"status"`
+	Minutes int    `json:"minutes"`
+}
+
+// streamDeck is pray streamdeck: it writes every key's title and state as a line of JSON
+// at start, every minute, and after each action, and reads actions one per line:
+//
+//	{"action": "log", "key": 2}                    log Asr as prayed (or "status": "late")
+//	{"action": "snooze", "key": 2, "minutes": 10}  show a due prayer as snoozed for a while
+//
+// so a Stream Deck or macro pad plugin only has to map states to icons and presses to lines.
+type streamDeck struct {
+	city    string
+	country string
+	method  int
+	user    string
+	cfg     Config
+
+	timings Timings
+	snoozed map[string]time.Time
+	out     *json.Encoder
+}
+
+func runStreamDeck(ctx context.Context, city, country string, method int, user string, cfg Config, in io.Reader, out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	d := &streamDeck{city: city, country: country, method: method, user: normalizeUser(user), cfg: cfg, snoozed: map[string]time.Time{}, out: enc}
+
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	d.timings = data.Data.Timings
+
+	actions := make(chan string)
+	scanErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(in)
+		for scanner.Scan() {
+			select {
+			case actions <- strings.TrimSpace(scanner.Text()):
+			case <-ctx.Done():
+				return
+			}
+		}
+		scanErr <- scanner.Err()
+	}()
+
+	d.sendKeys()
+	for {
+		now := clock.Now()
+		minute := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		select {
+		case <-ctx.Done():
+			minute.Stop()
+			return nil
+		// The plugin closing standard input is the end of the session
+		case err := <-scanErr:
+			minute.Stop()
+			return err
+		case line := <-actions:
+			minute.Stop()
+			if line == "" {
+				continue
+			}
+			if err := d.handle(line); err != nil {
+				d.send(deckMessage{Error: err.Error()})
+			}
+			d.sendKeys()
+		case <-minute.C:
+			// Timings come from the response cache, so only a new day costs a request
+			if data, err := fetchPrayerTimes(ctx, d.city, d.country, d.method, d.cfg); err == nil {
+				d.timings = data.Data.Timings
+			}
+			d.sendKeys()
+		}
+	}
+}
+
+func (d *streamDeck) handle(line string) error {
+	var action deckAction
+	if err := json.Unmarshal([]byte(line), &action); err != nil {
+		return fmt.Errorf("invalid action: %v", err)
+	}
+	prayer, err := d.actionPrayer(action)
+	if err != nil {
+		return err
+	}
+
+	switch action.Action {
+	case "log":
+		status := action.Status
+		if status == "" {
+			status = statusPrayed
+		}
+		if status != statusPrayed && status != statusLate && status != statusMissed {
+			return fmt.Errorf("unknown status %q (expected prayed, late, or missed)", status)
+		}
+		badges, err := d.logPrayer(prayer, status, clock.Now())
+		if err != nil {
+			return err
+		}
+		delete(d.snoozed, prayer)
+		if len(badges) > 0 {
+			d.send(deckMessage{Badges: badges})
+		}
+	case "snooze":
+		snooze := defaultDeckSnooze
+		if action.Minutes < 0 {
+			return fmt.Errorf("invalid minutes %d", action.Minutes)
+		} else if action.Minutes > 0 {
+			snooze = time.Duration(action.Minutes) * time.Minute
+		}
+		d.snoozed[prayer] = clock.Now().Add(snooze)
+	default:
+		return fmt.Errorf("unknown action %q (expected log or snooze)", action.Action)
+	}
+	return nil
+}
+
+// actionPrayer is the prayer an action is for, by key or by name.
+func (d *streamDeck) actionPrayer(action deckAction) (string, error) {
+	if action.Key != nil {
+		if *action.Key < 0 || *action.Key >= len(trackedPrayers) {
+			return "", fmt.Errorf("invalid key %d (expected 0 to %d)", *action.Key, len(trackedPrayers)-1)
+		}
+		return trackedPrayers[*action.Key], nil
+	}
+	if action.Prayer == "" {
+		return "", fmt.Errorf("expected a key or a prayer")
+	}
+	return canonicalPrayer(action.Prayer)
+}
+
+// logPrayer logs a prayer like pray log, returning the badges it unlocks. They're sent
+// to the plugin rather than printed, which would break the output.
+func (d *streamDeck) logPrayer(prayer, status string, day time.Time) ([]string, error) {
+	var unlocked []achievement
+	err := updateStore(func(store *Store) error {
+		store.logPrayer(d.user, prayer, status, day)
+		unlocked = store.unlockAchievements(d.user, clock.Now())
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var badges []string
+	for _, a := range unlocked {
+		badges = append(badges, fmt.Sprintf("%s %s", a.Badge, a.Name))
+	}
+	return badges, nil
+}
+
+// sendKeys reads the store again each time, so prayers logged with pray log show up too.
+func (d *streamDeck) sendKeys() {
+	store, err := loadStore()
+	if err != nil {
+		d.send(deckMessage{Error: err.Error()})
+		return
+	}
+	keys, err := deckKeys(d.timings, store, d.user, d.snoozed, clock.Now())
+	if err != nil {
+		d.send(deckMessage{Error: err.Error()})
+		return
+	}
+	d.send(deckMessage{Keys: keys})
+}
+
+func (d *streamDeck) send(msg deckMessage) {
+	d.out.Encode(msg)
+}
+
+// deckKeys is today's five prayers with their time and state, then the next prayer with
+// its countdown.
+func deckKeys(timings Timings, store *Store, user string, snoozed map[string]time.Time, now time.Time) ([]deckKey, error) {
+	current, start, _, err := currentPrayerAt(timings, now)
+	if err != nil {
+		return nil, err
+	}
+	next, at, err := findNextPrayerAt(timings, now)
+	if err != nil {
+		return nil, err
+	}
+	prayerTimes := map[string]string{
+		"Fajr":    timings.Fajr,
+		"Dhuhr":   timings.Dhuhr,
+		"Asr":     timings.Asr,
+		"Maghrib": timings.Maghrib,
+		"Isha":    timings.Isha,
+	}
+
+	keys := make([]deckKey, 0, len(trackedPrayers)+1)
+	for i, prayer := range trackedPrayers {
+		t, err := parseTimeOn(prayerTimes[prayer], now)
+		if err != nil {
+			return nil, err
+		}
+		state := store.prayerStatus(user, prayer, now)
+		switch {
+		case state != "":
+		// Before Fajr the current prayer is last night's Isha, not today's
+		case prayer == current && dayStart(start).Equal(dayStart(now)):
+			state = deckDue
+			if snoozed[prayer].After(now) {
+				state = deckSnoozed
+			}
+		case t.After(now):
+			state = deckUpcoming
+		default:
+			state = deckUnlogged
+		}
+		keys = append(keys, deckKey{Key: i, Prayer: prayer, Title: prayer + "\n" + t.Format("15:04"), State: state})
+	}
+	keys = append(keys, deckKey{
+		Key:    len(trackedPrayers),
+		Prayer: next,
+		Title:  next + "\n-" + glanceCountdown(at.Sub(now)),
+		State:  deckNext,
+	})
+	return keys, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// The interpolated declination stays within a thousandth of a degree of the direct
+// calculation over the whole day either side of noon, in any season and at any longitude.
+func TestSolarDayDeclination(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var worst float64
+	for day := 0; day < 366; day += 5 {
+		date := start.AddDate(0, 0, day)
+		for longitude := -180.0; longitude <= 180; longitude += 45 {
+			s := newSolarDay(date, longitude)
+			for minutes := -12 * 60; minutes <= 12*60; minutes += 30 {
+				at := s.meanNoon.Add(time.Duration(minutes) * time.Minute)
+				direct, _ := solarPosition(at)
+				worst = math.Max(worst, math.Abs(s.declination(at)-direct))
+			}
+		}
+	}
+	if worst > 0.001 {
+		t.Errorf("interpolated declination is off by up to %.5f°, want at most 0.001°", worst)
+	}
+}
+
+// At the March equinox on the equator the sun rises and sets six hours either side of
+// noon, plus the few minutes refraction and the sun's disc add to the day, and noon is
+// early by the equation of time, about 7 minutes.
+func TestSunAtAltitude(t *testing.T) {
+	date := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	rise, okRise := sunAtAltitude(date, 0, 0, sunriseAltitude, true)
+	set, okSet := sunAtAltitude(date, 0, 0, sunriseAltitude, false)
+	if !okRise || !okSet {
+		t.Fatal("no sunrise or sunset on the equator")
+	}
+	noon := solarNoon(date, 0)
+
+	if got := noon.Format("15:04"); got != "12:07" {
+		t.Errorf("noon = %s, want 12:07", got)
+	}
+	if day := set.Sub(rise); day < 12*time.Hour+5*time.Minute || day > 12*time.Hour+9*time.Minute {
+		t.Errorf("day length = %s, want 12h05m-12h09m", day)
+	}
+	if skew := rise.Sub(noon) + set.Sub(noon); skew < -time.Minute || skew > time.Minute {
+		t.Errorf("sunrise and sunset are %s off symmetric about noon", skew)
+	}
+
+	// A degree east is four minutes earlier
+	if d := solarNoon(date, 0).Sub(solarNoon(date, 15)); d < 59*time.Minute || d > 61*time.Minute {
+		t.Errorf("noon 15° east is %s earlier, want an hour", d)
+	}
+
+	// The sun doesn't set at midsummer in Tromsø
+	if _, ok := sunAtAltitude(time.Date(2025, 6, 21, 12, 0, 0, 0, time.UTC), 69.65, 18.96, sunriseAltitude, false); ok {
+		t.Error("the sun sets at midsummer in Tromsø, want no sunset")
+	}
+}
+
+// BenchmarkSolarDay works out sunrise and sunset for a year in each of a thousand cities.
+func BenchmarkSolarDay(b *testing.B) {
+	type city struct{ latitude, longitude float64 }
+	cities := make([]city, 1000)
+	for i := range cities {
+		// Spread over the inhabited latitudes and every longitude
+		cities[i] = city{latitude: -55 + float64(i%111), longitude: -180 + float64(i*360/len(cities))}
+	}
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for b.Loop() {
+		for _, c := range cities {
+			for day := 0; day < 365; day++ {
+				s := newSolarDay(start.AddDate(0, 0, day), c.longitude)
+				s.at(c.latitude, sunriseAltitude, true)
+				s.at(c.latitude, sunriseAltitude, false)
+			}
+		}
+	}
+}
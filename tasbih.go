@@ -0,0 +1,162 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// Number of past sessions shown by --history
+const tasbihHistoryLimit = 10
+
+func newTasbihCmd() *cobra.Command {
+	var target int
+	var history bool
+
+	cmd := &cobra.Command{
+		Use:   "tasbih",
+		Short: "Interactive tasbih counter",
+		Long:  "Count dhikr by pressing any key. The terminal bell rings each time the target is reached. Backspace undoes a count; q or Esc finishes and saves the session.",
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			if history {
+				err = showTasbihHistory()
+			} else {
+				err = runTasbih(target)
+			}
+
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&target, "target", 33, "Count at which to ring the bell (e.g. 33 or 99)")
+	cmd.Flags().BoolVar(&history, "history", false, "Show past tasbih sessions")
+	return cmd
+}
+
+func runTasbih(target int) error {
+	if target <= 0 {
+		return fmt.Errorf("target must be positive, got %d", target)
+	}
+
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("tasbih needs an interactive terminal")
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	session := TasbihSession{Started: time.Now(), Target: target}
+
+	// Raw mode disables output processing, so lines end with \r\n
+	fmt.Print(titleStyle.Render("📿 Tasbih") + "\r\n")
+	fmt.Print(prayerStyle.Render("any key: count · backspace: undo · q/esc: finish") + "\r\n\r\n")
+	renderTasbih(session.Count, target)
+
+	buf := make([]byte, 8)
+	for {
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			break
+		}
+
+		key := buf[0]
+		if n == 1 && (key == 'q' || key == 'Q' || key == 27 || key == 3 || key == 4) {
+			break
+		}
+
+		if n == 1 && (key == 127 || key == 8) {
+			if session.Count > 0 {
+				session.Count--
+			}
+		} else {
+			session.Count++
+			if session.Count%target == 0 {
+				fmt.Print("\a")
+			}
+		}
+		renderTasbih(session.Count, target)
+	}
+	fmt.Print("\r\n")
+	term.Restore(fd, state)
+
+	session.Ended = time.Now()
+	if session.Count == 0 {
+		return nil
+	}
+
+	err = updateStore(func(store *Store) error {
+		store.TasbihSessions = append(store.TasbihSessions, session)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("Saved %d in %s", session.Count, formatDurationTo(session.Ended.Sub(session.Started), time.Second))))
+	return nil
+}
+
+func renderTasbih(count, target int) {
+	round := count/target + 1
+	progress := count % target
+	if count > 0 && progress == 0 {
+		round--
+		progress = target
+	}
+
+	line := fmt.Sprintf("%s %s / %d   round %d   total %d",
+		emojiStyle.Render("📿"),
+		timeStyle.Render(fmt.Sprintf("%3d", progress)),
+		target, round, count)
+
+	// Redraw in place and clear the rest of the line
+	fmt.Print("\r" + prayerStyle.Render(line) + "\x1b[K")
+}
+
+func showTasbihHistory() error {
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("📿 Tasbih History"))
+	fmt.Println(strings.Repeat("━", 50))
+
+	sessions := store.TasbihSessions
+	if len(sessions) == 0 {
+		fmt.Println(prayerStyle.Render("No sessions yet — start one with `pray tasbih`"))
+		return nil
+	}
+
+	if len(sessions) > tasbihHistoryLimit {
+		sessions = sessions[len(sessions)-tasbihHistoryLimit:]
+	}
+
+	total := 0
+	for i := len(sessions) - 1; i >= 0; i-- {
+		s := sessions[i]
+		total += s.Count
+		line := fmt.Sprintf("%-18s %s  (target %d, %s)",
+			s.Started.Format("02 Jan 2006 15:04"),
+			timeStyle.Render(fmt.Sprintf("%5d", s.Count)),
+			s.Target,
+			formatDurationTo(s.Ended.Sub(s.Started), time.Second))
+		fmt.Println(prayerStyle.Render(line))
+	}
+
+	fmt.Println()
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("Total: %d", total)))
+	return nil
+}
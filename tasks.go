@@ -0,0 +1,323 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// To-do apps pray tasks sync can keep prayer tasks in
+const (
+	tasksTaskwarrior = "taskwarrior"
+	tasksTodoist     = "todoist"
+)
+
+const todoistTasksURL = "https://api.todoist.com/api/v1/tasks"
+
+// Tag or label the prayer tasks carry, so they're easy to filter
+const prayerTaskTag = "prayer"
+
+// TasksConfig is the to-do app pray tasks sync adds a task to for each prayer
+type TasksConfig struct {
+	// taskwarrior or todoist
+	Backend string `yaml:"backend"`
+	// Taskwarrior project name, or Todoist project ID; empty for the inbox
+	Project      string `yaml:"project"`
+	TodoistToken string `yaml:"todoist_token"`
+}
+
+// SyncedTask is a prayer task created in a to-do app, kept so it isn't created twice
+// and can be completed when the prayer is logged
+type SyncedTask struct {
+	Backend string `json:"backend"`
+	ID      string `json:"id"`
+	Date    string `json:"date"` // YYYY-MM-DD
+	Prayer  string `json:"prayer"`
+	Done    bool   `json:"done,omitempty"`
+}
+
+// taskBackend adds and completes tasks in a to-do app
+type taskBackend interface {
+	addTask(ctx context.Context, title string, due time.Time) (string, error)
+	completeTask(ctx context.Context, id string) error
+}
+
+func newTaskBackend(cfg TasksConfig) (taskBackend, error) {
+	switch cfg.Backend {
+	case tasksTaskwarrior:
+		return taskwarrior{project: cfg.Project}, nil
+	case tasksTodoist:
+		if cfg.TodoistToken == "" {
+			return nil, fmt.Errorf("tasks.todoist_token is required for Todoist")
+		}
+		return todoist{token: cfg.TodoistToken, project: cfg.Project}, nil
+	case "":
+		return nil, fmt.Errorf("no to-do app set; set tasks.backend to taskwarrior or todoist")
+	}
+	return nil, fmt.Errorf("unknown tasks.backend %q (expected taskwarrior or todoist)", cfg.Backend)
+}
+
+// taskwarrior adds tasks through the task command, importing them with a UUID chosen
+// here so they can be found again.
+type taskwarrior struct {
+	project string
+}
+
+func (t taskwarrior) addTask(ctx context.Context, title string, due time.Time) (string, error) {
+	id, err := newUUID()
+	if err != nil {
+		return "", err
+	}
+	task := map[string]interface{}{
+		"uuid":        id,
+		"description": title,
+		"status":      "pending",
+		"entry":       time.Now().UTC().Format("20060102T150405Z"),
+		"due":         due.UTC().Format("20060102T150405Z"),
+		"tags":        []string{prayerTaskTag},
+	}
+	if t.project != "" {
+		task["project"] = t.project
+	}
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return "", err
+	}
+	cmd := exec.CommandContext(ctx, "task", "rc.confirmation=off", "rc.verbose=nothing", "import")
+	cmd.Stdin = strings.NewReader(string(payload) + "\n")
+	if err := runScheduler(cmd); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+func (t taskwarrior) completeTask(ctx context.Context, id string) error {
+	return runScheduler(exec.CommandContext(ctx, "task", "rc.confirmation=off", "rc.verbose=nothing", id, "done"))
+}
+
+// todoist adds tasks through the Todoist API with a personal token.
+type todoist struct {
+	token   string
+	project string
+}
+
+func (t todoist) addTask(ctx context.Context, title string, due time.Time) (string, error) {
+	task := map[string]interface{}{
+		"content":      title,
+		"due_datetime": due.UTC().Format(time.RFC3339),
+		"labels":       []string{prayerTaskTag},
+	}
+	if t.project != "" {
+		task["project_id"] = t.project
+	}
+	payload, err := json.Marshal(task)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := postJSON(ctx, todoistTasksURL, t.token, payload, &result); err != nil {
+		return "", fmt.Errorf("failed to add Todoist task: %v", err)
+	}
+	return result.ID, nil
+}
+
+func (t todoist) completeTask(ctx context.Context, id string) error {
+	if err := postJSON(ctx, todoistTasksURL+"/"+id+"/close", t.token, nil, nil); err != nil {
+		return fmt.Errorf("failed to complete Todoist task: %v", err)
+	}
+	return nil
+}
+
+// newUUID makes a random version 4 UUID.
+func newUUID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// syncedTask finds the task for a prayer on a day in a to-do app.
+func (s *Store) syncedTask(backend, date, prayer string) *SyncedTask {
+	for i, t := range s.Tasks {
+		if t.Backend == backend && t.Date == date && t.Prayer == prayer {
+			return &s.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// completesTask reports whether a logged prayer completes its task: only the machine's own
+// user has tasks, and only a prayer prayed on time or late completes one.
+func completesTask(entry PrayerLog) bool {
+	return entry.User == "" && (entry.Status == statusPrayed || entry.Status == statusLate)
+}
+
+// loggedTask is the open task a prayer just logged completes, when tasks are set up.
+func (s *Store) loggedTask(cfg TasksConfig, entry PrayerLog) *SyncedTask {
+	if cfg.Backend == "" || !completesTask(entry) {
+		return nil
+	}
+	task := s.syncedTask(cfg.Backend, entry.Date, entry.Prayer)
+	if task == nil || task.Done {
+		return nil
+	}
+	open := *task
+	return &open
+}
+
+// completeLoggedTask completes a logged prayer's task in the to-do app, then marks it done
+// in the store. It runs once the log is saved, and the store is only locked to mark the
+// task, so a slow to-do app doesn't hold up other writes.
+func completeLoggedTask(ctx context.Context, cfg TasksConfig, task *SyncedTask) error {
+	if task == nil {
+		return nil
+	}
+	backend, err := newTaskBackend(cfg)
+	if err != nil {
+		return err
+	}
+	if err := backend.completeTask(ctx, task.ID); err != nil {
+		return err
+	}
+
+	return updateStore(func(store *Store) error {
+		if done := store.syncedTask(task.Backend, task.Date, task.Prayer); done != nil {
+			done.Done = true
+		}
+		return nil
+	})
+}
+
+// syncTasks adds a task for each prayer over the next days, due at its time, and completes
+// the tasks of prayers logged since the last sync. A to-do app's own recurrence can't follow
+// prayer times as they shift through the year, so each day's tasks are their own.
+func syncTasks(ctx context.Context, days int, city, country string, method int, cfg Config) error {
+	backend, err := newTaskBackend(cfg.Tasks)
+	if err != nil {
+		return err
+	}
+	if days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	// Work from a copy of the store, so it isn't locked while the API and the to-do app
+	// answer, and then record what was done
+	snapshot, err := loadStore()
+	if err != nil {
+		return err
+	}
+	var done taskSync
+	syncErr := done.run(ctx, snapshot, backend, days, city, country, method, cfg)
+	// Record what was done even when a later task fails, so it isn't added twice
+	if len(done.completed)+len(done.added) > 0 {
+		if err := updateStore(done.record); err != nil {
+			return err
+		}
+	}
+	if syncErr != nil {
+		return syncErr
+	}
+
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("%d added, %d completed in %s", len(done.added), len(done.completed), cfg.Tasks.Backend)))
+	return nil
+}
+
+// taskSync is what a sync did in the to-do app, to record in the store
+type taskSync struct {
+	completed []SyncedTask
+	added     []SyncedTask
+}
+
+// run completes the tasks of prayers logged since the last sync and adds the missing ones
+// for the next days, going by a snapshot of the store. What it did before any error is
+// kept for record.
+func (t *taskSync) run(ctx context.Context, snapshot *Store, backend taskBackend, days int, city, country string, method int, cfg Config) error {
+	for _, entry := range snapshot.Prayers {
+		if !completesTask(entry) {
+			continue
+		}
+		task := snapshot.syncedTask(cfg.Tasks.Backend, entry.Date, entry.Prayer)
+		if task == nil || task.Done {
+			continue
+		}
+		if err := backend.completeTask(ctx, task.ID); err != nil {
+			return err
+		}
+		t.completed = append(t.completed, *task)
+	}
+
+	today := clock.Now()
+	for i := 0; i < days; i++ {
+		day := addDays(today, i)
+		var data *PrayerTimesResponse
+		var err error
+		if i == 0 {
+			data, err = fetchPrayerTimes(ctx, city, country, method, cfg)
+		} else {
+			data, err = fetchPrayerTimesOn(ctx, day, city, country, method, cfg)
+		}
+		if err != nil {
+			return err
+		}
+		loc, err := time.LoadLocation(data.Data.Meta.Timezone)
+		if err != nil {
+			loc = time.Local
+		}
+		date, err := time.ParseInLocation("02-01-2006", data.Data.Date.Gregorian.Date, loc)
+		if err != nil {
+			return fmt.Errorf("unexpected date %q from the API", data.Data.Date.Gregorian.Date)
+		}
+
+		timings := map[string]string{
+			"Fajr":    data.Data.Timings.Fajr,
+			"Dhuhr":   data.Data.Timings.Dhuhr,
+			"Asr":     data.Data.Timings.Asr,
+			"Maghrib": data.Data.Timings.Maghrib,
+			"Isha":    data.Data.Timings.Isha,
+		}
+		for _, prayer := range trackedPrayers {
+			if snapshot.syncedTask(cfg.Tasks.Backend, date.Format("2006-01-02"), prayer) != nil {
+				continue
+			}
+			if status := snapshot.prayerStatus("", prayer, date); status == statusPrayed || status == statusLate {
+				continue
+			}
+			due, err := parseTimeOn(timings[prayer], date)
+			if err != nil {
+				return err
+			}
+			id, err := backend.addTask(ctx, "Pray "+prayer, due)
+			if err != nil {
+				return err
+			}
+			t.added = append(t.added, SyncedTask{Backend: cfg.Tasks.Backend, ID: id, Date: date.Format("2006-01-02"), Prayer: prayer})
+			fmt.Println(prayerStyle.Render(fmt.Sprintf("📝 Pray %s, due %s", prayer, due.Format("Mon 02 Jan 15:04"))))
+		}
+	}
+	return nil
+}
+
+// record marks the completed tasks done in the store and adds the new ones, unless a sync
+// running at the same time recorded a task for the same prayer first.
+func (t *taskSync) record(store *Store) error {
+	for _, task := range t.completed {
+		if synced := store.syncedTask(task.Backend, task.Date, task.Prayer); synced != nil {
+			synced.Done = true
+		}
+	}
+	for _, task := range t.added {
+		if store.syncedTask(task.Backend, task.Date, task.Prayer) == nil {
+			store.Tasks = append(store.Tasks, task)
+		}
+	}
+	return nil
+}
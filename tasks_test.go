@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fakeTasks is a to-do app that runs a callback while a task is being added, standing in
+// for a slow request during which the store is written elsewhere.
+type fakeTasks struct {
+	adding    func()
+	added     int
+	completed []string
+}
+
+func (f *fakeTasks) addTask(ctx context.Context, title string, due time.Time) (string, error) {
+	if f.adding != nil {
+		f.adding()
+	}
+	f.added++
+	return fmt.Sprintf("task-%d", f.added), nil
+}
+
+func (f *fakeTasks) completeTask(ctx context.Context, id string) error {
+	f.completed = append(f.completed, id)
+	return nil
+}
+
+func TestTaskSyncWithoutLock(t *testing.T) {
+	useDataDir(t)
+	useClock(t, newFakeClock(time.Date(2024, 3, 1, 3, 0, 0, 0, time.UTC)))
+	day := Data{
+		Timings: Timings{Fajr: "04:58 (+03)", Sunrise: "06:16 (+03)", Dhuhr: "12:07 (+03)", Asr: "15:26 (+03)", Maghrib: "17:56 (+03)", Isha: "19:26 (+03)"},
+		Date:    Date{Gregorian: Gregorian{Date: "01-03-2024"}},
+		Meta:    Meta{Timezone: "Asia/Riyadh"},
+	}
+	body, err := json.Marshal(PrayerTimesResponse{Code: 200, Status: "OK", Data: day})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := useAPI(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	}))
+	cfg.Tasks = TasksConfig{Backend: tasksTodoist}
+
+	// Yesterday's Isha was prayed after its task was synced
+	err = updateStore(func(store *Store) error {
+		store.Tasks = append(store.Tasks, SyncedTask{Backend: tasksTodoist, ID: "old", Date: "2024-02-29", Prayer: "Isha"})
+		store.Prayers = append(store.Prayers, PrayerLog{Date: "2024-02-29", Prayer: "Isha", Status: statusPrayed})
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Log a prayer while the sync is adding a task; it would block for good if the sync
+	// held the store
+	logged := false
+	backend := &fakeTasks{adding: func() {
+		if logged {
+			return
+		}
+		logged = true
+		saved := make(chan error, 1)
+		go func() {
+			_, _, _, err := savePrayerLog("", "Fajr", statusLate, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), cfg.Tasks)
+			saved <- err
+		}()
+		select {
+		case err := <-saved:
+			if err != nil {
+				t.Error(err)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("logging a prayer waited on the sync")
+		}
+	}}
+
+	snapshot, err := loadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var done taskSync
+	if err := done.run(context.Background(), snapshot, backend, 1, "Riyadh", "SA", 4, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := updateStore(done.record); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(backend.completed) != 1 || backend.completed[0] != "old" {
+		t.Errorf("completed %v, want the old Isha task", backend.completed)
+	}
+	store, err := loadStore()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task := store.syncedTask(tasksTodoist, "2024-02-29", "Isha"); task == nil || !task.Done {
+		t.Errorf("the old Isha task = %+v, want it done", task)
+	}
+	if got := len(store.Tasks); got != 6 {
+		t.Errorf("store has %d tasks, want the old one and 5 added", got)
+	}
+	if status := store.prayerStatus("", "Fajr", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)); status != statusLate {
+		t.Errorf("Fajr logged during the sync has status %q, want it kept as late", status)
+	}
+}
+
+func TestTaskSyncRecordKeepsFirst(t *testing.T) {
+	store := &Store{Tasks: []SyncedTask{{Backend: tasksTodoist, ID: "other", Date: "2024-03-01", Prayer: "Fajr"}}}
+	done := taskSync{added: []SyncedTask{
+		{Backend: tasksTodoist, ID: "mine", Date: "2024-03-01", Prayer: "Fajr"},
+		{Backend: tasksTodoist, ID: "mine-dhuhr", Date: "2024-03-01", Prayer: "Dhuhr"},
+	}}
+	if err := done.record(store); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.Tasks) != 2 || store.Tasks[0].ID != "other" || store.Tasks[1].ID != "mine-dhuhr" {
+		t.Errorf("tasks = %+v, want the Fajr task already recorded and the new Dhuhr one", store.Tasks)
+	}
+}
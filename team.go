@@ -0,0 +1,149 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Office is a team location in locations.yaml
+type Office struct {
+	Name    string `yaml:"name"`
+	City    string `yaml:"city"`
+	Country string `yaml:"country"`
+	Method  int    `yaml:"method"`
+}
+
+// roster is the shape of locations.yaml
+type roster struct {
+	Offices []Office `yaml:"offices"`
+}
+
+// rosterPath is locations.yaml next to the config file.
+func rosterPath() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "locations.yaml"), nil
+}
+
+// loadRoster reads the offices, filling in the country and method left out.
+func loadRoster(path, country string, method int) ([]Office, error) {
+	if path == "" {
+		var err error
+		if path, err = rosterPath(); err != nil {
+			return nil, err
+		}
+	}
+	raw, err := os.ReadFile(expandHome(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("no team roster at %s; list the offices there under offices:", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read roster: %v", err)
+	}
+
+	var r roster
+	if err := yaml.Unmarshal(raw, &r); err != nil {
+		return nil, fmt.Errorf("failed to parse roster %s: %v", path, err)
+	}
+	if len(r.Offices) == 0 {
+		return nil, fmt.Errorf("the roster %s lists no offices", path)
+	}
+	for i, o := range r.Offices {
+		if o.City == "" {
+			return nil, fmt.Errorf("offices[%d] (%s) has no city", i, o.Name)
+		}
+		if o.Name == "" {
+			r.Offices[i].Name = o.City
+		}
+		if o.Country == "" {
+			r.Offices[i].Country = country
+		}
+		if o.Method == 0 {
+			r.Offices[i].Method = method
+		}
+	}
+	return r.Offices, nil
+}
+
+// officeTimes is an office's timings for its own today, with the time there
+type officeTimes struct {
+	data *PrayerTimesResponse
+	now  time.Time
+	err  error
+}
+
+// fetchOfficeTimes fetches an office's timings. Today is the office's date, which can be
+// a day either side of ours, so the timings are fetched again when it differs.
+func fetchOfficeTimes(ctx context.Context, o Office, cfg Config) officeTimes {
+	data, err := fetchPrayerTimes(ctx, o.City, o.Country, o.Method, cfg)
+	if err != nil {
+		return officeTimes{err: err}
+	}
+	now := cityNow(data.Data)
+	if now.Format("02-01-2006") != data.Data.Date.Gregorian.Date {
+		if data, err = fetchPrayerTimesOn(ctx, now, o.City, o.Country, o.Method, cfg); err != nil {
+			return officeTimes{err: err}
+		}
+	}
+	return officeTimes{data: data, now: now}
+}
+
+// showTeam prints the next prayer at each office, in the office's time and ours, for
+// scheduling meetings around prayers.
+func showTeam(ctx context.Context, path, country string, method int, cfg Config) error {
+	offices, err := loadRoster(path, country, method)
+	if err != nil {
+		return err
+	}
+
+	results := make([]officeTimes, len(offices))
+	var wg sync.WaitGroup
+	for i, o := range offices {
+		wg.Add(1)
+		go func(i int, o Office) {
+			defer wg.Done()
+			results[i] = fetchOfficeTimes(ctx, o, cfg)
+		}(i, o)
+	}
+	wg.Wait()
+
+	fmt.Println(titleStyle.Render("🏢 Team prayer times"))
+	fmt.Println(strings.Repeat("━", 70))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%-18s %-7s %-16s %-9s %s", "Office", "Local", "Next prayer", "In", "Your time")))
+
+	for i, o := range offices {
+		name := cityStyle.Render(padRight(o.Name, 18))
+		r := results[i]
+		if r.err != nil {
+			fmt.Printf("  %s %s\n", name, countdownStyle.Render("⚠️  "+r.err.Error()))
+			continue
+		}
+
+		prayer, at, err := findNextPrayerAt(r.data.Data.Timings, r.now)
+		if err != nil {
+			fmt.Printf("  %s %s\n", name, countdownStyle.Render("⚠️  "+err.Error()))
+			continue
+		}
+		yours := at.Local().Format("15:04")
+		if daysBetween(clock.Now(), at.Local()) != 0 {
+			yours += " " + at.Local().Format("Mon")
+		}
+		fmt.Printf("  %s %s %s %s %s\n",
+			name,
+			timeStyle.Render(fmt.Sprintf("%-7s", r.now.Format("15:04"))),
+			cityStyle.Render(padRight(fmt.Sprintf("%s %s", prayerNames[prayer], at.Format("15:04")), 16)),
+			countdownStyle.Render(fmt.Sprintf("%-9s", formatDuration(at.Sub(r.now)))),
+			timeStyle.Render(yours))
+	}
+	return nil
+}
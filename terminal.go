@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// Escape sequences the terminal notifier can write
+const (
+	terminalOSC9   = "osc9"   // iTerm2, WezTerm, Windows Terminal, Ghostty
+	terminalOSC777 = "osc777" // foot, urxvt, and VTE terminals such as GNOME Terminal
+	terminalKitty  = "kitty"  // kitty's OSC 99
+)
+
+// terminalNotifier shows reminders with the terminal's own notification escape sequence,
+// written to the controlling terminal. The terminal turns it into a desktop notification
+// on the machine it runs on, so it works over SSH, where notify-send can't reach a desktop.
+type terminalNotifier struct {
+	format string
+}
+
+func newTerminalNotifier(format string) (terminalNotifier, error) {
+	switch format {
+	case "", "auto":
+		format = terminalOSC9
+		// TERM survives SSH, unlike KITTY_WINDOW_ID
+		if os.Getenv("TERM") == "xterm-kitty" || os.Getenv("KITTY_WINDOW_ID") != "" {
+			format = terminalKitty
+		}
+	case terminalOSC9, terminalOSC777, terminalKitty:
+	default:
+		return terminalNotifier{}, fmt.Errorf("unknown terminal_notify %q (expected auto, osc9, osc777, or kitty)", format)
+	}
+	return terminalNotifier{format: format}, nil
+}
+
+func (terminalNotifier) name() string { return "terminal" }
+
+func (t terminalNotifier) notify(ctx context.Context, n notification) error {
+	seq := terminalSequence(t.format, n.Title, n.Body)
+	// tmux passes an escape sequence on to the terminal only when wrapped, with allow-passthrough on
+	if os.Getenv("TMUX") != "" {
+		seq = "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	}
+
+	tty, err := openTerminal()
+	if err != nil {
+		return fmt.Errorf("the terminal notifier needs a terminal: %v", err)
+	}
+	defer tty.Close()
+	if _, err := tty.WriteString(seq); err != nil {
+		return fmt.Errorf("failed to write to the terminal: %v", err)
+	}
+	return nil
+}
+
+// openTerminal opens the controlling terminal, which still works when the daemon's output
+// goes to a log file.
+func openTerminal() (*os.File, error) {
+	if runtime.GOOS == "windows" {
+		return os.OpenFile("CONOUT$", os.O_WRONLY, 0)
+	}
+	return os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+}
+
+// terminalSequence is the escape sequence for a notification in a format.
+func terminalSequence(format, title, body string) string {
+	title, body = terminalText(title), terminalText(body)
+	switch format {
+	case terminalOSC777:
+		// The title is a field of its own, so it can't hold the separator
+		return fmt.Sprintf("\x1b]777;notify;%s;%s\x07", strings.ReplaceAll(title, ";", ","), body)
+	case terminalKitty:
+		// The title, then the body, of notification 1; d=0 holds it back until the body arrives
+		return fmt.Sprintf("\x1b]99;i=pray:d=0:p=title;%s\x1b\\\x1b]99;i=pray:d=1:p=body;%s\x1b\\", title, body)
+	}
+	// OSC 9 has a single line of text
+	if body == "" {
+		return fmt.Sprintf("\x1b]9;%s\x07", title)
+	}
+	return fmt.Sprintf("\x1b]9;%s: %s\x07", title, body)
+}
+
+// terminalText keeps text from ending the sequence early, by dropping control characters
+// and joining lines.
+func terminalText(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return ' '
+		case r < ' ' || r == 0x7f || (r >= 0x80 && r < 0xa0):
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+)
+
+// Activity timed by pray timer athkar, alongside the prayers
+const timerAthkar = "athkar"
+
+// Minutes a session is gently aimed at, unless timer_targets says otherwise
+var timerTargets = map[string]int{
+	"Fajr":      8,
+	"Dhuhr":     12,
+	"Asr":       10,
+	"Maghrib":   10,
+	"Isha":      14,
+	timerAthkar: 10,
+}
+
+// Number of weeks averaged by pray stats
+const statsWeeks = 4
+
+func newTimerCmd(cfg Config) *cobra.Command {
+	var user string
+
+	cmd := &cobra.Command{
+		Use:   "timer <prayer|athkar>",
+		Short: "Time a prayer or athkar session",
+		Long: "Time how long a prayer or athkar session takes, without rushing it. The target is a gentle guide, not a deadline: " +
+			"nothing rings when it passes. Press any key to finish and save the session; pray stats shows weekly averages.",
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := runTimer(cmd.Context(), args[0], user, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "Household member to time (default: you)")
+	return cmd
+}
+
+func newStatsCmd(cfg Config) *cobra.Command {
+	var user string
+	var household, badges bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show logged prayers and weekly averages of timed sessions",
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			switch {
+			case household:
+				err = showHousehold(clock.Now(), cfg.firstWeekday())
+			case badges:
+				err = showBadges(normalizeUser(user))
+			default:
+				err = showStats(clock.Now(), normalizeUser(user), cfg.firstWeekday())
+			}
+
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "Household member to show (default: you)")
+	cmd.Flags().BoolVar(&household, "household", false, "Summarize everyone's prayers today and this week")
+	cmd.Flags().BoolVar(&badges, "badges", false, "Show earned badges and progress towards the rest")
+	return cmd
+}
+
+// timerActivity canonicalizes a prayer name or athkar.
+func timerActivity(name string) (string, error) {
+	if strings.EqualFold(name, timerAthkar) {
+		return timerAthkar, nil
+	}
+	prayer, err := canonicalPrayer(name)
+	if err != nil {
+		return "", fmt.Errorf("unknown activity %q (expected a prayer or athkar)", name)
+	}
+	return prayer, nil
+}
+
+// timerTarget is the configured target for an activity in minutes.
+func (c Config) timerTarget(activity string) int {
+	if minutes, ok := c.TimerTargets[activity]; ok {
+		return minutes
+	}
+	return timerTargets[activity]
+}
+
+// activityLabel is how an activity is shown in the timer and stats.
+func activityLabel(activity string) string {
+	if activity == timerAthkar {
+		return "📖 Athkar"
+	}
+	return prayerNames[activity]
+}
+
+func runTimer(ctx context.Context, name, user string, cfg Config) error {
+	activity, err := timerActivity(name)
+	if err != nil {
+		return err
+	}
+
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("timer needs an interactive terminal")
+	}
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	session := TimerSession{User: normalizeUser(user), Activity: activity, Started: time.Now(), Target: cfg.timerTarget(activity)}
+
+	// Raw mode disables output processing, so lines end with \r\n
+	fmt.Print(titleStyle.Render(fmt.Sprintf("⏱️  %s", activityLabel(activity))) + "\r\n")
+	fmt.Print(prayerStyle.Render("Take your time. Press any key when you're done.") + "\r\n\r\n")
+
+	keys := make(chan struct{})
+	go func() {
+		buf := make([]byte, 8)
+		os.Stdin.Read(buf)
+		close(keys)
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for done := false; !done; {
+		renderTimer(time.Since(session.Started), session.Target)
+		select {
+		case <-keys:
+			done = true
+		case <-ticker.C:
+		}
+	}
+	fmt.Print("\r\n")
+	term.Restore(fd, state)
+
+	session.Ended = time.Now()
+	elapsed := session.Ended.Sub(session.Started)
+	if elapsed < 10*time.Second {
+		// Most likely started by mistake
+		return nil
+	}
+
+	var unlocked []achievement
+	err = updateStore(func(store *Store) error {
+		store.TimerSessions = append(store.TimerSessions, session)
+		unlocked = store.unlockAchievements(session.User, session.Ended)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("Saved %s: %s", activity, formatDurationTo(elapsed, time.Second))))
+	announceAchievements(ctx, session.User, unlocked, cfg)
+	return nil
+}
+
+func renderTimer(elapsed time.Duration, target int) {
+	line := fmt.Sprintf("%s %s", emojiStyle.Render("⏱️"), timeStyle.Render(fmt.Sprintf("%02d:%02d", int(elapsed.Minutes()), int(elapsed.Seconds())%60)))
+	if target > 0 {
+		if elapsed >= time.Duration(target)*time.Minute {
+			line += fmt.Sprintf("   %dm target reached, no need to hurry", target)
+		} else {
+			line += fmt.Sprintf("   target %dm", target)
+		}
+	}
+
+	// Redraw in place and clear the rest of the line
+	fmt.Print("\r" + prayerStyle.Render(line) + "\x1b[K")
+}
+
+// weekStart is midnight on the first day of t's week, which starts on first.
+func weekStart(t time.Time, first time.Weekday) time.Time {
+	offset := (int(t.Weekday()) - int(first) + 7) % 7
+	return dayStart(addDays(t, -offset))
+}
+
+// showStats prints a user's logged prayers this week, then the average session length
+// per activity this week, last week, and over the last few weeks.
+func showStats(now time.Time, user string, first time.Weekday) error {
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+
+	title := "📊 Stats"
+	if user != "" {
+		title += " for " + user
+	}
+	fmt.Println(titleStyle.Render(title))
+	fmt.Println(strings.Repeat("━", 50))
+	logged := showPrayerStats(store, user, weekStart(now, first))
+
+	var sessions []TimerSession
+	for _, s := range store.TimerSessions {
+		if s.User == user {
+			sessions = append(sessions, s)
+		}
+	}
+	if len(sessions) == 0 {
+		if !logged {
+			fmt.Println(prayerStyle.Render("Nothing yet — log a prayer with `pray log asr` or time one with `pray timer asr`"))
+		}
+		return nil
+	}
+
+	thisWeek := weekStart(now, first)
+	lastWeek := addDays(thisWeek, -7)
+	since := addDays(thisWeek, -7*(statsWeeks-1))
+
+	type totals struct {
+		sum time.Duration
+		n   int
+	}
+	// Per activity: this week, last week, and the whole period
+	periods := map[string]*[3]totals{}
+	target := map[string]int{}
+	for _, s := range sessions {
+		if s.Started.Before(since) {
+			continue
+		}
+		p, ok := periods[s.Activity]
+		if !ok {
+			p = &[3]totals{}
+			periods[s.Activity] = p
+		}
+		d := s.Ended.Sub(s.Started)
+		if !s.Started.Before(thisWeek) {
+			p[0].sum += d
+			p[0].n++
+		} else if !s.Started.Before(lastWeek) {
+			p[1].sum += d
+			p[1].n++
+		}
+		p[2].sum += d
+		p[2].n++
+		target[s.Activity] = s.Target
+	}
+	if len(periods) == 0 {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("No timed sessions in the last %d weeks", statsWeeks)))
+		return nil
+	}
+
+	activities := append(append([]string{}, prayerOrder...), timerAthkar)
+
+	average := func(t totals) string {
+		if t.n == 0 {
+			return "-"
+		}
+		return formatDurationTo(t.sum/time.Duration(t.n), time.Second)
+	}
+
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %-9s %-9s %-9s %s", padRight("", 15), "this week", "last week", fmt.Sprintf("%d weeks", statsWeeks), "target")))
+	for _, activity := range activities {
+		p, ok := periods[activity]
+		if !ok {
+			continue
+		}
+		row := fmt.Sprintf("%-9s %-9s %-9s", average(p[0]), average(p[1]), average(p[2]))
+		goal := "-"
+		if target[activity] > 0 {
+			goal = fmt.Sprintf("%dm", target[activity])
+		}
+		fmt.Printf("%s %s %s\n", prayerStyle.Render(padRight(activityLabel(activity), 15)), timeStyle.Render(row), cityStyle.Render(goal))
+	}
+	return nil
+}
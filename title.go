@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// titleText is the countdown for a window title, like "Asr -42m".
+func titleText(timings Timings, now time.Time) (string, error) {
+	prayer, at, err := findNextPrayerAt(timings, now)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s -%s", prayer, glanceCountdown(at.Sub(now))), nil
+}
+
+// titleSequence sets the terminal's window title, and in tmux the window name as well,
+// which tmux only takes with allow-rename on.
+func titleSequence(text string) string {
+	text = terminalText(text)
+	seq := "\x1b]2;" + text + "\x07"
+	if os.Getenv("TMUX") != "" {
+		seq += "\x1bk" + text + "\x1b\\"
+	}
+	return seq
+}
+
+// terminalTitle keeps the countdown in the window title, rewritten at the start of every
+// minute. It writes to the controlling terminal rather than standard output, so the title
+// keeps counting down while another program has the screen.
+type terminalTitle struct {
+	tty     *os.File
+	timings atomic.Pointer[Timings]
+	mu      sync.Mutex
+}
+
+// newTerminalTitle opens the terminal and saves its title, to put back when done.
+func newTerminalTitle() (*terminalTitle, error) {
+	tty, err := openTerminal()
+	if err != nil {
+		return nil, fmt.Errorf("the window title needs a terminal: %v", err)
+	}
+	// Push the title on xterm's title stack; terminals without one ignore it
+	tty.WriteString("\x1b[22;2t")
+	return &terminalTitle{tty: tty}, nil
+}
+
+// update switches to a new day's timings, or a new location's.
+func (t *terminalTitle) update(timings Timings) {
+	t.timings.Store(&timings)
+	t.write(clock.Now())
+}
+
+// run rewrites the title every minute until ctx is done, then restores the saved one.
+func (t *terminalTitle) run(ctx context.Context) {
+	defer t.close()
+	for {
+		now := clock.Now()
+		if err := clock.Sleep(ctx, now.Truncate(time.Minute).Add(time.Minute).Sub(now)); err != nil {
+			return
+		}
+		t.write(clock.Now())
+	}
+}
+
+func (t *terminalTitle) write(now time.Time) {
+	timings := t.timings.Load()
+	if timings == nil {
+		return
+	}
+	text, err := titleText(*timings, now)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tty.WriteString(titleSequence(text))
+}
+
+func (t *terminalTitle) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tty.WriteString("\x1b[23;2t")
+	t.tty.Close()
+}
+
+// runTitle keeps the countdown in the window title until ctx is done, fetching each
+// day's timings after midnight. With once, it sets the title and returns.
+func runTitle(ctx context.Context, once bool, city, country string, method int, cfg Config) error {
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	if once {
+		text, err := titleText(data.Data.Timings, clock.Now())
+		if err != nil {
+			return err
+		}
+		tty, err := openTerminal()
+		if err != nil {
+			return fmt.Errorf("the window title needs a terminal: %v", err)
+		}
+		defer tty.Close()
+		_, err = tty.WriteString(titleSequence(text))
+		return err
+	}
+
+	title, err := newTerminalTitle()
+	if err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	go func() {
+		title.run(ctx)
+		close(done)
+	}()
+	defer func() { <-done }()
+
+	for {
+		title.update(data.Data.Timings)
+		now := clock.Now()
+		if err := clock.Sleep(ctx, nextDayStart(now).Sub(now)); err != nil {
+			return nil
+		}
+		// Keep the last day's timings if the new ones can't be fetched, and try again later
+		for {
+			if data, err = fetchPrayerTimes(ctx, city, country, method, cfg); err == nil {
+				break
+			}
+			if clock.Sleep(ctx, 5*time.Minute) != nil {
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,399 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Prayer log statuses
+const (
+	statusPrayed = "prayed"
+	statusLate   = "late"
+	statusMissed = "missed"
+)
+
+// Prayers that are logged, without Sunrise
+var trackedPrayers = []string{"Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"}
+
+// Marks for a day's prayers in the household view
+var statusMarks = map[string]string{
+	statusPrayed: "✓",
+	statusLate:   "~",
+	statusMissed: "✗",
+	"":           "·",
+}
+
+// normalizeUser lower-cases a user name so --user Ahmad and --user ahmad are one person.
+// The empty name is the machine's own user.
+func normalizeUser(user string) string {
+	return strings.ToLower(strings.TrimSpace(user))
+}
+
+// userLabel is how a user is shown in summaries.
+func userLabel(user string) string {
+	if user == "" {
+		return "you"
+	}
+	return user
+}
+
+// logPrayer records a prayer for a user on a day, replacing an earlier entry for it.
+func (s *Store) logPrayer(user, prayer, status string, day time.Time) PrayerLog {
+	entry := PrayerLog{
+		User:   normalizeUser(user),
+		Date:   day.Format("2006-01-02"),
+		Prayer: prayer,
+		Status: status,
+		Logged: time.Now(),
+	}
+	for i, p := range s.Prayers {
+		if p.User == entry.User && p.Date == entry.Date && p.Prayer == entry.Prayer {
+			s.Prayers[i] = entry
+			return entry
+		}
+	}
+	s.Prayers = append(s.Prayers, entry)
+	return entry
+}
+
+// prayerStatus is the logged status of a user's prayer on a day, or "" when not logged.
+func (s *Store) prayerStatus(user, prayer string, day time.Time) string {
+	date := day.Format("2006-01-02")
+	for _, p := range s.Prayers {
+		if p.User == user && p.Date == date && p.Prayer == prayer {
+			return p.Status
+		}
+	}
+	return ""
+}
+
+// completeDays is the set of days (YYYY-MM-DD) on which a user logged all five prayers
+// as prayed or late.
+func (s *Store) completeDays(user string) map[string]bool {
+	prayed := map[string]int{}
+	for _, p := range s.Prayers {
+		if p.User == user && (p.Status == statusPrayed || p.Status == statusLate) {
+			prayed[p.Date]++
+		}
+	}
+	complete := map[string]bool{}
+	for date, n := range prayed {
+		if n == len(trackedPrayers) {
+			complete[date] = true
+		}
+	}
+	return complete
+}
+
+// users lists everyone with logged prayers or timer sessions, the machine's user first.
+func (s *Store) users() []string {
+	seen := map[string]bool{}
+	for _, p := range s.Prayers {
+		seen[p.User] = true
+	}
+	for _, t := range s.TimerSessions {
+		seen[t.User] = true
+	}
+	var users []string
+	for user := range seen {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users
+}
+
+// statusCounts counts a user's logged statuses per prayer from since up to now.
+func (s *Store) statusCounts(user string, since time.Time) map[string]map[string]int {
+	counts := map[string]map[string]int{}
+	from := since.Format("2006-01-02")
+	for _, p := range s.Prayers {
+		if p.User != user || p.Date < from {
+			continue
+		}
+		if counts[p.Prayer] == nil {
+			counts[p.Prayer] = map[string]int{}
+		}
+		counts[p.Prayer][p.Status]++
+	}
+	return counts
+}
+
+func newLogCmd(cfg Config) *cobra.Command {
+	var user, date string
+	var late, missed bool
+
+	cmd := &cobra.Command{
+		Use:   "log <prayer>",
+		Short: "Log a prayer as prayed, late, or missed",
+		Long:  "Log a prayer for today or --date. Use --user to log for someone else in the household, such as a child; pray stats --household shows everyone.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			status := statusPrayed
+			if late && missed {
+				fmt.Println("Error: --late and --missed can't be combined")
+				os.Exit(1)
+			} else if late {
+				status = statusLate
+			} else if missed {
+				status = statusMissed
+			}
+
+			if err := runLog(cmd.Context(), args[0], status, date, user, cfg); err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().StringVar(&user, "user", "", "Household member to log for (default: you)")
+	cmd.Flags().StringVar(&date, "date", "", "Day to log, as YYYY-MM-DD (default: today)")
+	cmd.Flags().BoolVar(&late, "late", false, "Prayed, but after its time")
+	cmd.Flags().BoolVar(&missed, "missed", false, "Not prayed")
+	return cmd
+}
+
+// parseLogDate reads a YYYY-MM-DD day, defaulting to today.
+func parseLogDate(date string) (time.Time, error) {
+	if date == "" {
+		return clock.Now(), nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (expected YYYY-MM-DD)", date)
+	}
+	return day, nil
+}
+
+// savePrayerLog logs a prayer in the store and saves it, returning the entry, the
+// achievements it unlocks, and the to-do task it completes, if any.
+func savePrayerLog(user, prayer, status string, day time.Time, cfg TasksConfig) (entry PrayerLog, unlocked []achievement, task *SyncedTask, err error) {
+	err = updateStore(func(store *Store) error {
+		entry = store.logPrayer(user, prayer, status, day)
+		unlocked = store.unlockAchievements(entry.User, clock.Now())
+		task = store.loggedTask(cfg, entry)
+		return nil
+	})
+	return entry, unlocked, task, err
+}
+
+func runLog(ctx context.Context, name, status, date, user string, cfg Config) error {
+	prayer, err := canonicalPrayer(name)
+	if err != nil {
+		return err
+	}
+	day, err := parseLogDate(date)
+	if err != nil {
+		return err
+	}
+
+	entry, unlocked, task, err := savePrayerLog(user, prayer, status, day, cfg.Tasks)
+	if err != nil {
+		return err
+	}
+	taskErr := completeLoggedTask(ctx, cfg.Tasks, task)
+
+	who := ""
+	if entry.User != "" {
+		who = " for " + entry.User
+	}
+	fmt.Println(cityStyle.PaddingLeft(2).Render(fmt.Sprintf("%s %s logged as %s%s on %s", statusMarks[status], prayer, status, who, day.Format("Mon 02 Jan"))))
+	if taskErr != nil {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("(task not completed: %v; pray tasks sync retries)", taskErr)))
+	}
+	announceAchievements(ctx, entry.User, unlocked, cfg)
+	return nil
+}
+
+// showPrayerStats prints a user's logged prayers since week, the start of this week,
+// reporting whether there were any.
+func showPrayerStats(store *Store, user string, week time.Time) bool {
+	counts := store.statusCounts(user, week)
+	if len(counts) == 0 {
+		return false
+	}
+
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%s %-7s %-7s %s", padRight("", 15), statusPrayed, statusLate, statusMissed)))
+	for _, prayer := range trackedPrayers {
+		c := counts[prayer]
+		row := fmt.Sprintf("%-7d %-7d %d", c[statusPrayed], c[statusLate], c[statusMissed])
+		fmt.Printf("%s %s\n", prayerStyle.Render(padRight(prayerNames[prayer], 15)), timeStyle.Render(row))
+	}
+	fmt.Println()
+	return true
+}
+
+// showHousehold prints everyone's prayers today and their totals this week.
+func showHousehold(now time.Time, first time.Weekday) error {
+	store, err := loadStore()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render("🏠 Household"))
+	fmt.Println(strings.Repeat("━", 50))
+
+	users := store.users()
+	if len(users) == 0 {
+		fmt.Println(prayerStyle.Render("Nothing logged yet — log a prayer with `pray log fajr --user name`"))
+		return nil
+	}
+
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%-12s %-11s %s", "", "today", "this week")))
+	for _, user := range users {
+		var today strings.Builder
+		for _, prayer := range trackedPrayers {
+			today.WriteString(statusMarks[store.prayerStatus(user, prayer, now)] + " ")
+		}
+
+		var prayed, late, missed int
+		for _, c := range store.statusCounts(user, weekStart(now, first)) {
+			prayed += c[statusPrayed]
+			late += c[statusLate]
+			missed += c[statusMissed]
+		}
+		week := fmt.Sprintf("%d prayed, %d late, %d missed", prayed, late, missed)
+		fmt.Printf("%s %s %s\n", prayerStyle.Render(fmt.Sprintf("%-12s", userLabel(user))), timeStyle.Render(today.String()), cityStyle.Render(week))
+	}
+	fmt.Println()
+	fmt.Println(cityStyle.PaddingLeft(2).Render("Fajr Dhuhr Asr Maghrib Isha · ✓ prayed, ~ late, ✗ missed"))
+	return nil
+}
+
+// householdMember is one user in the /api/household response
+type householdMember struct {
+	User  string            `json:"user"`
+	Today map[string]string `json:"today"`
+	Week  map[string]int    `json:"week"`
+}
+
+// handleLog logs a prayer from a household device: POST /api/log?user=&prayer=&status=&date=
+func (s *server) handleLog(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	prayer, err := canonicalPrayer(query.Get("prayer"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	status := query.Get("status")
+	if status == "" {
+		status = statusPrayed
+	}
+	if status != statusPrayed && status != statusLate && status != statusMissed {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q (expected prayed, late, or missed)", status))
+		return
+	}
+	day, err := parseLogDate(query.Get("date"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if _, err := s.recordPrayer(r.Context(), query.Get("user"), prayer, status, day); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Statuses a tracking webhook can send, with the log status each one means
+var webhookStatuses = map[string]string{
+	"on_time":    statusPrayed,
+	statusPrayed: statusPrayed,
+	statusLate:   statusLate,
+	statusMissed: statusMissed,
+}
+
+// Largest tracking webhook body read
+const maxWebhookBody = 64 << 10
+
+// trackRequest is the body of a tracking webhook
+type trackRequest struct {
+	Prayer string `json:"prayer"`
+	Status string `json:"status"`
+	User   string `json:"user"`
+	Date   string `json:"date"`
+}
+
+// handleTrack logs a prayer sent as JSON, e.g. {"prayer": "fajr", "status": "on_time"},
+// for phone shortcuts and watch apps that post to a webhook rather than build a query.
+func (s *server) handleTrack(w http.ResponseWriter, r *http.Request) {
+	var req trackRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBody)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %v", err))
+		return
+	}
+	prayer, err := canonicalPrayer(strings.TrimSpace(req.Prayer))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	status := statusPrayed
+	if req.Status != "" {
+		var ok bool
+		if status, ok = webhookStatuses[strings.ToLower(strings.TrimSpace(req.Status))]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid status %q (expected on_time, late, or missed)", req.Status))
+			return
+		}
+	}
+	day, err := parseLogDate(req.Date)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	entry, err := s.recordPrayer(r.Context(), req.User, prayer, status, day)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// recordPrayer logs a prayer in the store and announces any achievements it unlocks.
+func (s *server) recordPrayer(ctx context.Context, user, prayer, status string, day time.Time) (PrayerLog, error) {
+	entry, unlocked, task, err := savePrayerLog(user, prayer, status, day, s.cfg.Tasks)
+	if err != nil {
+		return PrayerLog{}, err
+	}
+	if err := completeLoggedTask(ctx, s.cfg.Tasks, task); err != nil {
+		log.Printf("failed to complete the task for %s: %v", prayer, err)
+	}
+	announceAchievements(ctx, entry.User, unlocked, s.cfg)
+	return entry, nil
+}
+
+// handleHousehold returns every user's prayers today and status totals this week.
+func (s *server) handleHousehold(w http.ResponseWriter, r *http.Request) {
+	store, err := loadStore()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	now := clock.Now()
+	members := []householdMember{}
+	for _, user := range store.users() {
+		member := householdMember{User: userLabel(user), Today: map[string]string{}, Week: map[string]int{}}
+		for _, prayer := range trackedPrayers {
+			if status := store.prayerStatus(user, prayer, now); status != "" {
+				member.Today[prayer] = status
+			}
+		}
+		for _, c := range store.statusCounts(user, weekStart(now, s.cfg.firstWeekday())) {
+			for status, n := range c {
+				member.Week[status] += n
+			}
+		}
+		members = append(members, member)
+	}
+	writeJSON(w, http.StatusOK, members)
+}
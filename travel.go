@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// Distance from home beyond which travel mode notes shortening, when the config doesn't
+// set one: 48 Hashimi miles, the most common reckoning
+const defaultQasrDistanceKm = 81
+
+// Schools of fiqh travel mode can follow; without one it sums up where they differ
+const (
+	madhhabHanafi  = "hanafi"
+	madhhabMaliki  = "maliki"
+	madhhabShafii  = "shafii"
+	madhhabHanbali = "hanbali"
+)
+
+var madhhabNames = map[string]string{
+	madhhabHanafi:  "Hanafi",
+	madhhabMaliki:  "Maliki",
+	madhhabShafii:  "Shafi'i",
+	madhhabHanbali: "Hanbali",
+}
+
+// TravelConfig turns on travel mode: with a home set, the timings for anywhere further
+// than distance_km from it note what applies to a traveller's prayers
+type TravelConfig struct {
+	HomeCity    string  `yaml:"home_city"`
+	HomeCountry string  `yaml:"home_country"`
+	DistanceKm  float64 `yaml:"distance_km"`
+
+	// hanafi, maliki, shafii, or hanbali; empty sums up where they differ
+	Madhhab string `yaml:"madhhab"`
+}
+
+func (t TravelConfig) distance() float64 {
+	if t.DistanceKm > 0 {
+		return t.DistanceKm
+	}
+	return defaultQasrDistanceKm
+}
+
+// travelDistance is how far the timings' location is from home, and whether that's far
+// enough to count as travelling. Home comes from the city database, or from the API
+// for a city it doesn't have.
+func travelDistance(ctx context.Context, data *PrayerTimesResponse, method int, cfg Config) (float64, bool, error) {
+	t := cfg.Travel
+	if t.HomeCity == "" {
+		return 0, false, nil
+	}
+	latitude, longitude := 0.0, 0.0
+	if home, ok := lookupCity(t.HomeCity, t.HomeCountry); ok {
+		latitude, longitude = home.Latitude, home.Longitude
+	} else {
+		home, err := fetchPrayerTimes(ctx, t.HomeCity, t.HomeCountry, method, cfg)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to locate home: %v", err)
+		}
+		latitude, longitude = home.Data.Meta.Latitude, home.Data.Meta.Longitude
+	}
+	d := distanceKm(latitude, longitude, data.Data.Meta.Latitude, data.Data.Meta.Longitude)
+	return d, d >= t.distance(), nil
+}
+
+// travelGuidance is what a school holds about a traveller's prayers, as information
+// rather than a ruling.
+func travelGuidance(madhhab string) []string {
+	switch madhhab {
+	case madhhabHanafi:
+		return []string{
+			"Dhuhr, Asr, and Isha are shortened to two rak'ahs (qasr).",
+			"Prayers aren't combined, except at Arafah and Muzdalifah during Hajj.",
+			"Intending to stay 15 days or more in one place ends travel.",
+		}
+	case madhhabMaliki, madhhabShafii, madhhabHanbali:
+		stay := "Intending to stay four days or more, besides the days of arrival and departure, ends travel."
+		if madhhab == madhhabHanbali {
+			stay = "Intending to stay for more than twenty prayers, about four days, ends travel."
+		}
+		return []string{
+			"Dhuhr, Asr, and Isha may be shortened to two rak'ahs (qasr).",
+			"Dhuhr and Asr, and Maghrib and Isha, may be combined at the time of either.",
+			stay,
+		}
+	}
+	return []string{
+		"Dhuhr, Asr, and Isha may be shortened to two rak'ahs (qasr).",
+		"Schools differ on combining prayers and on how long a stay ends travel;",
+		"set travel.madhhab for one school's view.",
+	}
+}
+
+// showTravelPanel notes what applies to a traveller's prayers when the timings are far
+// enough from home.
+func showTravelPanel(ctx context.Context, data *PrayerTimesResponse, method int, cfg Config) {
+	d, travelling, err := travelDistance(ctx, data, method, cfg)
+	if err != nil {
+		fmt.Println()
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("🧳 Travel mode: %v", err)))
+		return
+	}
+	if !travelling {
+		return
+	}
+
+	fmt.Println()
+	heading := fmt.Sprintf("🧳 Travelling: %.0f km from %s", d, cfg.Travel.HomeCity)
+	if cfg.Travel.Madhhab != "" {
+		heading += fmt.Sprintf(" · %s view", madhhabNames[cfg.Travel.Madhhab])
+	}
+	fmt.Println(cityStyle.PaddingLeft(2).Render(heading))
+	for _, line := range travelGuidance(cfg.Travel.Madhhab) {
+		fmt.Println(prayerStyle.Render(line))
+	}
+	fmt.Println(prayerStyle.Render("For information only; ask a scholar you trust."))
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// readTimetable reads a recorded timetable as CSV with a header row: a date column
+// (YYYY-MM-DD) and a column per prayer, as written by pray year --format csv. Other
+// columns are ignored, and so are empty cells.
+func readTimetable(path string) ([]announcedDay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	r.Comment = '#'
+
+	header, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", path, err)
+	}
+	dateColumn := -1
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		if strings.EqualFold(name, "date") {
+			dateColumn = i
+		}
+		for _, prayer := range prayerOrder {
+			if strings.EqualFold(name, prayer) {
+				columns[prayer] = i
+			}
+		}
+	}
+	if dateColumn < 0 || len(columns) == 0 {
+		return nil, fmt.Errorf("%s: the header needs a date column and at least one prayer column", path)
+	}
+
+	var days []announcedDay
+	for line := 2; ; line++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+
+		date, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(record[dateColumn]), time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid date %q (expected YYYY-MM-DD)", path, line, record[dateColumn])
+		}
+		day := announcedDay{Date: date, Times: map[string]time.Time{}}
+		for prayer, column := range columns {
+			if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+				continue
+			}
+			t, err := parseTimeOn(strings.TrimSpace(record[column]), date)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: invalid %s time %q (expected HH:MM)", path, line, prayer, record[column])
+			}
+			day.Times[prayer] = t
+		}
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// deviation is how far a timetable is from the API's timings for one prayer: the
+// average and largest difference in minutes, either way, over the days it has that prayer
+type deviation struct {
+	Prayer string
+	Days   int
+	Avg    float64
+	Max    float64
+}
+
+// timetableDeviations sums up the differences per prayer, in prayer order.
+func timetableDeviations(diffs map[string][]float64) []deviation {
+	var deviations []deviation
+	for _, prayer := range prayerOrder {
+		if len(diffs[prayer]) == 0 {
+			continue
+		}
+		d := deviation{Prayer: prayer, Days: len(diffs[prayer])}
+		var sum float64
+		for _, diff := range diffs[prayer] {
+			sum += math.Abs(diff)
+			d.Max = math.Max(d.Max, math.Abs(diff))
+		}
+		d.Avg = sum / float64(d.Days)
+		deviations = append(deviations, d)
+	}
+	return deviations
+}
+
+// runVerify compares the timings the API gives for the method, including configured tune
+// offsets, with a recorded official timetable and reports the deviation per prayer. It
+// fails when any time is off by more than the tolerance.
+func runVerify(ctx context.Context, path string, tolerance time.Duration, city, country string, method int, cfg Config) error {
+	days, err := readTimetable(path)
+	if err != nil {
+		return err
+	}
+	if len(days) == 0 {
+		return fmt.Errorf("%s has no days to verify", path)
+	}
+
+	diffs, name, err := methodDiffs(ctx, method, days, city, country, cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🔍 %s against %s", cityStyle.Render(city), path)))
+	fmt.Println(strings.Repeat("━", 50))
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("%-15s %6s %8s %8s", "", "days", "avg", "max")))
+
+	var worst float64
+	for _, d := range timetableDeviations(diffs) {
+		worst = math.Max(worst, d.Max)
+		row := fmt.Sprintf("%6d %7.1fm %7.0fm", d.Days, d.Avg, d.Max)
+		if d.Max > tolerance.Minutes() {
+			fmt.Printf("%s %s\n", nextPrayerStyle.Render(padRight(prayerNames[d.Prayer], 15)), countdownStyle.Render(row))
+		} else {
+			fmt.Printf("%s %s\n", prayerStyle.Render(padRight(prayerNames[d.Prayer], 15)), timeStyle.Render(row))
+		}
+	}
+
+	fmt.Println()
+	fmt.Println(prayerStyle.Render(fmt.Sprintf("📍 Method: %s", name)))
+	if worst > tolerance.Minutes() {
+		return fmt.Errorf("timings are off by up to %.0f minutes, more than the %s tolerance", worst, formatDuration(tolerance))
+	}
+	fmt.Println(cityStyle.Render(fmt.Sprintf("✓ All timings within %s", formatDuration(tolerance))))
+	return nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// useAPI points the API client at a test server until the test ends and returns a
+// config that fetches from it.
+func useAPI(t *testing.T, handler http.Handler) Config {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	saved := api
+	api = &apiClient{client: srv.Client(), limiter: newRateLimiter(1000, 1000), cache: newMemoryCache(), calls: map[string]*apiCall{}}
+	t.Cleanup(func() { api = saved })
+	return Config{APIURL: srv.URL}
+}
+
+// Calendar responses in testdata for Riyadh, March 2024, by method
+var riyadhCalendars = map[string]string{
+	"2": "calendar-riyadh-2024-03-isna.json",
+	"4": "calendar-riyadh-2024-03.json",
+}
+
+// serveCalendar answers calendar requests for Riyadh, March 2024, with the method's
+// response in testdata, counting the requests.
+func serveCalendar(t *testing.T, requests *int) http.Handler {
+	bodies := map[string][]byte{}
+	for method, name := range riyadhCalendars {
+		body, err := os.ReadFile(filepath.Join("testdata", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bodies[method] = body
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		body, ok := bodies[r.URL.Query().Get("method")]
+		if !ok || r.URL.Path != "/calendarByCity/2024/3" || r.URL.Query().Get("city") != "Riyadh" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+func TestReadTimetable(t *testing.T) {
+	days, err := readTimetable(filepath.Join("testdata", "riyadh-2024-03.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 5 {
+		t.Fatalf("read %d days, want 5", len(days))
+	}
+	first := days[0]
+	if got := first.Date.Format("2006-01-02"); got != "2024-03-01" {
+		t.Errorf("first date = %s, want 2024-03-01", got)
+	}
+	if got := first.Times["Asr"].Format("2006-01-02 15:04"); got != "2024-03-01 15:26" {
+		t.Errorf("first Asr = %s, want 2024-03-01 15:26", got)
+	}
+	if len(first.Times) != 6 {
+		t.Errorf("first day has %d times, want 6", len(first.Times))
+	}
+	// An empty cell leaves the prayer out rather than failing
+	if _, ok := days[3].Times["Isha"]; ok {
+		t.Errorf("2024-03-04 has an Isha time, want none from the empty cell")
+	}
+}
+
+func TestReadTimetableErrors(t *testing.T) {
+	tests := map[string]string{
+		"no prayer columns": "date,notes\n2024-03-01,x\n",
+		"bad date":          "date,Fajr\n01/03/2024,05:02\n",
+		"bad time":          "date,Fajr\n2024-03-01,5am\n",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "timetable.csv")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := readTimetable(path); err == nil {
+				t.Errorf("readTimetable succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestVerifyDeviations(t *testing.T) {
+	var requests int
+	cfg := useAPI(t, serveCalendar(t, &requests))
+	days, err := readTimetable(filepath.Join("testdata", "riyadh-2024-03.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, name, err := methodDiffs(context.Background(), 4, days, "Riyadh", "SA", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d API requests, want 1 for the month", requests)
+	}
+	if name != "Umm Al-Qura University, Makkah" {
+		t.Errorf("method name = %q, want the one in the response", name)
+	}
+
+	want := []deviation{
+		{Prayer: "Fajr", Days: 5, Avg: 0.2, Max: 1},
+		{Prayer: "Sunrise", Days: 5, Avg: 0, Max: 0},
+		{Prayer: "Dhuhr", Days: 5, Avg: 0, Max: 0},
+		{Prayer: "Asr", Days: 5, Avg: 0.2, Max: 1},
+		{Prayer: "Maghrib", Days: 5, Avg: 0.6, Max: 3},
+		{Prayer: "Isha", Days: 4, Avg: 0.75, Max: 3},
+	}
+	got := timetableDeviations(diffs)
+	if len(got) != len(want) {
+		t.Fatalf("got %d prayers, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Prayer != w.Prayer || g.Days != w.Days || math.Abs(g.Avg-w.Avg) > 1e-9 || g.Max != w.Max {
+			t.Errorf("deviation %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestVerifyTolerance(t *testing.T) {
+	var requests int
+	cfg := useAPI(t, serveCalendar(t, &requests))
+	path := filepath.Join("testdata", "riyadh-2024-03.csv")
+
+	if err := runVerify(context.Background(), path, 3*time.Minute, "Riyadh", "SA", 4, cfg); err != nil {
+		t.Errorf("runVerify with a 3m tolerance: %v, want no error", err)
+	}
+	if err := runVerify(context.Background(), path, 2*time.Minute, "Riyadh", "SA", 4, cfg); err == nil {
+		t.Errorf("runVerify with a 2m tolerance succeeded, want an error for Maghrib and Isha 3m off")
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// voicePayload is the default /api/voice response, for assistants that read a field out of JSON
+type voicePayload struct {
+	Speech  string `json:"speech"`
+	City    string `json:"city"`
+	Prayer  string `json:"prayer"`
+	Time    string `json:"time"`
+	Minutes int    `json:"minutes"`
+}
+
+type alexaSpeech struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+type alexaResponse struct {
+	Version  string `json:"version"`
+	Response struct {
+		OutputSpeech     alexaSpeech `json:"outputSpeech"`
+		ShouldEndSession bool        `json:"shouldEndSession"`
+	} `json:"response"`
+}
+
+type dialogflowResponse struct {
+	FulfillmentText string `json:"fulfillmentText"`
+}
+
+// handleVoice answers voice assistant webhooks with a sentence about the next prayer.
+// ?format=alexa and ?format=dialogflow wrap it in those platforms' response shapes.
+func (s *server) handleVoice(w http.ResponseWriter, r *http.Request) {
+	city, country, method, err := s.location(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "alexa" && format != "dialogflow" {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid format %q (expected alexa or dialogflow)", format))
+		return
+	}
+
+	payload := voicePayload{City: city}
+	data, err := fetchPrayerTimes(r.Context(), city, country, method, s.cfg)
+	if err == nil {
+		now := cityNow(data.Data)
+		var at time.Time
+		if payload.Prayer, at, err = findNextPrayerAt(data.Data.Timings, now); err == nil {
+			until := at.Sub(now)
+			payload.Time = at.Format("15:04")
+			payload.Minutes = int(until.Minutes())
+			payload.Speech = fmt.Sprintf("The next prayer in %s is %s at %s, in %s.",
+				city, payload.Prayer, at.Format("3:04 PM"), spokenDuration(until))
+		}
+	}
+
+	// Assistants read errors aloud too, so they are answered as speech rather than HTTP errors
+	if err != nil && format != "" {
+		payload.Speech = fmt.Sprintf("Sorry, I couldn't get the prayer times for %s.", city)
+	} else if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	switch format {
+	case "alexa":
+		var resp alexaResponse
+		resp.Version = "1.0"
+		resp.Response.OutputSpeech = alexaSpeech{Type: "PlainText", Text: payload.Speech}
+		resp.Response.ShouldEndSession = true
+		writeJSON(w, http.StatusOK, resp)
+	case "dialogflow":
+		writeJSON(w, http.StatusOK, dialogflowResponse{FulfillmentText: payload.Speech})
+	default:
+		writeJSON(w, http.StatusOK, payload)
+	}
+}
+
+// spokenDuration phrases a duration the way it would be said aloud: "1 hour and 5 minutes".
+func spokenDuration(d time.Duration) string {
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+
+	plural := func(n int, unit string) string {
+		if n == 1 {
+			return fmt.Sprintf("1 %s", unit)
+		}
+		return fmt.Sprintf("%d %ss", n, unit)
+	}
+
+	switch {
+	case hours > 0 && minutes > 0:
+		return plural(hours, "hour") + " and " + plural(minutes, "minute")
+	case hours > 0:
+		return plural(hours, "hour")
+	case minutes > 0:
+		return plural(minutes, "minute")
+	default:
+		return "less than a minute"
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Weather providers; Open-Meteo needs no account or key
+const weatherOpenMeteo = "open-meteo"
+
+const openMeteoURL = "https://api.open-meteo.com/v1/forecast"
+
+// Temperature units for the forecast
+const (
+	unitsCelsius    = "celsius"
+	unitsFahrenheit = "fahrenheit"
+)
+
+// WeatherConfig turns on the forecast at each remaining prayer time under the timings
+type WeatherConfig struct {
+	// open-meteo; empty leaves the forecast out
+	Provider string `yaml:"provider"`
+	// celsius (default) or fahrenheit
+	Units string `yaml:"units"`
+}
+
+// forecast is the weather expected at a time
+type forecast struct {
+	Temperature float64
+	Code        int
+	RainChance  int
+}
+
+// Conditions by WMO weather code, as Open-Meteo reports them
+var weatherConditions = map[int]string{
+	0: "clear", 1: "mainly clear", 2: "partly cloudy", 3: "overcast",
+	45: "fog", 48: "freezing fog",
+	51: "light drizzle", 53: "drizzle", 55: "heavy drizzle", 56: "freezing drizzle", 57: "freezing drizzle",
+	61: "light rain", 63: "rain", 65: "heavy rain", 66: "freezing rain", 67: "freezing rain",
+	71: "light snow", 73: "snow", 75: "heavy snow", 77: "snow grains",
+	80: "light showers", 81: "showers", 82: "violent showers", 85: "snow showers", 86: "heavy snow showers",
+	95: "thunderstorm", 96: "thunderstorm with hail", 99: "thunderstorm with hail",
+}
+
+// describe is a forecast as shown next to a prayer, like "31°C, clear" or
+// "14°C, light rain, 70% chance of rain".
+func (f forecast) describe(units string) string {
+	unit := "°C"
+	if units == unitsFahrenheit {
+		unit = "°F"
+	}
+	text := fmt.Sprintf("%.0f%s", f.Temperature, unit)
+	if condition, ok := weatherConditions[f.Code]; ok {
+		text += ", " + condition
+	}
+	if f.RainChance >= 20 {
+		text += fmt.Sprintf(", %d%% chance of rain", f.RainChance)
+	}
+	return text
+}
+
+type openMeteoResponse struct {
+	Hourly struct {
+		Time        []string  `json:"time"`
+		Temperature []float64 `json:"temperature_2m"`
+		WeatherCode []int     `json:"weather_code"`
+		RainChance  []int     `json:"precipitation_probability"`
+	} `json:"hourly"`
+	Error  bool   `json:"error"`
+	Reason string `json:"reason"`
+}
+
+// fetchForecast fetches the hourly forecast for today and tomorrow at a place, keyed by the
+// hour in the place's own time, like 2026-10-17T18:00.
+func fetchForecast(ctx context.Context, latitude, longitude float64, cfg WeatherConfig) (map[string]forecast, error) {
+	if cfg.Provider != weatherOpenMeteo {
+		return nil, fmt.Errorf("unknown weather.provider %q (expected open-meteo)", cfg.Provider)
+	}
+	query := url.Values{
+		"latitude":      {fmt.Sprintf("%.4f", latitude)},
+		"longitude":     {fmt.Sprintf("%.4f", longitude)},
+		"hourly":        {"temperature_2m,weather_code,precipitation_probability"},
+		"timezone":      {"auto"},
+		"forecast_days": {"2"},
+	}
+	if cfg.Units == unitsFahrenheit {
+		query.Set("temperature_unit", unitsFahrenheit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, apiTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, openMeteoURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "pray")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the forecast: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body openMeteoResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode the forecast: %v", err)
+	}
+	if body.Error || resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch the forecast: %s %s", resp.Status, body.Reason)
+	}
+
+	h := body.Hourly
+	hours := make(map[string]forecast, len(h.Time))
+	for i, t := range h.Time {
+		if i >= len(h.Temperature) || i >= len(h.WeatherCode) {
+			break
+		}
+		f := forecast{Temperature: h.Temperature[i], Code: h.WeatherCode[i]}
+		if i < len(h.RainChance) {
+			f.RainChance = h.RainChance[i]
+		}
+		hours[t] = f
+	}
+	return hours, nil
+}
+
+// forecastAt is the forecast for the hour nearest a time.
+func forecastAt(hours map[string]forecast, t time.Time) (forecast, bool) {
+	f, ok := hours[t.Add(30*time.Minute).Truncate(time.Hour).Format("2006-01-02T15:04")]
+	return f, ok
+}
+
+// showWeatherPanel prints the forecast at each prayer still to come today. The weather is
+// a nice-to-have, so failing to get it is a note rather than an error.
+func showWeatherPanel(ctx context.Context, data *PrayerTimesResponse, cfg Config) {
+	if cfg.Weather.Provider == "" {
+		return
+	}
+	now := cityNow(data.Data)
+	timings := map[string]string{
+		"Fajr":    data.Data.Timings.Fajr,
+		"Dhuhr":   data.Data.Timings.Dhuhr,
+		"Asr":     data.Data.Timings.Asr,
+		"Maghrib": data.Data.Timings.Maghrib,
+		"Isha":    data.Data.Timings.Isha,
+	}
+	var upcoming []string
+	for _, prayer := range trackedPrayers {
+		if t, err := parseTimeOn(timings[prayer], now); err == nil && t.After(now) {
+			upcoming = append(upcoming, prayer)
+		}
+	}
+	if len(upcoming) == 0 {
+		return
+	}
+
+	fmt.Println()
+	hours, err := fetchForecast(ctx, data.Data.Meta.Latitude, data.Data.Meta.Longitude, cfg.Weather)
+	if err != nil {
+		fmt.Println(prayerStyle.Render(fmt.Sprintf("🌦️  Weather: %v", err)))
+		return
+	}
+	fmt.Println(cityStyle.PaddingLeft(2).Render("🌦️  Weather at prayer time"))
+	for _, prayer := range upcoming {
+		t, _ := parseTimeOn(timings[prayer], now)
+		f, ok := forecastAt(hours, t)
+		if !ok {
+			continue
+		}
+		fmt.Printf("%s %s\n", prayerStyle.Render(padRight(prayerNames[prayer], 15)),
+			timeStyle.Render(fmt.Sprintf("%s — %s", cfg.digits(t.Format("15:04")), f.describe(cfg.Weather.Units))))
+	}
+}
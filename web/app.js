@@ -0,0 +1,130 @@
+// Dashboard for `pray serve`. Query parameters (city, country, method) are passed through to the API.
+
+const PRAYERS = ["Fajr", "Dhuhr", "Asr", "Maghrib", "Isha"];
+const SHOWN = ["Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha"];
+const EMOJI = { Fajr: "🌅", Sunrise: "☀️", Dhuhr: "🌞", Asr: "🌤️", Maghrib: "🌅", Isha: "🌙" };
+
+let today = null;
+let loadedFor = null;
+
+async function fetchJSON(path) {
+  const res = await fetch(path + window.location.search);
+  const body = await res.json();
+  if (!res.ok) {
+    throw new Error(body.error || res.statusText);
+  }
+  return body;
+}
+
+// "05:12 (+03)" -> seconds since midnight
+function toSeconds(value) {
+  const [h, m] = value.split(" ")[0].split(":").map(Number);
+  return h * 3600 + m * 60;
+}
+
+function clock(value) {
+  return value.split(" ")[0];
+}
+
+// Seconds since midnight and the calendar date in the city's timezone
+function cityNow(timezone) {
+  const parts = {};
+  new Intl.DateTimeFormat("en-GB", {
+    timeZone: timezone,
+    hourCycle: "h23",
+    year: "numeric", month: "2-digit", day: "2-digit",
+    hour: "2-digit", minute: "2-digit", second: "2-digit",
+  }).formatToParts(new Date()).forEach((p) => { parts[p.type] = p.value; });
+
+  return {
+    seconds: Number(parts.hour) * 3600 + Number(parts.minute) * 60 + Number(parts.second),
+    date: `${parts.day}-${parts.month}-${parts.year}`,
+  };
+}
+
+function nextPrayer(timings, now) {
+  for (const name of PRAYERS) {
+    const at = toSeconds(timings[name]);
+    if (at > now) {
+      return { name, remaining: at - now };
+    }
+  }
+  // Everything has passed: tomorrow's Fajr
+  return { name: "Fajr", remaining: toSeconds(timings.Fajr) + 86400 - now };
+}
+
+function pad(n) {
+  return String(n).padStart(2, "0");
+}
+
+function renderToday() {
+  const { timings, date, meta } = today;
+  const now = cityNow(meta.timezone);
+  const next = nextPrayer(timings, now.seconds);
+
+  document.getElementById("city").textContent = today.city;
+  document.getElementById("date").textContent =
+    `📅 ${date.readable} | ${date.hijri.day} ${date.hijri.month.en}, ${date.hijri.year} AH`;
+  document.getElementById("method").textContent = `📍 Method: ${meta.method.name}`;
+
+  document.getElementById("today").innerHTML = SHOWN.map((name) => `
+    <tr class="${name === next.name ? "next" : ""}">
+      <td>${name === next.name ? "▶ " : ""}${EMOJI[name]} ${name}</td>
+      <td>${clock(timings[name])}</td>
+    </tr>`).join("");
+
+  const r = next.remaining;
+  document.getElementById("next-name").textContent = `${EMOJI[next.name]} ${next.name} at ${clock(timings[next.name])}`;
+  document.getElementById("countdown").textContent =
+    `${pad(Math.floor(r / 3600))}:${pad(Math.floor((r % 3600) / 60))}:${pad(r % 60)}`;
+
+  // Reload when the city's date changes
+  if (loadedFor && now.date !== loadedFor) {
+    load();
+  }
+}
+
+function renderCalendar(calendar) {
+  const current = today ? today.date.gregorian.date : "";
+  document.getElementById("month-title").textContent =
+    `${calendar.days[0].date.gregorian.month.en} ${calendar.year}`;
+
+  const header = `<tr><th>Date</th><th>Hijri</th>${SHOWN.map((n) => `<th>${n}</th>`).join("")}</tr>`;
+  const rows = calendar.days.map((day, i) => {
+    const weekday = day.date.gregorian.weekday.en;
+    const classes = [
+      day.date.gregorian.date === current ? "current" : "",
+      weekday === "Friday" ? "friday" : "",
+      i > 0 && weekday === calendar.week_start ? "week-start" : "",
+    ].filter(Boolean).join(" ");
+    return `
+    <tr class="${classes}">
+      <td>${day.date.gregorian.weekday.en.slice(0, 3)} ${day.date.gregorian.day}</td>
+      <td>${day.date.hijri.day} ${day.date.hijri.month.en}</td>
+      ${SHOWN.map((n) => `<td>${clock(day.timings[n])}</td>`).join("")}
+    </tr>`;
+  }).join("");
+
+  document.getElementById("calendar").innerHTML = header + rows;
+}
+
+function showError(err) {
+  const el = document.getElementById("error");
+  el.textContent = `Error: ${err.message}`;
+  el.hidden = false;
+}
+
+async function load() {
+  try {
+    today = await fetchJSON("/api/timings");
+    loadedFor = cityNow(today.meta.timezone).date;
+    renderToday();
+    renderCalendar(await fetchJSON("/api/calendar"));
+    document.getElementById("error").hidden = true;
+  } catch (err) {
+    showError(err);
+  }
+}
+
+load();
+setInterval(() => { if (today) renderToday(); }, 1000);
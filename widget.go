@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// Targets of pray widget
+const (
+	widgetConky  = "conky"
+	widgetArgos  = "argos"
+	widgetKargos = "kargos"
+)
+
+var widgetTargets = []string{widgetConky, widgetArgos, widgetKargos}
+
+// Colors of the terminal styles, for widgets that take their own markup
+const (
+	widgetTitleColor = "#04B575"
+	widgetCityColor  = "#87CEEB"
+	widgetNextColor  = "#FFD700"
+	widgetDimColor   = "#888888"
+)
+
+func validWidgetTarget(target string) error {
+	for _, t := range widgetTargets {
+		if target == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown target %q (expected conky, argos, or kargos)", target)
+}
+
+func showWidget(ctx context.Context, city, country string, method int, cfg Config, target string) error {
+	data, err := fetchPrayerTimes(ctx, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+	now := clock.Now()
+
+	switch target {
+	case widgetConky:
+		out, err := conkyWidget(city, data.Data, now)
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+	case widgetArgos, widgetKargos:
+		out, err := bitbarWidget(city, data.Data, now, target == widgetArgos)
+		if err != nil {
+			return err
+		}
+		fmt.Print(out)
+	}
+	return nil
+}
+
+// conkyWidget is today's table in conky markup, for ${execpi 60 pray widget --target conky}:
+// the city and dates on top, then each timing aligned right, with Sunrise dimmed and the
+// next prayer and its countdown in gold.
+func conkyWidget(city string, day Data, now time.Time) (string, error) {
+	next, at, err := findNextPrayerAt(day.Timings, now)
+	if err != nil {
+		return "", err
+	}
+	timings := map[string]string{
+		"Fajr":    day.Timings.Fajr,
+		"Sunrise": day.Timings.Sunrise,
+		"Dhuhr":   day.Timings.Dhuhr,
+		"Asr":     day.Timings.Asr,
+		"Maghrib": day.Timings.Maghrib,
+		"Isha":    day.Timings.Isha,
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "${color %s}%s${color}${alignr}%s\n", widgetTitleColor, conkyEscape(city), now.Format("Mon 2 Jan"))
+	fmt.Fprintf(&b, "${color %s}${alignr}%s${color}\n", widgetCityColor, conkyEscape(plainHijriDate(day.Date.Hijri)))
+	b.WriteString("${hr}\n")
+	for _, prayer := range prayerOrder {
+		timeStr := strings.Split(timings[prayer], " ")[0]
+		switch {
+		case prayer == next:
+			fmt.Fprintf(&b, "${color %s}%s${alignr}in %s  %s${color}\n", widgetNextColor, prayer, formatDuration(at.Sub(now)), timeStr)
+		case prayer == "Sunrise":
+			fmt.Fprintf(&b, "${color %s}%s${alignr}%s${color}\n", widgetDimColor, prayer, timeStr)
+		default:
+			fmt.Fprintf(&b, "%s${alignr}%s\n", prayer, timeStr)
+		}
+	}
+	return b.String(), nil
+}
+
+// conkyEscape keeps conky from reading text as a variable.
+func conkyEscape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+// bitbarWidget is the BitBar protocol that GNOME's Argos and KDE's Kargos read from a
+// script: the next prayer in the panel, and a dropdown with today's table and an item to
+// log the current prayer. Argos reads Pango markup, so its text is escaped.
+func bitbarWidget(city string, day Data, now time.Time, markup bool) (string, error) {
+	next, at, err := findNextPrayerAt(day.Timings, now)
+	if err != nil {
+		return "", err
+	}
+	current, start, _, err := currentPrayerAt(day.Timings, now)
+	if err != nil {
+		return "", err
+	}
+	timings := map[string]string{
+		"Fajr":    day.Timings.Fajr,
+		"Sunrise": day.Timings.Sunrise,
+		"Dhuhr":   day.Timings.Dhuhr,
+		"Asr":     day.Timings.Asr,
+		"Maghrib": day.Timings.Maghrib,
+		"Isha":    day.Timings.Isha,
+	}
+	text := func(s string) string {
+		// A | starts the item's attributes
+		s = strings.ReplaceAll(s, "|", "/")
+		if markup {
+			s = xmlEscape(s)
+		}
+		return s
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "🕌 %s %s · %s\n", next, at.Format("15:04"), formatDuration(at.Sub(now)))
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "%s | color=%s\n", text(city), widgetTitleColor)
+	fmt.Fprintf(&b, "%s · %s | size=10\n", now.Format("Mon 2 Jan"), text(plainHijriDate(day.Date.Hijri)))
+	b.WriteString("---\n")
+	for _, prayer := range prayerOrder {
+		line := fmt.Sprintf("%-8s %s", prayer, strings.Split(timings[prayer], " ")[0])
+		switch {
+		case prayer == next:
+			fmt.Fprintf(&b, "%s  in %s | font=monospace color=%s\n", line, formatDuration(at.Sub(now)), widgetNextColor)
+		case prayer == "Sunrise":
+			fmt.Fprintf(&b, "%s | font=monospace color=%s\n", line, widgetDimColor)
+		default:
+			fmt.Fprintf(&b, "%s | font=monospace\n", line)
+		}
+	}
+	b.WriteString("---\n")
+	// Before Fajr the current prayer is last night's Isha, which pray log can't tell from tonight's
+	if exe, err := os.Executable(); err == nil && dayStart(start).Equal(dayStart(now)) {
+		fmt.Fprintf(&b, "Log %s as prayed | bash='%s log %s' terminal=false refresh=true\n", current, exe, strings.ToLower(current))
+	}
+	b.WriteString("Refresh | refresh=true\n")
+	return b.String(), nil
+}
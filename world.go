@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FamilyMember is someone whose next prayer pray world shows, where they live
+type FamilyMember struct {
+	Name    string `yaml:"name"`
+	City    string `yaml:"city"`
+	Country string `yaml:"country"`
+	Method  int    `yaml:"method"`
+}
+
+// Clock faces for each hour, from 1 o'clock
+var clockFaces = []string{"🕐", "🕑", "🕒", "🕓", "🕔", "🕕", "🕖", "🕗", "🕘", "🕙", "🕚", "🕛"}
+
+// clockFace is the clock emoji nearest a time's hour.
+func clockFace(t time.Time) string {
+	hour := t.Hour()
+	if t.Minute() >= 30 {
+		hour++
+	}
+	return clockFaces[(hour+11)%12]
+}
+
+// familyClock is a family member's time and next prayer, for pray world
+type familyClock struct {
+	member FamilyMember
+	now    time.Time
+	prayer string
+	at     time.Time
+	err    error
+}
+
+// familyClocks fetches everyone's timings at once and works out their next prayer, soonest
+// first, with those that failed at the end.
+func familyClocks(ctx context.Context, family []FamilyMember, country string, method int, cfg Config) []familyClock {
+	clocks := make([]familyClock, len(family))
+	var wg sync.WaitGroup
+	for i, m := range family {
+		if m.Country == "" {
+			m.Country = country
+		}
+		if m.Method == 0 {
+			m.Method = method
+		}
+		if m.Name == "" {
+			m.Name = m.City
+		}
+		wg.Add(1)
+		go func(i int, m FamilyMember) {
+			defer wg.Done()
+			c := familyClock{member: m}
+			r := fetchOfficeTimes(ctx, Office(m), cfg)
+			if c.err = r.err; c.err == nil {
+				c.now = r.now
+				c.prayer, c.at, c.err = findNextPrayerAt(r.data.Data.Timings, r.now)
+			}
+			clocks[i] = c
+		}(i, m)
+	}
+	wg.Wait()
+
+	sort.SliceStable(clocks, func(i, j int) bool {
+		if (clocks[i].err == nil) != (clocks[j].err == nil) {
+			return clocks[i].err == nil
+		}
+		return clocks[i].at.Sub(clocks[i].now) < clocks[j].at.Sub(clocks[j].now)
+	})
+	return clocks
+}
+
+// showWorld prints each family member's local time and the countdown to their next
+// prayer, world clock style, with whoever prays next at the top.
+func showWorld(ctx context.Context, country string, method int, cfg Config) error {
+	if len(cfg.Family) == 0 {
+		return fmt.Errorf("no family in the config; list them under family: with a name and city")
+	}
+	clocks := familyClocks(ctx, cfg.Family, country, method, cfg)
+
+	now := clock.Now()
+	fmt.Println(titleStyle.Render(fmt.Sprintf("🌍 Family prayer times · %s here", now.Format("Mon 15:04"))))
+	fmt.Println(strings.Repeat("━", 60))
+	for _, c := range clocks {
+		name := cityStyle.Render(padRight(c.member.Name, 14))
+		if c.err != nil {
+			fmt.Printf("  %s %s\n", name, countdownStyle.Render("⚠️  "+c.err.Error()))
+			continue
+		}
+		local := c.now.Format("15:04")
+		if daysBetween(now, c.now) != 0 {
+			local += " " + c.now.Format("Mon")
+		}
+		fmt.Printf("  %s %s %s %s\n",
+			clockFace(c.now),
+			timeStyle.Render(padRight(local, 10)),
+			name,
+			prayerStyle.UnsetPaddingLeft().Render(c.member.City))
+		fmt.Printf("       %s %s\n",
+			prayerStyle.Render(padRight(fmt.Sprintf("%s %s", prayerNames[c.prayer], c.at.Format("15:04")), 20)),
+			countdownStyle.Render("⏰ "+relativeDuration(cfg, c.at.Sub(c.now), time.Minute)))
+	}
+	return nil
+}
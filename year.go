@@ -0,0 +1,219 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// yearDay is one row of the yearly timetable, as exported to CSV and JSON
+type yearDay struct {
+	Date    string            `json:"date"`
+	Weekday string            `json:"weekday"`
+	Week    int               `json:"week"`
+	Hijri   string            `json:"hijri"`
+	Timings map[string]string `json:"timings"`
+}
+
+// fetchYear fetches the twelve monthly calendars of a year.
+func fetchYear(ctx context.Context, year int, city, country string, method int, cfg Config) ([][]Data, error) {
+	months := make([][]Data, 12)
+	for month := 1; month <= 12; month++ {
+		calendar, err := fetchCalendar(ctx, city, country, method, cfg, year, month)
+		if err != nil {
+			return nil, err
+		}
+		months[month-1] = calendar.Data
+	}
+	return months, nil
+}
+
+// showYear prints a year of timings: a month at a time with paging in a terminal, or the
+// whole year as text, CSV, or JSON for piping.
+func showYear(ctx context.Context, year int, format, city, country string, method int, cfg Config) error {
+	if format == "" && (!term.IsTerminal(os.Stdin.Fd()) || !term.IsTerminal(os.Stdout.Fd())) {
+		format = "text"
+	}
+	if format != "" && format != "text" && format != "csv" && format != "json" {
+		return fmt.Errorf("unknown format %q (expected text, csv, or json)", format)
+	}
+
+	months, err := fetchYear(ctx, year, city, country, method, cfg)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "csv":
+		return writeYearCSV(months, cfg)
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(yearDays(months, cfg))
+	case "text":
+		for i, days := range months {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(renderMonth(days, cfg))
+		}
+		return nil
+	default:
+		start := 0
+		if now := clock.Now(); now.Year() == year {
+			start = int(now.Month()) - 1
+		}
+		return pageMonths(months, start, cfg)
+	}
+}
+
+// yearDays flattens a year for export, with Hijri dates in the configured calendar. Week
+// numbers count weeks starting on the configured first day, with 1 January in week 1.
+func yearDays(months [][]Data, cfg Config) []yearDay {
+	first := cfg.firstWeekday()
+	var days []yearDay
+	for _, month := range months {
+		for _, day := range month {
+			t := day.Timings
+			hijri := cfg.hijriDate(day.Date)
+			week := 0
+			if date, err := time.Parse("02-01-2006", day.Date.Gregorian.Date); err == nil {
+				week = weekOfYear(date, first)
+			}
+			days = append(days, yearDay{
+				Date:    isoDate(day.Date.Gregorian.Date),
+				Weekday: day.Date.Gregorian.Weekday.En,
+				Week:    week,
+				Hijri:   fmt.Sprintf("%s %s %s", hijri.Day, hijri.Month.En, hijri.Year),
+				Timings: map[string]string{
+					"Fajr":    strings.Split(t.Fajr, " ")[0],
+					"Sunrise": strings.Split(t.Sunrise, " ")[0],
+					"Dhuhr":   strings.Split(t.Dhuhr, " ")[0],
+					"Asr":     strings.Split(t.Asr, " ")[0],
+					"Maghrib": strings.Split(t.Maghrib, " ")[0],
+					"Isha":    strings.Split(t.Isha, " ")[0],
+				},
+			})
+		}
+	}
+	return days
+}
+
+func writeYearCSV(months [][]Data, cfg Config) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write(append([]string{"date", "weekday", "week", "hijri"}, prayerOrder...))
+	for _, day := range yearDays(months, cfg) {
+		row := []string{day.Date, day.Weekday, strconv.Itoa(day.Week), day.Hijri}
+		for _, prayer := range prayerOrder {
+			row = append(row, day.Timings[prayer])
+		}
+		w.Write(row)
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// isoDate turns the API's DD-MM-YYYY dates into YYYY-MM-DD.
+func isoDate(date string) string {
+	t, err := time.Parse("02-01-2006", date)
+	if err != nil {
+		return date
+	}
+	return t.Format("2006-01-02")
+}
+
+// renderMonth renders a month as a table, with today and Fridays highlighted and a rule
+// before each week, with day and month names in the configured language. Hijri days the
+// two calendars disagree on are starred.
+func renderMonth(days []Data, cfg Config) string {
+	if len(days) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	first := days[0].Date.Gregorian
+	b.WriteString(titleStyle.Render("📅 "+cfg.monthYear(first)) + "\n")
+	b.WriteString(strings.Repeat("━", 62) + "\n")
+	// Arabic day names don't abbreviate, so they get a wider column
+	labelWidth := 14
+	if cfg.arabicDates() {
+		labelWidth = 18
+	}
+	b.WriteString(prayerStyle.Render(fmt.Sprintf("%s %-7s %-7s %-7s %-7s %-7s %-7s", padRight("Day · Hijri", labelWidth), "Fajr", "Sunrise", "Dhuhr", "Asr", "Maghrib", "Isha")) + "\n")
+
+	today := clock.Now().Format("02-01-2006")
+	starred := false
+	for i, day := range days {
+		weekday := day.Date.Gregorian.Weekday.En
+		if i > 0 && weekday == cfg.firstWeekday().String() {
+			b.WriteString(prayerStyle.Render(strings.Repeat("┄", 60)) + "\n")
+		}
+		t := day.Timings
+		hijri, other := cfg.hijriDates(day.Date)
+		label := cfg.dateDigits(fmt.Sprintf("%s %s · %s", cfg.shortWeekday(day.Date.Gregorian), day.Date.Gregorian.Day, hijri.Day))
+		if other != nil {
+			label += "*"
+			starred = true
+		}
+		row := fmt.Sprintf("%s %-7s %-7s %-7s %-7s %-7s %-7s", padRight(label, labelWidth),
+			strings.Split(t.Fajr, " ")[0], strings.Split(t.Sunrise, " ")[0], strings.Split(t.Dhuhr, " ")[0],
+			strings.Split(t.Asr, " ")[0], strings.Split(t.Maghrib, " ")[0], strings.Split(t.Isha, " ")[0])
+		switch {
+		case day.Date.Gregorian.Date == today:
+			b.WriteString(nextPrayerStyle.Render(row) + "\n")
+		case weekday == time.Friday.String():
+			b.WriteString(cityStyle.PaddingLeft(2).Render(row) + "\n")
+		default:
+			b.WriteString(prayerStyle.Render(row) + "\n")
+		}
+	}
+	if starred {
+		b.WriteString(prayerStyle.Render(tr(cfg.Language, "hijri_differs", tr(cfg.Language, cfg.otherHijriCalendar()))) + "\n")
+	}
+	return b.String()
+}
+
+// pageMonths shows one month at a time: arrows, n/p, or h/l to move, q or Esc to quit.
+func pageMonths(months [][]Data, month int, cfg Config) error {
+	fd := os.Stdin.Fd()
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("failed to enter raw mode: %v", err)
+	}
+	defer term.Restore(fd, state)
+
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer fmt.Print("\x1b[?25h\x1b[?1049l")
+
+	buf := make([]byte, 8)
+	for {
+		// Raw mode disables output processing, so lines end with \r\n
+		screen := renderMonth(months[month], cfg) + "\n" + cityStyle.Render("← p  previous · next  n →  · q quit") + "\n"
+		fmt.Print("\x1b[H\x1b[2J" + strings.ReplaceAll(screen, "\n", "\r\n"))
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil || n == 0 {
+			return nil
+		}
+		key := string(buf[:n])
+		switch key {
+		case "q", "Q", "\x1b", "\x03", "\x04":
+			return nil
+		case "n", "l", " ", "\x1b[C", "\x1b[B":
+			if month < len(months)-1 {
+				month++
+			}
+		case "p", "h", "\x1b[D", "\x1b[A":
+			if month > 0 {
+				month--
+			}
+		}
+	}
+}